@@ -4,10 +4,12 @@ import (
 	"context"
 	"fmt"
 	"log"
+	"net"
 	"net/http"
 	"os"
 	"os/signal"
 	"syscall"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	swaggerFiles "github.com/swaggo/files"
@@ -19,9 +21,13 @@ import (
 	_ "go-pack-calculator/docs" // Import swagger docs
 	"go-pack-calculator/internal/adapters/primary/rest"
 	"go-pack-calculator/internal/adapters/secondary/inmemory"
+	"go-pack-calculator/internal/adapters/secondary/packingslip"
 	"go-pack-calculator/internal/adapters/secondary/postgres"
+	"go-pack-calculator/internal/adapters/secondary/publisher"
 	"go-pack-calculator/internal/application/services"
+	"go-pack-calculator/internal/application/usecases"
 	"go-pack-calculator/internal/ports/secondary"
+	"go-pack-calculator/internal/shared/types"
 )
 
 // @title           Pack Calculator API
@@ -52,8 +58,9 @@ func main() {
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	// Initialize router
+	// Initialize router, attributing the changes of each request to its actor and request ID
 	r := gin.Default()
+	r.Use(rest.RequestInfo())
 
 	// Create global variables
 	var (
@@ -67,13 +74,25 @@ func main() {
 		postgresDBSSLMode  = cfg.PostgresDBSSLMode
 	)
 
-	// Initialize repository
+	// Initialize repositories
 	var packSizeRepository secondary.PackSizeRepository
+	var packSizeAuditRepository secondary.PackSizeAuditRepository
+	var transactions secondary.TransactionManager
+	var outboxRepository secondary.OutboxRepository
+	var calculationRepository secondary.CalculationRepository
+	var shippingRateRepository secondary.ShippingRateRepository
+	var packingTableRepository secondary.PackingTableRepository = inmemory.NewPackingTableRepository()
 
 	// Connect to PostgresDB in production, use in-memory repository in test
 	if cfg.Environment == "test" {
 		log.Println("Using in-memory repository for testing")
-		packSizeRepository = inmemory.NewPackSizeRepository()
+		inMemoryPackSizeRepository := inmemory.NewPackSizeRepository()
+		packSizeRepository = inMemoryPackSizeRepository
+		packSizeAuditRepository = inmemory.NewPackSizeAuditRepository(inMemoryPackSizeRepository)
+		transactions = inmemory.NewTransactionManager(inMemoryPackSizeRepository)
+		outboxRepository = inmemory.NewOutboxRepository(inMemoryPackSizeRepository)
+		calculationRepository = inmemory.NewCalculationRepository()
+		shippingRateRepository = inmemory.NewShippingRateRepository()
 	} else {
 		// Connect to PostgresDB
 		err = db.NewPostgresDB(
@@ -88,18 +107,92 @@ func main() {
 			log.Fatalf("Failed to connect to database: %v", err)
 		}
 
-		// Create repository using GORM
+		// Create repositories using GORM
 		packSizeRepository = postgres.NewPackSizeRepository(db.PostgresDB)
+		packSizeAuditRepository = postgres.NewPackSizeAuditRepository(db.PostgresDB)
+		transactions = postgres.NewTransactionManager(db.PostgresDB)
+		outboxRepository = postgres.NewOutboxRepository(db.PostgresDB)
+		calculationRepository = postgres.NewCalculationRepository(db.PostgresDB)
+		shippingRateRepository = postgres.NewShippingRateRepository(db.PostgresDB)
+		if cfg.PackingTablePersist {
+			packingTableRepository = postgres.NewPackingTableRepository(db.PostgresDB)
+		}
 	}
 
 	// Initialize application service
-	packCalculatorService := services.NewPackCalculatorService(packSizeRepository)
+	packCalculatorService := services.NewPackCalculatorService(
+		packSizeRepository,
+		transactions,
+		calculationRepository,
+		shippingRateRepository,
+		packingslip.NewPDFRenderer(),
+		packingslip.NewTextRenderer(),
+	)
+
+	packCalculatorService.SetCalculationLimits(usecases.CalculationLimits{
+		MaxMemoryBytes: int64(cfg.CalculationMaxMemoryMB) << 20,
+		MaxDuration:    cfg.CalculationMaxDuration,
+	})
+
+	// Precompute the packings of quantities up to the ceiling, again whenever the pack sizes change
+	packCalculatorService.EnablePackingTable(packingTableRepository, cfg.PackingTableCeiling)
+	rebuildPackingTable := func() {
+		table, err := packCalculatorService.RebuildPackingTable(context.Background())
+		if err != nil {
+			log.Printf("Packing table not available: %v\n", err)
+			return
+		}
+		log.Printf("Packing table ready for pack sizes %s up to %d items (%d KB)\n", table.Key(), table.Ceiling, table.MemoryBytes()>>10)
+	}
+	rebuildPackingTable()
+	packCalculatorService.OnPackSizesChanged(func() { go rebuildPackingTable() })
+
+	// Bound the number of concurrent calculations
+	calculationService := services.NewCalculationWorkerPool(
+		packCalculatorService,
+		packCalculatorService,
+		cfg.CalculationWorkers,
+		cfg.CalculationQueueTimeout,
+		cfg.CalculationRetryAfter,
+	)
+
+	// Share a single calculation between concurrent identical requests
+	calculationDeduplicator := services.NewCalculationDeduplicator(calculationService)
+	packCalculatorService.OnPackSizesChanged(calculationDeduplicator.Invalidate)
+	packCalculatorService.OnShippingRatesChanged(calculationDeduplicator.Invalidate)
+
+	// Serve repeated calculations from a cache cleared whenever the pack sizes or the shipping
+	// rates the results are estimated with change
+	calculationCache := services.NewCalculationCache(calculationDeduplicator, cfg.CalculationCacheSize, cfg.CalculationCacheTTL)
+	packCalculatorService.OnPackSizesChanged(calculationCache.Invalidate)
+	packCalculatorService.OnShippingRatesChanged(calculationCache.Invalidate)
+
+	// Pack sizes starting or ending their validity period change the set calculations use as well
+	if err := packCalculatorService.WatchPackSizeValidity(context.Background()); err != nil {
+		log.Printf("Pack size validity periods not watched: %v\n", err)
+	}
 
 	// Initialize REST handler
-	packCalculatorHandler := rest.NewPackCalculatorHandler(packCalculatorService, packCalculatorService)
+	packCalculatorHandler := rest.NewPackCalculatorHandler(packCalculatorService, calculationCache)
+	packCalculatorHandler.SetRequireIfMatch(cfg.PackSizeRequireIfMatch)
+
+	shippingRateHandler := rest.NewShippingRateHandler(packCalculatorService)
+
+	calculationCacheHandler := rest.NewCalculationCacheHandler(calculationCache)
+
+	packSetComparisonHandler := rest.NewPackSetComparisonHandler(calculationService)
+
+	packSizeAuditHandler := rest.NewPackSizeAuditHandler(services.NewPackSizeAuditService(packSizeAuditRepository, packSizeRepository))
+
+	packSizeTransferHandler := rest.NewPackSizeTransferHandler(packCalculatorService)
 
 	// Register REST API routes
 	packCalculatorHandler.RegisterRoutes(r)
+	shippingRateHandler.RegisterRoutes(r)
+	calculationCacheHandler.RegisterRoutes(r)
+	packSetComparisonHandler.RegisterRoutes(r)
+	packSizeAuditHandler.RegisterRoutes(r)
+	packSizeTransferHandler.RegisterRoutes(r)
 
 	// Serve static files
 	r.Static("/static", "./static")
@@ -117,11 +210,101 @@ func main() {
 		c.JSON(http.StatusOK, gin.H{"status": "ok"})
 	})
 
+	// Requests derive their context from this one, so in-flight calculations and
+	// queries are canceled when the server does not shut down in time
+	baseCtx, cancelRequests := context.WithCancel(context.Background())
+	defer cancelRequests()
+
+	// Permanently remove the calculation results stored longer than the retention
+	go func() {
+		ticker := time.NewTicker(cfg.CalculationPurgeInterval)
+		defer ticker.Stop()
+
+		for {
+			purged, err := packCalculatorService.PurgeCalculations(baseCtx, cfg.CalculationRetention)
+			if err != nil {
+				log.Printf("Purging calculations failed: %v\n", err)
+			} else if purged > 0 {
+				log.Printf("Purged %d calculations\n", purged)
+			}
+
+			select {
+			case <-baseCtx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	// Permanently remove the pack sizes that have been in the trash longer than the retention,
+	// attributing the purges to the job in the audit trail
+	go func() {
+		ticker := time.NewTicker(cfg.PackSizePurgeInterval)
+		defer ticker.Stop()
+
+		purgeCtx := types.WithRequestInfo(baseCtx, types.RequestInfo{ClaimedActor: "trash-purge"})
+		for {
+			purged, err := packCalculatorService.PurgeDeletedPackSizes(purgeCtx, cfg.PackSizeTrashRetention)
+			if err != nil {
+				log.Printf("Purging deleted pack sizes failed: %v\n", err)
+			} else if purged > 0 {
+				log.Printf("Purged %d deleted pack sizes\n", purged)
+			}
+
+			select {
+			case <-baseCtx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
+	// Deliver the pack size events saved in the outbox with the changes to the publishers
+	publishers, err := newEventPublishers(cfg)
+	if err != nil {
+		log.Fatalf("Error configuring event publishers: %v", err)
+	}
+	outboxRelayService := services.NewOutboxRelayService(
+		outboxRepository,
+		usecases.RetryPolicy{
+			MaxAttempts: cfg.OutboxMaxAttempts,
+			Backoff:     cfg.OutboxRetryBackoff,
+			MaxBackoff:  cfg.OutboxMaxRetryBackoff,
+		},
+		cfg.OutboxBatchSize,
+		// Claimed entries stay hidden while the whole batch may still be publishing
+		cfg.OutboxPublishTimeout*time.Duration(cfg.OutboxBatchSize*max(len(publishers), 1)),
+		publishers...,
+	)
+	go func() {
+		ticker := time.NewTicker(cfg.OutboxRelayInterval)
+		defer ticker.Stop()
+
+		for {
+			result, err := outboxRelayService.RelayEvents(baseCtx)
+			if err != nil {
+				log.Printf("Relaying pack size events failed: %v\n", err)
+			}
+			if result.Retried > 0 || result.Dead > 0 {
+				log.Printf("Relayed %d pack size events, %d failed and will be retried, %d given up\n", result.Delivered, result.Retried, result.Dead)
+			}
+
+			select {
+			case <-baseCtx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+
 	// Create a new server with the router
 	server := &http.Server{
 		Addr:              addr,
 		Handler:           r,
 		ReadHeaderTimeout: constants.ReadHeaderTimeout,
+		BaseContext: func(net.Listener) context.Context {
+			return baseCtx
+		},
 	}
 
 	// Create a channel to listen for OS signals
@@ -150,7 +333,38 @@ func main() {
 	// Attempt graceful shutdown
 	if err := server.Shutdown(ctx); err != nil {
 		log.Printf("Server forced to shutdown: %v\n", err)
+		cancelRequests()
 	}
 
 	log.Println("Server gracefully stopped")
 }
+
+// newEventPublishers creates the configured publishers of pack size events
+func newEventPublishers(cfg *config.Config) ([]secondary.EventPublisher, error) {
+	publishers := make([]secondary.EventPublisher, 0, len(cfg.OutboxPublishers))
+	for _, name := range cfg.OutboxPublishers {
+		switch name {
+		case "log":
+			publishers = append(publishers, publisher.NewLogPublisher(nil))
+		case "webhook":
+			if cfg.OutboxWebhookURL == "" {
+				return nil, fmt.Errorf("OUTBOX_WEBHOOK_URL is required by the webhook publisher")
+			}
+			client := &http.Client{Timeout: cfg.OutboxPublishTimeout}
+			publishers = append(publishers, publisher.NewWebhookPublisher(cfg.OutboxWebhookURL, cfg.OutboxWebhookSecret, client))
+		case "nats":
+			if cfg.OutboxNATSURL == "" {
+				return nil, fmt.Errorf("OUTBOX_NATS_URL is required by the nats publisher")
+			}
+			natsPublisher, err := publisher.NewNATSPublisher(cfg.OutboxNATSURL, cfg.OutboxNATSSubject, cfg.OutboxNATSCredentials, cfg.OutboxPublishTimeout)
+			if err != nil {
+				return nil, err
+			}
+			publishers = append(publishers, natsPublisher)
+		default:
+			return nil, fmt.Errorf("unknown event publisher %q, expected log, webhook or nats", name)
+		}
+	}
+
+	return publishers, nil
+}
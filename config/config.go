@@ -4,6 +4,9 @@ import (
 	"errors"
 	"fmt"
 	"go-pack-calculator/constants"
+	"runtime"
+	"strings"
+	"time"
 
 	"github.com/spf13/viper"
 )
@@ -20,6 +23,44 @@ type Config struct {
 	PostgresDBPassword string
 	PostgresDBName     string
 	PostgresDBSSLMode  string
+
+	// Calculation budget and worker pool
+	CalculationMaxMemoryMB  int
+	CalculationMaxDuration  time.Duration
+	CalculationWorkers      int
+	CalculationQueueTimeout time.Duration
+	CalculationRetryAfter   time.Duration
+	CalculationCacheSize    int
+	CalculationCacheTTL     time.Duration
+
+	// Stored calculation results
+	CalculationRetention     time.Duration
+	CalculationPurgeInterval time.Duration
+
+	// Precomputed packing table
+	PackingTableCeiling int
+	PackingTablePersist bool
+
+	// Pack size trash
+	PackSizeTrashRetention time.Duration
+	PackSizePurgeInterval  time.Duration
+
+	// Reject pack size updates and deletions without an If-Match header
+	PackSizeRequireIfMatch bool
+
+	// Delivery of pack size events from the outbox
+	OutboxPublishers      []string
+	OutboxRelayInterval   time.Duration
+	OutboxBatchSize       int
+	OutboxMaxAttempts     int
+	OutboxRetryBackoff    time.Duration
+	OutboxMaxRetryBackoff time.Duration
+	OutboxPublishTimeout  time.Duration
+	OutboxWebhookURL      string
+	OutboxWebhookSecret   string
+	OutboxNATSURL         string
+	OutboxNATSSubject     string
+	OutboxNATSCredentials string
 }
 
 // LoadConfig loads the configuration from environment variables and .env file
@@ -49,6 +90,111 @@ func Load() (*Config, error) {
 		PostgresDBPassword: viper.GetString("POSTGRES_DB_PASSWORD"),
 		PostgresDBName:     viper.GetString("POSTGRES_DB_NAME"),
 		PostgresDBSSLMode:  viper.GetString("POSTGRES_DB_SSLMODE"),
+
+		CalculationMaxMemoryMB:  viper.GetInt("CALCULATION_MAX_MEMORY_MB"),
+		CalculationMaxDuration:  viper.GetDuration("CALCULATION_MAX_DURATION"),
+		CalculationWorkers:      viper.GetInt("CALCULATION_WORKERS"),
+		CalculationQueueTimeout: viper.GetDuration("CALCULATION_QUEUE_TIMEOUT"),
+		CalculationRetryAfter:   viper.GetDuration("CALCULATION_RETRY_AFTER"),
+		CalculationCacheSize:    viper.GetInt("CALCULATION_CACHE_SIZE"),
+		CalculationCacheTTL:     viper.GetDuration("CALCULATION_CACHE_TTL"),
+
+		CalculationRetention:     viper.GetDuration("CALCULATION_RETENTION"),
+		CalculationPurgeInterval: viper.GetDuration("CALCULATION_PURGE_INTERVAL"),
+
+		PackingTableCeiling: viper.GetInt("PACKING_TABLE_CEILING"),
+		PackingTablePersist: viper.GetBool("PACKING_TABLE_PERSIST"),
+
+		PackSizeTrashRetention: viper.GetDuration("PACK_SIZE_TRASH_RETENTION"),
+		PackSizePurgeInterval:  viper.GetDuration("PACK_SIZE_PURGE_INTERVAL"),
+
+		PackSizeRequireIfMatch: viper.GetBool("PACK_SIZE_REQUIRE_IF_MATCH"),
+
+		OutboxRelayInterval:   viper.GetDuration("OUTBOX_RELAY_INTERVAL"),
+		OutboxBatchSize:       viper.GetInt("OUTBOX_BATCH_SIZE"),
+		OutboxMaxAttempts:     viper.GetInt("OUTBOX_MAX_ATTEMPTS"),
+		OutboxRetryBackoff:    viper.GetDuration("OUTBOX_RETRY_BACKOFF"),
+		OutboxMaxRetryBackoff: viper.GetDuration("OUTBOX_MAX_RETRY_BACKOFF"),
+		OutboxPublishTimeout:  viper.GetDuration("OUTBOX_PUBLISH_TIMEOUT"),
+		OutboxWebhookURL:      viper.GetString("OUTBOX_WEBHOOK_URL"),
+		OutboxWebhookSecret:   viper.GetString("OUTBOX_WEBHOOK_SECRET"),
+		OutboxNATSURL:         viper.GetString("OUTBOX_NATS_URL"),
+		OutboxNATSSubject:     viper.GetString("OUTBOX_NATS_SUBJECT"),
+		OutboxNATSCredentials: viper.GetString("OUTBOX_NATS_CREDENTIALS"),
+	}
+
+	// Publishers are a comma-separated list, "none" for no publisher at all
+	publishers := viper.GetString("OUTBOX_PUBLISHERS")
+	if publishers == "" {
+		publishers = constants.DefaultOutboxPublishers
+	}
+	for _, publisher := range strings.Split(publishers, ",") {
+		if publisher = strings.TrimSpace(publisher); publisher != "" && publisher != "none" {
+			config.OutboxPublishers = append(config.OutboxPublishers, publisher)
+		}
+	}
+
+	// Fall back to the defaults for unset calculation settings
+	if config.CalculationMaxMemoryMB <= 0 {
+		config.CalculationMaxMemoryMB = constants.DefaultCalculationMaxMemoryMB
+	}
+	if config.CalculationMaxDuration <= 0 {
+		config.CalculationMaxDuration = constants.DefaultCalculationMaxDuration
+	}
+	if config.CalculationWorkers <= 0 {
+		config.CalculationWorkers = runtime.NumCPU()
+	}
+	if config.CalculationQueueTimeout <= 0 {
+		config.CalculationQueueTimeout = constants.DefaultCalculationQueueTimeout
+	}
+	if config.CalculationRetryAfter <= 0 {
+		config.CalculationRetryAfter = constants.DefaultCalculationRetryAfter
+	}
+	if config.CalculationCacheSize <= 0 {
+		config.CalculationCacheSize = constants.DefaultCalculationCacheSize
+	}
+	if config.PackingTableCeiling <= 0 {
+		config.PackingTableCeiling = constants.DefaultPackingTableCeiling
+	}
+	if config.CalculationRetention <= 0 {
+		config.CalculationRetention = constants.DefaultCalculationRetention
+	}
+	if config.CalculationPurgeInterval <= 0 {
+		config.CalculationPurgeInterval = constants.DefaultCalculationPurgeInterval
+	}
+	if config.CalculationCacheTTL <= 0 {
+		config.CalculationCacheTTL = constants.DefaultCalculationCacheTTL
+	}
+	// A cached result is a stored calculation, it must expire long before the calculation is purged
+	if config.CalculationCacheTTL > config.CalculationRetention/2 {
+		config.CalculationCacheTTL = config.CalculationRetention / 2
+	}
+	if config.PackSizeTrashRetention <= 0 {
+		config.PackSizeTrashRetention = constants.DefaultPackSizeTrashRetention
+	}
+	if config.PackSizePurgeInterval <= 0 {
+		config.PackSizePurgeInterval = constants.DefaultPackSizePurgeInterval
+	}
+	if config.OutboxRelayInterval <= 0 {
+		config.OutboxRelayInterval = constants.DefaultOutboxRelayInterval
+	}
+	if config.OutboxBatchSize <= 0 {
+		config.OutboxBatchSize = constants.DefaultOutboxBatchSize
+	}
+	if config.OutboxMaxAttempts <= 0 {
+		config.OutboxMaxAttempts = constants.DefaultOutboxMaxAttempts
+	}
+	if config.OutboxRetryBackoff <= 0 {
+		config.OutboxRetryBackoff = constants.DefaultOutboxRetryBackoff
+	}
+	if config.OutboxMaxRetryBackoff <= 0 {
+		config.OutboxMaxRetryBackoff = constants.DefaultOutboxMaxRetryBackoff
+	}
+	if config.OutboxPublishTimeout <= 0 {
+		config.OutboxPublishTimeout = constants.DefaultOutboxPublishTimeout
+	}
+	if config.OutboxNATSSubject == "" {
+		config.OutboxNATSSubject = constants.DefaultOutboxNATSSubject
 	}
 
 	return config, nil
@@ -16,3 +16,41 @@ const ReadHeaderTimeout = 10 * time.Second
 const ShutdownTimeout = 10 * time.Second
 
 const GormLoggerSlowThreshold = 200 * time.Millisecond
+
+const DefaultCalculationMaxMemoryMB = 512
+
+const DefaultCalculationMaxDuration = 5 * time.Second
+
+const DefaultCalculationQueueTimeout = 2 * time.Second
+
+const DefaultCalculationRetryAfter = 5 * time.Second
+
+const DefaultCalculationCacheSize = 1024
+
+const DefaultCalculationCacheTTL = time.Hour
+
+const DefaultCalculationRetention = 7 * 24 * time.Hour
+
+const DefaultCalculationPurgeInterval = time.Hour
+
+const DefaultPackingTableCeiling = 100000
+
+const DefaultPackSizeTrashRetention = 30 * 24 * time.Hour
+
+const DefaultPackSizePurgeInterval = time.Hour
+
+const DefaultOutboxPublishers = "log"
+
+const DefaultOutboxRelayInterval = 5 * time.Second
+
+const DefaultOutboxBatchSize = 100
+
+const DefaultOutboxMaxAttempts = 10
+
+const DefaultOutboxRetryBackoff = time.Second
+
+const DefaultOutboxMaxRetryBackoff = time.Hour
+
+const DefaultOutboxPublishTimeout = 10 * time.Second
+
+const DefaultOutboxNATSSubject = "pack_sizes"
@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Calculation model for migration
+type Calculation struct {
+	ID           string `gorm:"primaryKey;type:varchar(255)"`
+	ItemsOrdered int    `gorm:"not null"`
+	TotalItems   int    `gorm:"not null"`
+	Packs        string `gorm:"type:jsonb;not null"`
+	CreatedAt    time.Time
+}
+
+// TableName specifies the table name for the model
+func (Calculation) TableName() string {
+	return "calculations"
+}
+
+func init() {
+	Register(Migration{
+		Version: "002_create_calculations",
+		Up: func(db *gorm.DB) error {
+			// Create calculations table
+			return db.AutoMigrate(&Calculation{})
+		},
+	})
+}
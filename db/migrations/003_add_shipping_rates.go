@@ -0,0 +1,45 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ShippingRate model for migration
+type ShippingRate struct {
+	ID         string  `gorm:"primaryKey;type:varchar(255)"`
+	Carrier    string  `gorm:"not null;index"`
+	MaxWeight  float64 `gorm:"not null"`
+	MaxParcels int     `gorm:"not null"`
+	Price      float64 `gorm:"not null"`
+	CreatedAt  time.Time
+}
+
+// TableName specifies the table name for the model
+func (ShippingRate) TableName() string {
+	return "shipping_rates"
+}
+
+func init() {
+	Register(Migration{
+		Version: "003_add_shipping_rates",
+		Up: func(db *gorm.DB) error {
+			// Add pack weights
+			if err := db.Exec("ALTER TABLE pack_sizes ADD COLUMN IF NOT EXISTS weight double precision NOT NULL DEFAULT 0").Error; err != nil {
+				return err
+			}
+
+			// Store the weight and shipping estimates of calculations
+			if err := db.Exec("ALTER TABLE calculations ADD COLUMN IF NOT EXISTS total_weight double precision NOT NULL DEFAULT 0").Error; err != nil {
+				return err
+			}
+			if err := db.Exec("ALTER TABLE calculations ADD COLUMN IF NOT EXISTS shipping_estimates jsonb").Error; err != nil {
+				return err
+			}
+
+			// Create shipping_rates table
+			return db.AutoMigrate(&ShippingRate{})
+		},
+	})
+}
@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+func init() {
+	Register(Migration{
+		Version: "004_add_packaging_footprint",
+		Up: func(db *gorm.DB) error {
+			statements := []string{
+				// Add packaging material and emission factors of pack sizes
+				"ALTER TABLE pack_sizes ADD COLUMN IF NOT EXISTS material_weight double precision NOT NULL DEFAULT 0",
+				"ALTER TABLE pack_sizes ADD COLUMN IF NOT EXISTS emission_factor double precision NOT NULL DEFAULT 0",
+				// Store the packaging footprint of calculations
+				"ALTER TABLE calculations ADD COLUMN IF NOT EXISTS material_weight double precision NOT NULL DEFAULT 0",
+				"ALTER TABLE calculations ADD COLUMN IF NOT EXISTS emissions double precision NOT NULL DEFAULT 0",
+			}
+
+			for _, statement := range statements {
+				if err := db.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	})
+}
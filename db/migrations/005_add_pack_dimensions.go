@@ -0,0 +1,22 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+func init() {
+	Register(Migration{
+		Version: "005_add_pack_dimensions",
+		Up: func(db *gorm.DB) error {
+			// Add the outer dimensions of pack sizes
+			for _, column := range []string{"length", "width", "height"} {
+				statement := "ALTER TABLE pack_sizes ADD COLUMN IF NOT EXISTS " + column + " double precision NOT NULL DEFAULT 0"
+				if err := db.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	})
+}
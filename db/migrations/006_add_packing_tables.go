@@ -0,0 +1,31 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PackingTable model for migration
+type PackingTable struct {
+	SizeSet   string `gorm:"primaryKey;type:varchar(255)"`
+	Ceiling   int    `gorm:"not null"`
+	Index     []byte `gorm:"not null"`
+	Rows      []byte `gorm:"not null"`
+	CreatedAt time.Time
+}
+
+// TableName specifies the table name for the model
+func (PackingTable) TableName() string {
+	return "packing_tables"
+}
+
+func init() {
+	Register(Migration{
+		Version: "006_add_packing_tables",
+		Up: func(db *gorm.DB) error {
+			// Create packing_tables table
+			return db.AutoMigrate(&PackingTable{})
+		},
+	})
+}
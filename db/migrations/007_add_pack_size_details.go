@@ -0,0 +1,29 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+func init() {
+	Register(Migration{
+		Version: "007_add_pack_size_details",
+		Up: func(db *gorm.DB) error {
+			statements := []string{
+				// Add the label, SKU and tags of pack sizes
+				"ALTER TABLE pack_sizes ADD COLUMN IF NOT EXISTS name varchar(100) NOT NULL DEFAULT ''",
+				"ALTER TABLE pack_sizes ADD COLUMN IF NOT EXISTS sku varchar(64) NOT NULL DEFAULT ''",
+				"ALTER TABLE pack_sizes ADD COLUMN IF NOT EXISTS tags jsonb NOT NULL DEFAULT '[]'",
+				// Existing pack sizes stay in use
+				"ALTER TABLE pack_sizes ADD COLUMN IF NOT EXISTS active boolean NOT NULL DEFAULT true",
+			}
+
+			for _, statement := range statements {
+				if err := db.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	})
+}
@@ -0,0 +1,40 @@
+package migrations
+
+import (
+	"log"
+
+	"gorm.io/gorm"
+)
+
+func init() {
+	Register(Migration{
+		Version: "008_add_unique_pack_size",
+		Up: func(db *gorm.DB) error {
+			// Move the existing duplicates to the trash, keeping the oldest pack size of each size,
+			// so they can still be reviewed and restored
+			var duplicates []struct {
+				ID   string
+				Size int
+			}
+			err := db.Raw(`UPDATE pack_sizes SET deleted_at = now()
+				WHERE id IN (
+					SELECT id FROM (
+						SELECT id, row_number() OVER (PARTITION BY size ORDER BY created_at, id) AS position
+						FROM pack_sizes
+						WHERE deleted_at IS NULL
+					) ranked
+					WHERE position > 1
+				)
+				RETURNING id, size`).Scan(&duplicates).Error
+			if err != nil {
+				return err
+			}
+			for _, duplicate := range duplicates {
+				log.Printf("Moved duplicate pack size %s of size %d to the trash\n", duplicate.ID, duplicate.Size)
+			}
+
+			// Allow one pack size per size among the rows that are not deleted
+			return db.Exec("CREATE UNIQUE INDEX IF NOT EXISTS idx_pack_sizes_size_unique ON pack_sizes (size) WHERE deleted_at IS NULL").Error
+		},
+	})
+}
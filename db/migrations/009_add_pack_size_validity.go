@@ -0,0 +1,26 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+func init() {
+	Register(Migration{
+		Version: "009_add_pack_size_validity",
+		Up: func(db *gorm.DB) error {
+			statements := []string{
+				// Add the validity period of pack sizes, existing pack sizes stay valid indefinitely
+				"ALTER TABLE pack_sizes ADD COLUMN IF NOT EXISTS valid_from timestamptz",
+				"ALTER TABLE pack_sizes ADD COLUMN IF NOT EXISTS valid_to timestamptz",
+			}
+
+			for _, statement := range statements {
+				if err := db.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	})
+}
@@ -0,0 +1,59 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// PackSizeAudit model for migration. There is no foreign key to pack_sizes so the entries of
+// purged pack sizes are kept. The claimed actor and request ID come from request headers, bounded
+// to the size of their columns.
+type PackSizeAudit struct {
+	ID           string    `gorm:"primaryKey;type:varchar(255)"`
+	PackSizeID   string    `gorm:"not null;index;type:varchar(255)"`
+	Action       string    `gorm:"not null;type:varchar(20)"`
+	ClaimedActor string    `gorm:"not null;default:'';type:varchar(255)"`
+	RequestID    string    `gorm:"not null;default:'';type:varchar(255)"`
+	Before       []byte    `gorm:"type:jsonb"`
+	After        []byte    `gorm:"type:jsonb"`
+	Timestamp    time.Time `gorm:"not null;index"`
+}
+
+// TableName specifies the table name for the model
+func (PackSizeAudit) TableName() string {
+	return "pack_size_audit"
+}
+
+func init() {
+	Register(Migration{
+		Version: "010_create_pack_size_audit",
+		Up: func(db *gorm.DB) error {
+			// Create pack_size_audit table
+			if err := db.AutoMigrate(&PackSizeAudit{}); err != nil {
+				return err
+			}
+
+			statements := []string{
+				// Reject any change to the audit entries once written
+				`CREATE OR REPLACE FUNCTION reject_pack_size_audit_change() RETURNS trigger AS $$
+				BEGIN
+					RAISE EXCEPTION 'pack size audit entries are immutable';
+				END;
+				$$ LANGUAGE plpgsql`,
+				"DROP TRIGGER IF EXISTS pack_size_audit_immutable ON pack_size_audit",
+				`CREATE TRIGGER pack_size_audit_immutable
+					BEFORE UPDATE OR DELETE ON pack_size_audit
+					FOR EACH ROW EXECUTE FUNCTION reject_pack_size_audit_change()`,
+			}
+
+			for _, statement := range statements {
+				if err := db.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	})
+}
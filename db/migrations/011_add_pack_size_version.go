@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+func init() {
+	Register(Migration{
+		Version: "011_add_pack_size_version",
+		Up: func(db *gorm.DB) error {
+			statements := []string{
+				// Add the version incremented by every change of a pack size, for optimistic concurrency
+				"ALTER TABLE pack_sizes ADD COLUMN IF NOT EXISTS version bigint NOT NULL DEFAULT 1",
+			}
+
+			for _, statement := range statements {
+				if err := db.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	})
+}
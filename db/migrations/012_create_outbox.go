@@ -0,0 +1,52 @@
+package migrations
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// Outbox model for migration. The entries wait for the relay to deliver their event to the
+// publishers and are removed once delivered.
+type Outbox struct {
+	ID            string     `gorm:"primaryKey;type:varchar(255)"`
+	Type          string     `gorm:"not null;type:varchar(50)"`
+	PackSizeID    string     `gorm:"not null;index;type:varchar(255)"`
+	Event         []byte     `gorm:"not null;type:jsonb"`
+	Attempts      int        `gorm:"not null;default:0"`
+	NextAttemptAt time.Time  `gorm:"not null;index"`
+	LastError     string     `gorm:"not null;default:''"`
+	DeadAt        *time.Time `gorm:"index"`
+	OccurredAt    time.Time  `gorm:"not null"`
+}
+
+// TableName specifies the table name for the model
+func (Outbox) TableName() string {
+	return "outbox"
+}
+
+func init() {
+	Register(Migration{
+		Version: "012_create_outbox",
+		Up: func(db *gorm.DB) error {
+			// Create outbox table
+			if err := db.AutoMigrate(&Outbox{}); err != nil {
+				return err
+			}
+
+			statements := []string{
+				// Deliver the entries in the order they were added
+				"ALTER TABLE outbox ADD COLUMN IF NOT EXISTS position bigserial",
+				"CREATE UNIQUE INDEX IF NOT EXISTS idx_outbox_position ON outbox (position)",
+			}
+
+			for _, statement := range statements {
+				if err := db.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	})
+}
@@ -0,0 +1,25 @@
+package migrations
+
+import (
+	"gorm.io/gorm"
+)
+
+func init() {
+	Register(Migration{
+		Version: "014_add_calculation_created_at_index",
+		Up: func(db *gorm.DB) error {
+			statements := []string{
+				// Find the calculations past the retention without scanning the table
+				"CREATE INDEX IF NOT EXISTS idx_calculations_created_at ON calculations (created_at)",
+			}
+
+			for _, statement := range statements {
+				if err := db.Exec(statement).Error; err != nil {
+					return err
+				}
+			}
+
+			return nil
+		},
+	})
+}
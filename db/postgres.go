@@ -72,6 +72,8 @@ func (p *PostgresDBConfig) Connect() error {
 	for i := 0; i < maxRetries; i++ {
 		db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{
 			Logger: gormLogger,
+			// Report constraint violations as gorm errors such as gorm.ErrDuplicatedKey
+			TranslateError: true,
 		})
 
 		if err == nil {
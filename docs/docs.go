@@ -26,7 +26,7 @@ const docTemplate = `{
     "paths": {
         "/calculate-packs": {
             "post": {
-                "description": "Calculate the optimal pack combination for an order",
+                "description": "Calculate the optimal pack combination for an order, with its packaging footprint and estimated shipping costs per carrier. The objective packs (default) minimises the number of packs and material minimises the packaging material among the packings shipping the fewest items. With optimize_shipping, the cheapest to ship of the tied optimal packings is chosen.",
                 "consumes": [
                     "application/json"
                 ],
@@ -66,31 +66,163 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/pack-sizes": {
+        "/calculate-packs/boxes": {
+            "post": {
+                "description": "Calculate the optimal packs for an order and assign them to the fewest boxes of the given catalog using 3D first-fit-decreasing with rotation. Every pack size used needs dimensions.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calculation"
+                ],
+                "summary": "Assign the packs of an order to shipping boxes",
+                "parameters": [
+                    {
+                        "description": "Box Packing Request",
+                        "name": "boxPacking",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.BoxPackingRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.BoxPackingResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/calculate-packs/cache": {
             "get": {
-                "description": "Get all pack sizes",
+                "description": "Get the hit and miss counts and the size of the calculation cache. The cache is cleared whenever a pack size is created, updated or deleted.",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "pack-sizes"
+                    "calculation"
                 ],
-                "summary": "Get all pack sizes",
+                "summary": "Get calculation cache statistics",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.CacheStatsResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/calculate-packs/consolidate": {
+            "post": {
+                "description": "Calculate the combined optimal packing for several orders, compare it with packing each order separately and allocate the combined packs back to the orders",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calculation"
+                ],
+                "summary": "Consolidate several orders into one packing",
+                "parameters": [
+                    {
+                        "description": "Consolidation Request",
+                        "name": "consolidation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.ConsolidationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ConsolidationResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/calculate-packs/table": {
+            "get": {
+                "description": "Calculate the optimal pack combination of every step-th quantity from one quantity to another in a single pass, as JSON or as CSV with one column per pack size. The results are not stored.",
+                "produces": [
+                    "application/json",
+                    "text/csv"
+                ],
+                "tags": [
+                    "calculation"
+                ],
+                "summary": "Calculate packs for a range of quantities",
                 "parameters": [
                     {
                         "type": "integer",
-                        "description": "Page number (default: 1)",
-                        "name": "page",
+                        "description": "First quantity (default: 1)",
+                        "name": "from",
                         "in": "query"
                     },
                     {
                         "type": "integer",
-                        "description": "Items per page (default: 10)",
-                        "name": "limit",
+                        "description": "Last quantity",
+                        "name": "to",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Distance between quantities (default: 1)",
+                        "name": "step",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Response format: json or csv (default: json)",
+                        "name": "format",
                         "in": "query"
                     }
                 ],
@@ -98,7 +230,13 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.PackSizesResponse"
+                            "$ref": "#/definitions/rest.CalculationTableResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
                         }
                     },
                     "500": {
@@ -106,11 +244,25 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        },
+                        "headers": {
+                            "Retry-After": {
+                                "type": "integer",
+                                "description": "Seconds to wait before retrying"
+                            }
+                        }
                     }
                 }
-            },
+            }
+        },
+        "/calculate-packs/verify": {
             "post": {
-                "description": "Create a new pack size",
+                "description": "Check a manually proposed packing of an order against the business rules: 1. only whole packs of the configured sizes, 2. the fewest items fulfilling the order, 3. as few packs as possible. The optimal packing is returned for comparison and nothing is stored.",
                 "consumes": [
                     "application/json"
                 ],
@@ -118,25 +270,25 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "pack-sizes"
+                    "calculation"
                 ],
-                "summary": "Create a new pack size",
+                "summary": "Verify a proposed packing",
                 "parameters": [
                     {
-                        "description": "Pack Size",
-                        "name": "packSize",
+                        "description": "Verification Request",
+                        "name": "verification",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/rest.CreatePackSizeRequest"
+                            "$ref": "#/definitions/rest.VerificationRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "Created",
+                    "200": {
+                        "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.PackSizeResponse"
+                            "$ref": "#/definitions/rest.VerificationResponse"
                         }
                     },
                     "400": {
@@ -150,24 +302,30 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
                     }
                 }
             }
         },
-        "/pack-sizes/{id}": {
+        "/calculations/{id}": {
             "get": {
-                "description": "Get a pack size by ID",
+                "description": "Get a stored calculation result by ID",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "pack-sizes"
+                    "calculation"
                 ],
-                "summary": "Get a pack size by ID",
+                "summary": "Get a calculation by ID",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Pack Size ID",
+                        "description": "Calculation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -177,7 +335,7 @@ const docTemplate = `{
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.PackSizeResponse"
+                            "$ref": "#/definitions/rest.CalculationResponse"
                         }
                     },
                     "404": {
@@ -193,42 +351,39 @@ const docTemplate = `{
                         }
                     }
                 }
-            },
-            "put": {
-                "description": "Update a pack size",
-                "consumes": [
-                    "application/json"
-                ],
+            }
+        },
+        "/calculations/{id}/packing-slip": {
+            "get": {
+                "description": "Generate a printable pick list and packing slip for a stored calculation",
                 "produces": [
-                    "application/json"
+                    "application/pdf",
+                    "text/plain"
                 ],
                 "tags": [
-                    "pack-sizes"
+                    "calculation"
                 ],
-                "summary": "Update a pack size",
+                "summary": "Get the packing slip of a calculation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Pack Size ID",
+                        "description": "Calculation ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Pack Size",
-                        "name": "packSize",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/rest.UpdatePackSizeRequest"
-                        }
+                        "type": "string",
+                        "description": "Document format: pdf or txt (default: pdf)",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
                         "description": "OK",
                         "schema": {
-                            "$ref": "#/definitions/rest.PackSizeResponse"
+                            "type": "file"
                         }
                     },
                     "400": {
@@ -250,28 +405,85 @@ const docTemplate = `{
                         }
                     }
                 }
+            }
+        },
+        "/pack-sizes": {
+            "get": {
+                "description": "Get all pack sizes",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pack-sizes"
+                ],
+                "summary": "Get all pack sizes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PackSizesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
             },
-            "delete": {
-                "description": "Delete a pack size",
+            "post": {
+                "description": "Create a new pack size",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
                 "tags": [
                     "pack-sizes"
                 ],
-                "summary": "Delete a pack size",
+                "summary": "Create a new pack size",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Pack Size ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Pack Size",
+                        "name": "packSize",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.CreatePackSizeRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "204": {
-                        "description": "No Content"
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PackSizeResponse"
+                        }
                     },
-                    "404": {
-                        "description": "Not Found",
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
                         "schema": {
                             "$ref": "#/definitions/rest.ErrorResponse"
                         }
@@ -283,81 +495,1828 @@ const docTemplate = `{
                         }
                     }
                 }
-            }
-        }
-    },
+            },
+            "put": {
+                "description": "Make the pack sizes exactly the given ones in one transaction: pack sizes of sizes not given are deleted, the others updated to the given properties and the missing sizes created. Calculations never see a partly replaced set.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pack-sizes"
+                ],
+                "summary": "Replace the pack size set",
+                "parameters": [
+                    {
+                        "description": "Pack Sizes",
+                        "name": "packSizes",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.PackSizesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PackSizeChangesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pack-sizes/audit": {
+            "get": {
+                "description": "Get the recorded changes of every pack size, newest first. Changes are attributed to the X-Actor header as claimed_actor, which clients set freely: it is not authenticated and must not be trusted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pack-sizes"
+                ],
+                "summary": "Get the audit feed of pack sizes",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "description": "Page number (default: 1)",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Items per page (default: 10)",
+                        "name": "limit",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PaginatedPackSizeAuditResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pack-sizes/bulk": {
+            "post": {
+                "description": "Create several pack sizes in one transaction, none of them when one is invalid or its size is taken",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pack-sizes"
+                ],
+                "summary": "Create several pack sizes",
+                "parameters": [
+                    {
+                        "description": "Pack Sizes",
+                        "name": "packSizes",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.PackSizesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Created",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PackSizesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pack-sizes/bulk-delete": {
+            "post": {
+                "description": "Move several pack sizes to the trash in one transaction, none of them when one is not found",
+                "consumes": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pack-sizes"
+                ],
+                "summary": "Delete several pack sizes",
+                "parameters": [
+                    {
+                        "description": "Pack Size IDs",
+                        "name": "ids",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.DeletePackSizesRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pack-sizes/compare": {
+            "post": {
+                "description": "Pack every quantity of a distribution with the current and a candidate pack size set and return the average overshoot and pack count, the worst cases and the per-quantity differences of both sets. The stored pack sizes are the current set unless current_sizes is given. The results are not stored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pack-sizes"
+                ],
+                "summary": "Compare two pack size sets",
+                "parameters": [
+                    {
+                        "description": "Pack Set Comparison Request",
+                        "name": "comparison",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.PackSetComparisonRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PackSetComparisonResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pack-sizes/export": {
+            "get": {
+                "description": "Download the pack sizes, smallest first, as a CSV, JSON or YAML file that can be imported again, e.g. into another environment. IDs, versions and timestamps are left out.",
+                "produces": [
+                    "text/csv",
+                    "application/json",
+                    "application/yaml"
+                ],
+                "tags": [
+                    "pack-sizes"
+                ],
+                "summary": "Export the pack sizes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File format: csv, json or yaml (default: csv)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pack-sizes/import": {
+            "post": {
+                "description": "Import the pack sizes of a CSV, JSON or YAML file as exported. The merge strategy creates the missing sizes and updates the others, the replace strategy also deletes the pack sizes of sizes that are not in the file. The import is applied entirely or not at all: when a row is invalid nothing is changed and the report lists the errors of every row. A dry run only reports what the import would do. Files are limited to 1 MB and 1000 pack sizes.",
+                "consumes": [
+                    "text/csv",
+                    "application/json",
+                    "application/yaml"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pack-sizes"
+                ],
+                "summary": "Import pack sizes",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "File format: csv, json or yaml (default: from the Content-Type)",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Import strategy: merge or replace (default: merge)",
+                        "name": "strategy",
+                        "in": "query"
+                    },
+                    {
+                        "type": "boolean",
+                        "description": "Only report what the import would do",
+                        "name": "dry_run",
+                        "in": "query"
+                    },
+                    {
+                        "description": "Pack size file",
+                        "name": "file",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PackSizeImportReportResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "413": {
+                        "description": "Request Entity Too Large",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "422": {
+                        "description": "Unprocessable Entity",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PackSizeImportReportResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pack-sizes/trash": {
+            "get": {
+                "description": "Get the deleted pack sizes, most recently deleted first. They are purged once the retention period has passed.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pack-sizes"
+                ],
+                "summary": "Get the pack sizes in the trash",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PackSizesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pack-sizes/{id}": {
+            "get": {
+                "description": "Get a pack size by ID",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pack-sizes"
+                ],
+                "summary": "Get a pack size by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pack Size ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PackSizeResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "Version of the pack size, for the If-Match header of updates and deletions"
+                            }
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "put": {
+                "description": "Update a pack size, only if it still has the version of the If-Match header when given",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pack-sizes"
+                ],
+                "summary": "Update a pack size",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pack Size ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETags of the versions to update, comma-separated",
+                        "name": "If-Match",
+                        "in": "header"
+                    },
+                    {
+                        "description": "Pack Size",
+                        "name": "packSize",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.UpdatePackSizeRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PackSizeResponse"
+                        },
+                        "headers": {
+                            "ETag": {
+                                "type": "string",
+                                "description": "New version of the pack size"
+                            }
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            },
+            "delete": {
+                "description": "Move a pack size to the trash, from where it can be restored until it is purged after the retention period. Only deleted if it still has the version of the If-Match header when given.",
+                "tags": [
+                    "pack-sizes"
+                ],
+                "summary": "Delete a pack size",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pack Size ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "ETags of the versions to delete, comma-separated",
+                        "name": "If-Match",
+                        "in": "header"
+                    }
+                ],
+                "responses": {
+                    "204": {
+                        "description": "No Content"
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "412": {
+                        "description": "Precondition Failed",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "428": {
+                        "description": "Precondition Required",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pack-sizes/{id}/history": {
+            "get": {
+                "description": "Get the recorded creation, updates, deletion, restores and purge of a pack size, oldest first. The history of purged pack sizes is kept. Changes are attributed to the X-Actor header as claimed_actor, which clients set freely: it is not authenticated and must not be trusted.",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pack-sizes"
+                ],
+                "summary": "Get the history of a pack size",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pack Size ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PackSizeHistoryResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/pack-sizes/{id}/restore": {
+            "post": {
+                "description": "Take a deleted pack size out of the trash, unless another pack size has its size in the meantime",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "pack-sizes"
+                ],
+                "summary": "Restore a pack size from the trash",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Pack Size ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.PackSizeResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Conflict",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shipping-rates": {
+            "get": {
+                "description": "Get all carrier shipping rates",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping-rates"
+                ],
+                "summary": "Get the carrier rate table",
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ShippingRatesResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/shipping-rates/import": {
+            "post": {
+                "description": "Replace the carrier rate table with the rates of a CSV file with the columns carrier, max_weight, max_parcels and price",
+                "consumes": [
+                    "text/csv"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "shipping-rates"
+                ],
+                "summary": "Import the carrier rate table",
+                "parameters": [
+                    {
+                        "description": "Rate table in CSV format",
+                        "name": "rates",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "type": "string"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ShippingRatesResponse"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/calculate-packs": {
+            "post": {
+                "description": "Calculate the optimal pack combination for an order like POST /calculate-packs, returning the packs as lines ordered by pack size, largest first, with the pack count and overshoot",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calculation"
+                ],
+                "summary": "Calculate packs for an order",
+                "parameters": [
+                    {
+                        "description": "Calculation Request",
+                        "name": "calculation",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/rest.CalculationRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.CalculationResponseV2"
+                        }
+                    },
+                    "400": {
+                        "description": "Bad Request",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "503": {
+                        "description": "Service Unavailable",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/v2/calculations/{id}": {
+            "get": {
+                "description": "Get a stored calculation result by ID with its packs as lines ordered by pack size, largest first",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "calculation"
+                ],
+                "summary": "Get a calculation by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Calculation ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "OK",
+                        "schema": {
+                            "$ref": "#/definitions/rest.CalculationResponseV2"
+                        }
+                    },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
+                    "500": {
+                        "description": "Internal Server Error",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        }
+    },
     "definitions": {
+        "rest.BoxAssignmentResponse": {
+            "type": "object",
+            "properties": {
+                "box": {
+                    "$ref": "#/definitions/rest.BoxResponse"
+                },
+                "packs": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "placements": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PackPlacementResponse"
+                    }
+                },
+                "total_weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "rest.BoxPackingRequest": {
+            "type": "object",
+            "required": [
+                "boxes",
+                "items_ordered"
+            ],
+            "properties": {
+                "boxes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/rest.BoxRequest"
+                    }
+                },
+                "items_ordered": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.BoxPackingResponse": {
+            "type": "object",
+            "properties": {
+                "boxes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.BoxAssignmentResponse"
+                    }
+                },
+                "calculation": {
+                    "$ref": "#/definitions/rest.CalculationResponse"
+                },
+                "total_boxes": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.BoxRequest": {
+            "type": "object",
+            "required": [
+                "max_weight",
+                "name"
+            ],
+            "properties": {
+                "dimensions": {
+                    "$ref": "#/definitions/rest.DimensionsRequest"
+                },
+                "max_weight": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.BoxResponse": {
+            "type": "object",
+            "properties": {
+                "dimensions": {
+                    "$ref": "#/definitions/rest.DimensionsResponse"
+                },
+                "max_weight": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.CacheStatsResponse": {
+            "type": "object",
+            "properties": {
+                "capacity": {
+                    "type": "integer"
+                },
+                "entries": {
+                    "type": "integer"
+                },
+                "evictions": {
+                    "type": "integer"
+                },
+                "hit_ratio": {
+                    "type": "number"
+                },
+                "hits": {
+                    "type": "integer"
+                },
+                "misses": {
+                    "type": "integer"
+                },
+                "version": {
+                    "description": "Version of the pack size set, increased on every change",
+                    "type": "integer"
+                }
+            }
+        },
         "rest.CalculationRequest": {
             "type": "object",
             "required": [
-                "itemsOrdered"
+                "items_ordered"
+            ],
+            "properties": {
+                "as_of": {
+                    "description": "Use the pack sizes valid at this time, now when omitted",
+                    "type": "string"
+                },
+                "items_ordered": {
+                    "type": "integer"
+                },
+                "objective": {
+                    "type": "string",
+                    "enum": [
+                        "packs",
+                        "material"
+                    ]
+                },
+                "optimize_shipping": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "rest.CalculationResponse": {
+            "type": "object",
+            "properties": {
+                "footprint": {
+                    "$ref": "#/definitions/rest.PackagingFootprintResponse"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items_ordered": {
+                    "type": "integer"
+                },
+                "packs": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "shipping_estimates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.ShippingEstimateResponse"
+                    }
+                },
+                "total_items": {
+                    "type": "integer"
+                },
+                "total_weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "rest.CalculationResponseV2": {
+            "type": "object",
+            "properties": {
+                "footprint": {
+                    "$ref": "#/definitions/rest.PackagingFootprintResponse"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items_ordered": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "description": "Sorted by pack size, largest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PackLineResponse"
+                    }
+                },
+                "overshoot": {
+                    "description": "Items sent beyond the items ordered",
+                    "type": "integer"
+                },
+                "shipping_estimates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.ShippingEstimateResponse"
+                    }
+                },
+                "total_items": {
+                    "type": "integer"
+                },
+                "total_packs": {
+                    "type": "integer"
+                },
+                "total_weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "rest.CalculationTableResponse": {
+            "type": "object",
+            "properties": {
+                "pack_sizes": {
+                    "description": "Sorted largest first",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.CalculationTableRow"
+                    }
+                }
+            }
+        },
+        "rest.CalculationTableRow": {
+            "type": "object",
+            "properties": {
+                "items_ordered": {
+                    "type": "integer"
+                },
+                "lines": {
+                    "description": "Sorted by pack size, largest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PackLineResponse"
+                    }
+                },
+                "overshoot": {
+                    "type": "integer"
+                },
+                "total_items": {
+                    "type": "integer"
+                },
+                "total_packs": {
+                    "type": "integer"
+                },
+                "total_weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "rest.ConsolidationOrderRequest": {
+            "type": "object",
+            "required": [
+                "items_ordered"
+            ],
+            "properties": {
+                "items_ordered": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.ConsolidationRequest": {
+            "type": "object",
+            "required": [
+                "orders"
+            ],
+            "properties": {
+                "orders": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/rest.ConsolidationOrderRequest"
+                    }
+                }
+            }
+        },
+        "rest.ConsolidationResponse": {
+            "type": "object",
+            "properties": {
+                "allocations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.OrderAllocationResponse"
+                    }
+                },
+                "combined": {
+                    "$ref": "#/definitions/rest.CalculationResponse"
+                },
+                "savings": {
+                    "$ref": "#/definitions/rest.ConsolidationSavingsResponse"
+                },
+                "separate": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.OrderCalculationResponse"
+                    }
+                }
+            }
+        },
+        "rest.ConsolidationSavingsResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "integer"
+                },
+                "packs": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.CreatePackSizeRequest": {
+            "type": "object",
+            "required": [
+                "size"
+            ],
+            "properties": {
+                "active": {
+                    "description": "true when omitted",
+                    "type": "boolean"
+                },
+                "dimensions": {
+                    "$ref": "#/definitions/rest.DimensionsRequest"
+                },
+                "emission_factor": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "material_weight": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "valid_from": {
+                    "description": "Valid from the start when omitted",
+                    "type": "string"
+                },
+                "valid_to": {
+                    "description": "Valid indefinitely when omitted",
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "rest.DeletePackSizesRequest": {
+            "type": "object",
+            "required": [
+                "ids"
+            ],
+            "properties": {
+                "ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "rest.DimensionsRequest": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "length": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "width": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "rest.DimensionsResponse": {
+            "type": "object",
+            "properties": {
+                "height": {
+                    "type": "number"
+                },
+                "length": {
+                    "type": "number"
+                },
+                "width": {
+                    "type": "number"
+                }
+            }
+        },
+        "rest.ErrorResponse": {
+            "type": "object",
+            "properties": {
+                "error": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.OrderAllocationResponse": {
+            "type": "object",
+            "properties": {
+                "allocated_items": {
+                    "type": "integer"
+                },
+                "items_ordered": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "packs": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "rest.OrderCalculationResponse": {
+            "type": "object",
+            "properties": {
+                "footprint": {
+                    "$ref": "#/definitions/rest.PackagingFootprintResponse"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "items_ordered": {
+                    "type": "integer"
+                },
+                "order_id": {
+                    "type": "string"
+                },
+                "packs": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                },
+                "shipping_estimates": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.ShippingEstimateResponse"
+                    }
+                },
+                "total_items": {
+                    "type": "integer"
+                },
+                "total_weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "rest.PackLineResponse": {
+            "type": "object",
+            "properties": {
+                "pack_size": {
+                    "type": "integer"
+                },
+                "quantity": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.PackPlacementResponse": {
+            "type": "object",
+            "properties": {
+                "dimensions": {
+                    "$ref": "#/definitions/rest.DimensionsResponse"
+                },
+                "pack_size": {
+                    "type": "integer"
+                },
+                "position": {
+                    "$ref": "#/definitions/rest.PositionResponse"
+                }
+            }
+        },
+        "rest.PackSetComparisonRequest": {
+            "type": "object",
+            "required": [
+                "candidate_sizes",
+                "quantities"
+            ],
+            "properties": {
+                "candidate_sizes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "current_sizes": {
+                    "description": "Stored pack sizes when omitted",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "quantities": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/rest.QuantityDemandRequest"
+                    }
+                }
+            }
+        },
+        "rest.PackSetComparisonResponse": {
+            "type": "object",
+            "properties": {
+                "candidate": {
+                    "$ref": "#/definitions/rest.PackSetMetricsResponse"
+                },
+                "current": {
+                    "$ref": "#/definitions/rest.PackSetMetricsResponse"
+                },
+                "quantities": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.QuantityComparisonResponse"
+                    }
+                }
+            }
+        },
+        "rest.PackSetMetricsResponse": {
+            "type": "object",
+            "properties": {
+                "average_overshoot": {
+                    "type": "number"
+                },
+                "average_packs": {
+                    "type": "number"
+                },
+                "most_packs": {
+                    "type": "integer"
+                },
+                "most_packs_items_ordered": {
+                    "type": "integer"
+                },
+                "overshoot_ratio": {
+                    "description": "Items shipped beyond the items ordered per item ordered",
+                    "type": "number"
+                },
+                "pack_sizes": {
+                    "description": "Sorted largest first",
+                    "type": "array",
+                    "items": {
+                        "type": "integer"
+                    }
+                },
+                "worst_overshoot": {
+                    "type": "integer"
+                },
+                "worst_overshoot_items_ordered": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.PackSizeAuditEntryResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "description": "created, updated, deleted, restored or purged",
+                    "type": "string"
+                },
+                "after": {
+                    "description": "Omitted for a deletion",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.PackSizeResponse"
+                        }
+                    ]
+                },
+                "before": {
+                    "description": "Omitted for a creation",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.PackSizeResponse"
+                        }
+                    ]
+                },
+                "claimed_actor": {
+                    "description": "Self-reported in the X-Actor header, not verified",
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "pack_size_id": {
+                    "type": "string"
+                },
+                "request_id": {
+                    "type": "string"
+                },
+                "timestamp": {
+                    "type": "string"
+                }
+            }
+        },
+        "rest.PackSizeChangesResponse": {
+            "type": "object",
+            "properties": {
+                "created": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PackSizeResponse"
+                    }
+                },
+                "deleted": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PackSizeResponse"
+                    }
+                },
+                "updated": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PackSizeResponse"
+                    }
+                }
+            }
+        },
+        "rest.PackSizeHistoryResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PackSizeAuditEntryResponse"
+                    }
+                }
+            }
+        },
+        "rest.PackSizeImportReportResponse": {
+            "type": "object",
+            "properties": {
+                "applied": {
+                    "description": "Whether the pack sizes were changed",
+                    "type": "boolean"
+                },
+                "created": {
+                    "type": "integer"
+                },
+                "deleted": {
+                    "description": "Pack sizes deleted by the replace strategy",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PackSizeResponse"
+                    }
+                },
+                "dry_run": {
+                    "type": "boolean"
+                },
+                "invalid": {
+                    "type": "integer"
+                },
+                "rows": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PackSizeImportRowResponse"
+                    }
+                },
+                "strategy": {
+                    "type": "string"
+                },
+                "unchanged": {
+                    "type": "integer"
+                },
+                "updated": {
+                    "type": "integer"
+                },
+                "valid": {
+                    "description": "Whether every row is valid",
+                    "type": "boolean"
+                }
+            }
+        },
+        "rest.PackSizeImportRowResponse": {
+            "type": "object",
+            "properties": {
+                "action": {
+                    "type": "string",
+                    "enum": [
+                        "create",
+                        "update",
+                        "unchanged",
+                        "invalid"
+                    ]
+                },
+                "errors": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "pack_size": {
+                    "description": "The pack size after the import",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.PackSizeResponse"
+                        }
+                    ]
+                },
+                "row": {
+                    "description": "Position of the pack size in the file, from 1",
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.PackSizeResponse": {
+            "type": "object",
+            "properties": {
+                "active": {
+                    "type": "boolean"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "deleted_at": {
+                    "description": "Set for pack sizes in the trash",
+                    "type": "string"
+                },
+                "dimensions": {
+                    "$ref": "#/definitions/rest.DimensionsResponse"
+                },
+                "emission_factor": {
+                    "type": "number"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "material_weight": {
+                    "type": "number"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "sku": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                },
+                "valid_from": {
+                    "type": "string"
+                },
+                "valid_to": {
+                    "type": "string"
+                },
+                "version": {
+                    "description": "Also returned as the ETag header",
+                    "type": "integer"
+                },
+                "weight": {
+                    "type": "number"
+                }
+            }
+        },
+        "rest.PackSizesRequest": {
+            "type": "object",
+            "required": [
+                "pack_sizes"
             ],
             "properties": {
-                "itemsOrdered": {
-                    "type": "integer"
+                "pack_sizes": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "$ref": "#/definitions/rest.CreatePackSizeRequest"
+                    }
                 }
             }
         },
-        "rest.CalculationResponse": {
+        "rest.PackSizesResponse": {
+            "type": "object",
+            "properties": {
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PackSizeResponse"
+                    }
+                }
+            }
+        },
+        "rest.PackagingFootprintResponse": {
+            "type": "object",
+            "properties": {
+                "emissions": {
+                    "description": "Kilograms of CO2e",
+                    "type": "number"
+                },
+                "material": {
+                    "description": "Kilograms of packaging material",
+                    "type": "number"
+                }
+            }
+        },
+        "rest.PackingSummaryResponse": {
             "type": "object",
             "properties": {
-                "itemsOrdered": {
+                "lines": {
+                    "description": "Sorted by pack size, largest first",
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PackLineResponse"
+                    }
+                },
+                "overshoot": {
                     "type": "integer"
                 },
-                "packs": {
-                    "type": "object",
-                    "additionalProperties": {
-                        "type": "integer"
+                "total_items": {
+                    "type": "integer"
+                },
+                "total_packs": {
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.PaginatedPackSizeAuditResponse": {
+            "type": "object",
+            "properties": {
+                "is_last_page": {
+                    "type": "boolean"
+                },
+                "items": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.PackSizeAuditEntryResponse"
                     }
                 },
-                "totalItems": {
+                "limit": {
+                    "type": "integer"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "total": {
                     "type": "integer"
                 }
             }
         },
-        "rest.CreatePackSizeRequest": {
+        "rest.PositionResponse": {
+            "type": "object",
+            "properties": {
+                "x": {
+                    "type": "number"
+                },
+                "y": {
+                    "type": "number"
+                },
+                "z": {
+                    "type": "number"
+                }
+            }
+        },
+        "rest.QuantityComparisonResponse": {
+            "type": "object",
+            "properties": {
+                "candidate": {
+                    "$ref": "#/definitions/rest.PackingSummaryResponse"
+                },
+                "current": {
+                    "$ref": "#/definitions/rest.PackingSummaryResponse"
+                },
+                "frequency": {
+                    "type": "integer"
+                },
+                "items_ordered": {
+                    "type": "integer"
+                },
+                "overshoot_difference": {
+                    "description": "Candidate minus current",
+                    "type": "integer"
+                },
+                "packs_difference": {
+                    "description": "Candidate minus current",
+                    "type": "integer"
+                }
+            }
+        },
+        "rest.QuantityDemandRequest": {
             "type": "object",
             "required": [
-                "size"
+                "items_ordered"
             ],
             "properties": {
-                "size": {
+                "frequency": {
+                    "description": "1 when omitted",
+                    "type": "integer",
+                    "minimum": 0
+                },
+                "items_ordered": {
                     "type": "integer"
                 }
             }
         },
-        "rest.ErrorResponse": {
+        "rest.RuleViolationResponse": {
             "type": "object",
             "properties": {
-                "error": {
+                "message": {
                     "type": "string"
+                },
+                "rule": {
+                    "description": "1: whole packs of configured sizes, 2: fewest items, 3: fewest packs",
+                    "type": "integer"
                 }
             }
         },
-        "rest.PackSizeResponse": {
+        "rest.ShippingEstimateResponse": {
             "type": "object",
             "properties": {
-                "createdAt": {
+                "carrier": {
+                    "type": "string"
+                },
+                "price": {
+                    "type": "number"
+                }
+            }
+        },
+        "rest.ShippingRateResponse": {
+            "type": "object",
+            "properties": {
+                "carrier": {
+                    "type": "string"
+                },
+                "created_at": {
                     "type": "string"
                 },
                 "id": {
                     "type": "string"
                 },
-                "size": {
+                "max_parcels": {
                     "type": "integer"
                 },
-                "updatedAt": {
-                    "type": "string"
+                "max_weight": {
+                    "type": "number"
+                },
+                "price": {
+                    "type": "number"
                 }
             }
         },
-        "rest.PackSizesResponse": {
+        "rest.ShippingRatesResponse": {
             "type": "object",
             "properties": {
                 "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/rest.PackSizeResponse"
+                        "$ref": "#/definitions/rest.ShippingRateResponse"
                     }
                 }
             }
@@ -368,8 +2327,97 @@ const docTemplate = `{
                 "size"
             ],
             "properties": {
+                "active": {
+                    "description": "true when omitted",
+                    "type": "boolean"
+                },
+                "dimensions": {
+                    "$ref": "#/definitions/rest.DimensionsRequest"
+                },
+                "emission_factor": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "material_weight": {
+                    "type": "number",
+                    "minimum": 0
+                },
+                "name": {
+                    "type": "string",
+                    "maxLength": 100
+                },
                 "size": {
                     "type": "integer"
+                },
+                "sku": {
+                    "type": "string",
+                    "maxLength": 64
+                },
+                "tags": {
+                    "type": "array",
+                    "maxItems": 20,
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "valid_from": {
+                    "description": "Valid from the start when omitted",
+                    "type": "string"
+                },
+                "valid_to": {
+                    "description": "Valid indefinitely when omitted",
+                    "type": "string"
+                },
+                "weight": {
+                    "type": "number",
+                    "minimum": 0
+                }
+            }
+        },
+        "rest.VerificationRequest": {
+            "type": "object",
+            "required": [
+                "items_ordered",
+                "packs"
+            ],
+            "properties": {
+                "items_ordered": {
+                    "type": "integer"
+                },
+                "packs": {
+                    "description": "Quantity by pack size, e.g. {\"500\": 1, \"250\": 1}",
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "number"
+                    }
+                }
+            }
+        },
+        "rest.VerificationResponse": {
+            "type": "object",
+            "properties": {
+                "items_ordered": {
+                    "type": "integer"
+                },
+                "optimal": {
+                    "$ref": "#/definitions/rest.PackingSummaryResponse"
+                },
+                "proposed": {
+                    "description": "Omitted when the packing breaks rule 1",
+                    "allOf": [
+                        {
+                            "$ref": "#/definitions/rest.PackingSummaryResponse"
+                        }
+                    ]
+                },
+                "valid": {
+                    "type": "boolean"
+                },
+                "violations": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/rest.RuleViolationResponse"
+                    }
                 }
             }
         }
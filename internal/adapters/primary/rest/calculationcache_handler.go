@@ -0,0 +1,47 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"go-pack-calculator/internal/ports/primary"
+)
+
+// CalculationCacheHandler handles HTTP requests for the calculation cache
+type CalculationCacheHandler struct {
+	cacheService primary.CalculationCacheService
+}
+
+// NewCalculationCacheHandler creates a new calculation cache handler
+func NewCalculationCacheHandler(cacheService primary.CalculationCacheService) *CalculationCacheHandler {
+	return &CalculationCacheHandler{
+		cacheService: cacheService,
+	}
+}
+
+// RegisterRoutes registers the REST API routes
+func (h *CalculationCacheHandler) RegisterRoutes(r *gin.Engine) {
+	r.GET("/api/calculate-packs/cache", h.GetCacheStats)
+}
+
+// GetCacheStats godoc
+// @Summary Get calculation cache statistics
+// @Description Get the hit and miss counts and the size of the calculation cache. The cache is cleared whenever a pack size is created, updated or deleted.
+// @Tags calculation
+// @Produce json
+// @Success 200 {object} CacheStatsResponse
+// @Router /calculate-packs/cache [get]
+func (h *CalculationCacheHandler) GetCacheStats(c *gin.Context) {
+	stats := h.cacheService.GetCacheStats(c.Request.Context())
+
+	c.JSON(http.StatusOK, CacheStatsResponse{
+		Hits:      stats.Hits,
+		Misses:    stats.Misses,
+		HitRatio:  stats.HitRatio(),
+		Evictions: stats.Evictions,
+		Entries:   stats.Entries,
+		Capacity:  stats.Capacity,
+		Version:   stats.Version,
+	})
+}
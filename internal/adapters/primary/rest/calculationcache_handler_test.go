@@ -0,0 +1,46 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-pack-calculator/internal/domain/entities"
+)
+
+type mockCalculationCacheService struct {
+	stats entities.CacheStats
+}
+
+func (m *mockCalculationCacheService) GetCacheStats(ctx context.Context) entities.CacheStats {
+	return m.stats
+}
+
+func TestCalculationCacheHandler_GetCacheStats(t *testing.T) {
+	// Setup
+	router := setupRouter()
+	handler := NewCalculationCacheHandler(&mockCalculationCacheService{
+		stats: entities.CacheStats{Hits: 3, Misses: 1, Entries: 1, Capacity: 10, Version: 2},
+	})
+	handler.RegisterRoutes(router)
+
+	// Perform request
+	req, _ := http.NewRequest(http.MethodGet, "/api/calculate-packs/cache", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Check response
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response CacheStatsResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), response.Hits)
+	assert.Equal(t, int64(1), response.Misses)
+	assert.Equal(t, 0.75, response.HitRatio)
+	assert.Equal(t, uint64(2), response.Version)
+}
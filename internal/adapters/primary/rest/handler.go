@@ -1,8 +1,14 @@
 package rest
 
 import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"fmt"
+	"math"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/gin-gonic/gin"
 
@@ -12,10 +18,14 @@ import (
 	"go-pack-calculator/internal/ports/primary"
 )
 
+// statusClientClosedRequest is the non-standard status logged when the client goes away before the response
+const statusClientClosedRequest = 499
+
 // PackCalculatorHandler handles HTTP requests for the pack calculator
 type PackCalculatorHandler struct {
 	packSizeService    primary.PackSizeService
 	calculationService primary.CalculationService
+	requireIfMatch     bool
 }
 
 // NewPackCalculatorHandler creates a new pack calculator handler
@@ -29,6 +39,12 @@ func NewPackCalculatorHandler(
 	}
 }
 
+// SetRequireIfMatch sets whether pack size updates and deletions without an If-Match header are
+// rejected, instead of overwriting whatever version is stored
+func (h *PackCalculatorHandler) SetRequireIfMatch(required bool) {
+	h.requireIfMatch = required
+}
+
 // RegisterRoutes registers the REST API routes
 func (h *PackCalculatorHandler) RegisterRoutes(r *gin.Engine) {
 	api := r.Group("/api")
@@ -39,6 +55,11 @@ func (h *PackCalculatorHandler) RegisterRoutes(r *gin.Engine) {
 
 		packSizes.GET("", h.GetAllPackSizes)
 		packSizes.POST("", h.CreatePackSize)
+		packSizes.PUT("", h.ReplacePackSizes)
+		packSizes.POST("/bulk", h.CreatePackSizes)
+		packSizes.POST("/bulk-delete", h.DeletePackSizes)
+		packSizes.GET("/trash", h.GetDeletedPackSizes)
+		packSizes.POST("/:id/restore", h.RestorePackSize)
 		packSizes.GET("/:id", h.GetPackSizeByID)
 		packSizes.PUT("/:id", h.UpdatePackSize)
 		packSizes.DELETE("/:id", h.DeletePackSize)
@@ -46,6 +67,26 @@ func (h *PackCalculatorHandler) RegisterRoutes(r *gin.Engine) {
 
 	// Calculation endpoint
 	api.POST("/calculate-packs", h.CalculatePacks)
+	api.POST("/calculate-packs/consolidate", h.ConsolidateOrders)
+	api.POST("/calculate-packs/boxes", h.PackIntoBoxes)
+	api.GET("/calculate-packs/table", h.GetCalculationTable)
+	api.POST("/calculate-packs/verify", h.VerifyPacking)
+
+	// Stored calculation endpoints
+	{
+		calculations := api.Group("/calculations")
+
+		calculations.GET("/:id", h.GetCalculationByID)
+		calculations.GET("/:id/packing-slip", h.GetPackingSlip)
+	}
+
+	// Version 2 endpoints return the packs as ordered lines
+	{
+		v2 := api.Group("/v2")
+
+		v2.POST("/calculate-packs", h.CalculatePacksV2)
+		v2.GET("/calculations/:id", h.GetCalculationByIDV2)
+	}
 
 }
 
@@ -58,6 +99,7 @@ func (h *PackCalculatorHandler) RegisterRoutes(r *gin.Engine) {
 // @Param packSize body CreatePackSizeRequest true "Pack Size"
 // @Success 201 {object} PackSizeResponse
 // @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /pack-sizes [post]
 func (h *PackCalculatorHandler) CreatePackSize(c *gin.Context) {
@@ -68,16 +110,109 @@ func (h *PackCalculatorHandler) CreatePackSize(c *gin.Context) {
 		return
 	}
 
-	packSize, err := h.packSizeService.CreatePackSize(req.Size)
+	packSize, err := h.packSizeService.CreatePackSize(c.Request.Context(), req.Size, toPackSizeAttributes(req))
 	if err != nil {
 		handleError(c, err)
 
 		return
 	}
 
+	c.Header("ETag", packSizeETag(packSize))
 	c.JSON(http.StatusCreated, toPackSizeResponse(packSize))
 }
 
+// CreatePackSizes godoc
+// @Summary Create several pack sizes
+// @Description Create several pack sizes in one transaction, none of them when one is invalid or its size is taken
+// @Tags pack-sizes
+// @Accept json
+// @Produce json
+// @Param packSizes body PackSizesRequest true "Pack Sizes"
+// @Success 201 {object} PackSizesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /pack-sizes/bulk [post]
+func (h *PackCalculatorHandler) CreatePackSizes(c *gin.Context) {
+	var req PackSizesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request: " + err.Error()})
+
+		return
+	}
+
+	packSizes, err := h.packSizeService.CreatePackSizes(c.Request.Context(), toPackSizeDefinitions(req.PackSizes))
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusCreated, toPackSizesResponse(packSizes))
+}
+
+// ReplacePackSizes godoc
+// @Summary Replace the pack size set
+// @Description Make the pack sizes exactly the given ones in one transaction: pack sizes of sizes not given are deleted, the others updated to the given properties and the missing sizes created. Calculations never see a partly replaced set.
+// @Tags pack-sizes
+// @Accept json
+// @Produce json
+// @Param packSizes body PackSizesRequest true "Pack Sizes"
+// @Success 200 {object} PackSizeChangesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /pack-sizes [put]
+func (h *PackCalculatorHandler) ReplacePackSizes(c *gin.Context) {
+	var req PackSizesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request: " + err.Error()})
+
+		return
+	}
+
+	changes, err := h.packSizeService.ReplacePackSizes(c.Request.Context(), toPackSizeDefinitions(req.PackSizes))
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, PackSizeChangesResponse{
+		Created: toPackSizesResponse(changes.Created).Items,
+		Updated: toPackSizesResponse(changes.Updated).Items,
+		Deleted: toPackSizesResponse(changes.Deleted).Items,
+	})
+}
+
+// DeletePackSizes godoc
+// @Summary Delete several pack sizes
+// @Description Move several pack sizes to the trash in one transaction, none of them when one is not found
+// @Tags pack-sizes
+// @Accept json
+// @Param ids body DeletePackSizesRequest true "Pack Size IDs"
+// @Success 204 "No Content"
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /pack-sizes/bulk-delete [post]
+func (h *PackCalculatorHandler) DeletePackSizes(c *gin.Context) {
+	var req DeletePackSizesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request: " + err.Error()})
+
+		return
+	}
+
+	if err := h.packSizeService.DeletePackSizes(c.Request.Context(), req.IDs); err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
 // GetAllPackSizes godoc
 // @Summary Get all pack sizes
 // @Description Get all pack sizes
@@ -106,7 +241,7 @@ func (h *PackCalculatorHandler) GetAllPackSizes(c *gin.Context) {
 		}
 
 		// Get paginated results
-		pagination, err := h.packSizeService.GetAllPackSizesWithPagination(page, limit)
+		pagination, err := h.packSizeService.GetAllPackSizesWithPagination(c.Request.Context(), page, limit)
 		if err != nil {
 			handleError(c, err)
 
@@ -134,22 +269,14 @@ func (h *PackCalculatorHandler) GetAllPackSizes(c *gin.Context) {
 	}
 
 	// Get all results without pagination
-	packSizes, err := h.packSizeService.GetAllPackSizes()
+	packSizes, err := h.packSizeService.GetAllPackSizes(c.Request.Context())
 	if err != nil {
 		handleError(c, err)
 
 		return
 	}
 
-	response := PackSizesResponse{
-		Items: make([]PackSizeResponse, len(packSizes)),
-	}
-
-	for i, ps := range packSizes {
-		response.Items[i] = toPackSizeResponse(ps)
-	}
-
-	c.JSON(http.StatusOK, response)
+	c.JSON(http.StatusOK, toPackSizesResponse(packSizes))
 }
 
 // GetPackSizeByID godoc
@@ -159,37 +286,49 @@ func (h *PackCalculatorHandler) GetAllPackSizes(c *gin.Context) {
 // @Produce json
 // @Param id path string true "Pack Size ID"
 // @Success 200 {object} PackSizeResponse
+// @Header 200 {string} ETag "Version of the pack size, for the If-Match header of updates and deletions"
 // @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /pack-sizes/{id} [get]
 func (h *PackCalculatorHandler) GetPackSizeByID(c *gin.Context) {
 	id := c.Param("id")
-	packSize, err := h.packSizeService.GetPackSizeByID(id)
+	packSize, err := h.packSizeService.GetPackSizeByID(c.Request.Context(), id)
 	if err != nil {
 		handleError(c, err)
 
 		return
 	}
 
+	c.Header("ETag", packSizeETag(packSize))
 	c.JSON(http.StatusOK, toPackSizeResponse(packSize))
 }
 
 // UpdatePackSize godoc
 // @Summary Update a pack size
-// @Description Update a pack size
+// @Description Update a pack size, only if it still has the version of the If-Match header when given
 // @Tags pack-sizes
 // @Accept json
 // @Produce json
 // @Param id path string true "Pack Size ID"
+// @Param If-Match header string false "ETags of the versions to update, comma-separated"
 // @Param packSize body UpdatePackSizeRequest true "Pack Size"
 // @Success 200 {object} PackSizeResponse
+// @Header 200 {string} ETag "New version of the pack size"
 // @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 428 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /pack-sizes/{id} [put]
 func (h *PackCalculatorHandler) UpdatePackSize(c *gin.Context) {
 	id := c.Param("id")
 
+	version, ok := h.ifMatchVersion(c, id)
+	if !ok {
+		return
+	}
+
 	var req UpdatePackSizeRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request: " + err.Error()})
@@ -197,28 +336,49 @@ func (h *PackCalculatorHandler) UpdatePackSize(c *gin.Context) {
 		return
 	}
 
-	packSize, err := h.packSizeService.UpdatePackSize(id, req.Size)
+	packSize, err := h.packSizeService.UpdatePackSize(c.Request.Context(), id, version, req.Size, entities.PackSizeAttributes{
+		Weight:         req.Weight,
+		MaterialWeight: req.MaterialWeight,
+		EmissionFactor: req.EmissionFactor,
+		Dimensions:     toDimensions(req.Dimensions),
+		Name:           req.Name,
+		SKU:            req.SKU,
+		Tags:           req.Tags,
+		Active:         req.Active,
+		ValidFrom:      req.ValidFrom,
+		ValidTo:        req.ValidTo,
+	})
 	if err != nil {
 		handleError(c, err)
 
 		return
 	}
 
+	c.Header("ETag", packSizeETag(packSize))
 	c.JSON(http.StatusOK, toPackSizeResponse(packSize))
 }
 
 // DeletePackSize godoc
 // @Summary Delete a pack size
-// @Description Delete a pack size
+// @Description Move a pack size to the trash, from where it can be restored until it is purged after the retention period. Only deleted if it still has the version of the If-Match header when given.
 // @Tags pack-sizes
 // @Param id path string true "Pack Size ID"
+// @Param If-Match header string false "ETags of the versions to delete, comma-separated"
 // @Success 204 "No Content"
 // @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 428 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 // @Router /pack-sizes/{id} [delete]
 func (h *PackCalculatorHandler) DeletePackSize(c *gin.Context) {
 	id := c.Param("id")
-	err := h.packSizeService.DeletePackSize(id)
+
+	version, ok := h.ifMatchVersion(c, id)
+	if !ok {
+		return
+	}
+
+	err := h.packSizeService.DeletePackSize(c.Request.Context(), id, version)
 	if err != nil {
 		handleError(c, err)
 
@@ -228,9 +388,52 @@ func (h *PackCalculatorHandler) DeletePackSize(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// GetDeletedPackSizes godoc
+// @Summary Get the pack sizes in the trash
+// @Description Get the deleted pack sizes, most recently deleted first. They are purged once the retention period has passed.
+// @Tags pack-sizes
+// @Produce json
+// @Success 200 {object} PackSizesResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /pack-sizes/trash [get]
+func (h *PackCalculatorHandler) GetDeletedPackSizes(c *gin.Context) {
+	packSizes, err := h.packSizeService.GetDeletedPackSizes(c.Request.Context())
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, toPackSizesResponse(packSizes))
+}
+
+// RestorePackSize godoc
+// @Summary Restore a pack size from the trash
+// @Description Take a deleted pack size out of the trash, unless another pack size has its size in the meantime
+// @Tags pack-sizes
+// @Produce json
+// @Param id path string true "Pack Size ID"
+// @Success 200 {object} PackSizeResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /pack-sizes/{id}/restore [post]
+func (h *PackCalculatorHandler) RestorePackSize(c *gin.Context) {
+	id := c.Param("id")
+	packSize, err := h.packSizeService.RestorePackSize(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.Header("ETag", packSizeETag(packSize))
+	c.JSON(http.StatusOK, toPackSizeResponse(packSize))
+}
+
 // CalculatePacks godoc
 // @Summary Calculate packs for an order
-// @Description Calculate the optimal pack combination for an order
+// @Description Calculate the optimal pack combination for an order, with its packaging footprint and estimated shipping costs per carrier. The objective packs (default) minimises the number of packs and material minimises the packaging material among the packings shipping the fewest items. With optimize_shipping, the cheapest to ship of the tied optimal packings is chosen.
 // @Tags calculation
 // @Accept json
 // @Produce json
@@ -238,16 +441,229 @@ func (h *PackCalculatorHandler) DeletePackSize(c *gin.Context) {
 // @Success 200 {object} CalculationResponse
 // @Failure 400 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
 // @Router /calculate-packs [post]
 func (h *PackCalculatorHandler) CalculatePacks(c *gin.Context) {
+	result, ok := h.calculatePacks(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, toCalculationResponse(result))
+}
+
+// CalculatePacksV2 godoc
+// @Summary Calculate packs for an order
+// @Description Calculate the optimal pack combination for an order like POST /calculate-packs, returning the packs as lines ordered by pack size, largest first, with the pack count and overshoot
+// @Tags calculation
+// @Accept json
+// @Produce json
+// @Param calculation body CalculationRequest true "Calculation Request"
+// @Success 200 {object} CalculationResponseV2
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /v2/calculate-packs [post]
+func (h *PackCalculatorHandler) CalculatePacksV2(c *gin.Context) {
+	result, ok := h.calculatePacks(c)
+	if !ok {
+		return
+	}
+
+	c.JSON(http.StatusOK, toCalculationResponseV2(result))
+}
+
+// calculatePacks calculates the packs of the requested order, writing the error response on failure
+func (h *PackCalculatorHandler) calculatePacks(c *gin.Context) (*entities.CalculationResult, bool) {
 	var req CalculationRequest
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request: " + err.Error()})
 
+		return nil, false
+	}
+
+	options := entities.CalculationOptions{
+		Objective:        entities.CalculationObjective(req.Objective),
+		OptimizeShipping: req.OptimizeShipping,
+	}
+	if req.AsOf != nil {
+		options.AsOf = req.AsOf.UTC()
+	}
+
+	result, err := h.calculationService.CalculatePacksForOrder(c.Request.Context(), req.ItemsOrdered, options)
+	if err != nil {
+		handleError(c, err)
+
+		return nil, false
+	}
+
+	return result, true
+}
+
+// GetCalculationTable godoc
+// @Summary Calculate packs for a range of quantities
+// @Description Calculate the optimal pack combination of every step-th quantity from one quantity to another in a single pass, as JSON or as CSV with one column per pack size. The results are not stored.
+// @Tags calculation
+// @Produce json
+// @Produce text/csv
+// @Param from query int false "First quantity (default: 1)"
+// @Param to query int true "Last quantity"
+// @Param step query int false "Distance between quantities (default: 1)"
+// @Param format query string false "Response format: json or csv (default: json)"
+// @Success 200 {object} CalculationTableResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Header 503 {integer} Retry-After "Seconds to wait before retrying"
+// @Router /calculate-packs/table [get]
+func (h *PackCalculatorHandler) GetCalculationTable(c *gin.Context) {
+	req := CalculationTableRequest{From: 1, Step: 1, Format: "json"}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request: " + err.Error()})
+
+		return
+	}
+	if req.Format != "json" && req.Format != "csv" {
+		handleError(c, errors.ErrUnsupportedFormat)
+
+		return
+	}
+
+	table, err := h.calculationService.CalculatePackingTable(c.Request.Context(), req.From, req.To, req.Step)
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	if req.Format == "csv" {
+		c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", fmt.Sprintf("packs-%d-%d.csv", req.From, req.To)))
+		c.Data(http.StatusOK, "text/csv; charset=utf-8", toCalculationTableCSV(table))
+
+		return
+	}
+
+	c.JSON(http.StatusOK, toCalculationTableResponse(table))
+}
+
+// VerifyPacking godoc
+// @Summary Verify a proposed packing
+// @Description Check a manually proposed packing of an order against the business rules: 1. only whole packs of the configured sizes, 2. the fewest items fulfilling the order, 3. as few packs as possible. The optimal packing is returned for comparison and nothing is stored.
+// @Tags calculation
+// @Accept json
+// @Produce json
+// @Param verification body VerificationRequest true "Verification Request"
+// @Success 200 {object} VerificationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Failure 503 {object} ErrorResponse
+// @Router /calculate-packs/verify [post]
+func (h *PackCalculatorHandler) VerifyPacking(c *gin.Context) {
+	var req VerificationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request: " + err.Error()})
+
 		return
 	}
 
-	result, err := h.calculationService.CalculatePacksForOrder(req.ItemsOrdered)
+	verification, err := h.calculationService.VerifyPacking(c.Request.Context(), req.ItemsOrdered, req.Packs)
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, toVerificationResponse(verification))
+}
+
+// ConsolidateOrders godoc
+// @Summary Consolidate several orders into one packing
+// @Description Calculate the combined optimal packing for several orders, compare it with packing each order separately and allocate the combined packs back to the orders
+// @Tags calculation
+// @Accept json
+// @Produce json
+// @Param consolidation body ConsolidationRequest true "Consolidation Request"
+// @Success 200 {object} ConsolidationResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /calculate-packs/consolidate [post]
+func (h *PackCalculatorHandler) ConsolidateOrders(c *gin.Context) {
+	var req ConsolidationRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request: " + err.Error()})
+
+		return
+	}
+
+	orders := make([]entities.Order, len(req.Orders))
+	for i, order := range req.Orders {
+		orders[i] = entities.Order{
+			ID:           order.OrderID,
+			ItemsOrdered: order.ItemsOrdered,
+		}
+	}
+
+	result, err := h.calculationService.ConsolidateOrders(c.Request.Context(), orders)
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, toConsolidationResponse(result))
+}
+
+// PackIntoBoxes godoc
+// @Summary Assign the packs of an order to shipping boxes
+// @Description Calculate the optimal packs for an order and assign them to the fewest boxes of the given catalog using 3D first-fit-decreasing with rotation. Every pack size used needs dimensions.
+// @Tags calculation
+// @Accept json
+// @Produce json
+// @Param boxPacking body BoxPackingRequest true "Box Packing Request"
+// @Success 200 {object} BoxPackingResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /calculate-packs/boxes [post]
+func (h *PackCalculatorHandler) PackIntoBoxes(c *gin.Context) {
+	var req BoxPackingRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request: " + err.Error()})
+
+		return
+	}
+
+	boxes := make([]entities.Box, len(req.Boxes))
+	for i, box := range req.Boxes {
+		boxes[i] = entities.Box{
+			Name:       box.Name,
+			Dimensions: toDimensions(box.Dimensions),
+			MaxWeight:  box.MaxWeight,
+		}
+	}
+
+	result, err := h.calculationService.PackOrderIntoBoxes(c.Request.Context(), req.ItemsOrdered, boxes)
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, toBoxPackingResponse(result))
+}
+
+// GetCalculationByID godoc
+// @Summary Get a calculation by ID
+// @Description Get a stored calculation result by ID
+// @Tags calculation
+// @Produce json
+// @Param id path string true "Calculation ID"
+// @Success 200 {object} CalculationResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /calculations/{id} [get]
+func (h *PackCalculatorHandler) GetCalculationByID(c *gin.Context) {
+	id := c.Param("id")
+	result, err := h.calculationService.GetCalculationByID(c.Request.Context(), id)
 	if err != nil {
 		handleError(c, err)
 
@@ -257,35 +673,444 @@ func (h *PackCalculatorHandler) CalculatePacks(c *gin.Context) {
 	c.JSON(http.StatusOK, toCalculationResponse(result))
 }
 
+// GetCalculationByIDV2 godoc
+// @Summary Get a calculation by ID
+// @Description Get a stored calculation result by ID with its packs as lines ordered by pack size, largest first
+// @Tags calculation
+// @Produce json
+// @Param id path string true "Calculation ID"
+// @Success 200 {object} CalculationResponseV2
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /v2/calculations/{id} [get]
+func (h *PackCalculatorHandler) GetCalculationByIDV2(c *gin.Context) {
+	id := c.Param("id")
+	result, err := h.calculationService.GetCalculationByID(c.Request.Context(), id)
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, toCalculationResponseV2(result))
+}
+
+// GetPackingSlip godoc
+// @Summary Get the packing slip of a calculation
+// @Description Generate a printable pick list and packing slip for a stored calculation
+// @Tags calculation
+// @Produce application/pdf
+// @Produce plain
+// @Param id path string true "Calculation ID"
+// @Param format query string false "Document format: pdf or txt (default: pdf)"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /calculations/{id}/packing-slip [get]
+func (h *PackCalculatorHandler) GetPackingSlip(c *gin.Context) {
+	id := c.Param("id")
+	format := entities.DocumentFormat(c.DefaultQuery("format", string(entities.DocumentFormatPDF)))
+
+	document, err := h.calculationService.GeneratePackingSlip(c.Request.Context(), id, format)
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", document.Filename))
+	c.Data(http.StatusOK, document.ContentType, document.Content)
+}
+
 // Helper function to handle errors
 func handleError(c *gin.Context, err error) {
 	switch {
-	case stderr.Is(err, errors.ErrPackSizeNotFound):
+	case stderr.Is(err, errors.ErrPackSizeNotFound) || stderr.Is(err, errors.ErrCalculationNotFound):
 		c.JSON(http.StatusNotFound, ErrorResponse{Error: err.Error()})
+	case stderr.Is(err, errors.ErrDuplicatePackSize):
+		c.JSON(http.StatusConflict, ErrorResponse{Error: err.Error()})
+	case stderr.Is(err, errors.ErrVersionMismatch):
+		c.JSON(http.StatusPreconditionFailed, ErrorResponse{Error: err.Error()})
 	case stderr.Is(err, errors.ErrInvalidPackSize) || stderr.Is(err, errors.ErrInvalidItemsOrdered):
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	case stderr.Is(err, errors.ErrInvalidImport):
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
 	case stderr.Is(err, errors.ErrNoPackSizesAvailable):
 		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	case stderr.Is(err, errors.ErrNoOrders) || stderr.Is(err, errors.ErrDuplicateOrderID):
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	case stderr.Is(err, errors.ErrUnsupportedFormat) || stderr.Is(err, errors.ErrInvalidShippingRate):
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	case stderr.Is(err, errors.ErrInvalidObjective):
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	case stderr.Is(err, errors.ErrInvalidBox) || stderr.Is(err, errors.ErrNoBoxes):
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	case stderr.Is(err, errors.ErrMissingDimensions) || stderr.Is(err, errors.ErrPackDoesNotFitBox):
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	case stderr.Is(err, errors.ErrCalculationTooLarge) || stderr.Is(err, errors.ErrInvalidRange):
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	case stderr.Is(err, errors.ErrNoQuantities) || stderr.Is(err, errors.ErrInvalidFrequency):
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+	case stderr.Is(err, errors.ErrCalculatorBusy):
+		var retryErr *errors.RetryAfterError
+		if stderr.As(err, &retryErr) {
+			c.Header("Retry-After", strconv.Itoa(int(math.Ceil(retryErr.RetryAfter.Seconds()))))
+		}
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: errors.ErrCalculatorBusy.Error()})
+	case stderr.Is(err, context.Canceled):
+		c.JSON(statusClientClosedRequest, ErrorResponse{Error: "Request canceled"})
+	case stderr.Is(err, context.DeadlineExceeded):
+		c.JSON(http.StatusGatewayTimeout, ErrorResponse{Error: "Request timed out"})
 	default:
 		c.JSON(http.StatusInternalServerError, ErrorResponse{Error: "Internal server error"})
 	}
 }
 
+// ifMatchVersion returns the version of the pack size required by the If-Match header, zero for
+// any version. Of several versions listed, the current one is required when it is among them. It
+// writes the error response and returns false when the header is missing but required, or lists
+// no ETag of the current version.
+func (h *PackCalculatorHandler) ifMatchVersion(c *gin.Context, id string) (int64, bool) {
+	header := strings.TrimSpace(c.GetHeader("If-Match"))
+	if header == "" {
+		if h.requireIfMatch {
+			c.JSON(http.StatusPreconditionRequired, ErrorResponse{Error: "If-Match header required"})
+
+			return 0, false
+		}
+
+		return 0, true
+	}
+
+	versions, wildcard := ifMatchVersions(header)
+	switch {
+	case wildcard:
+		return 0, true
+	case len(versions) == 1:
+		return versions[0], true
+	case len(versions) > 1:
+		// The update or deletion still only applies to the version matched here
+		packSize, err := h.packSizeService.GetPackSizeByID(c.Request.Context(), id)
+		if err != nil {
+			handleError(c, err)
+
+			return 0, false
+		}
+		for _, version := range versions {
+			if version == packSize.Version {
+				return version, true
+			}
+		}
+	}
+
+	c.JSON(http.StatusPreconditionFailed, ErrorResponse{Error: errors.ErrVersionMismatch.Error()})
+
+	return 0, false
+}
+
+// ifMatchVersions returns the pack size versions of the entity tags in the comma-separated list
+// of an If-Match header, and whether it is the wildcard matching any version. If-Match compares
+// tags strongly, so weak tags (W/"3") and the tags of no version are left out as they never match.
+func ifMatchVersions(header string) ([]int64, bool) {
+	var versions []int64
+	for _, tag := range strings.Split(header, ",") {
+		tag = strings.TrimSpace(tag)
+		if tag == "*" {
+			return nil, true
+		}
+		if len(tag) < 2 || tag[0] != '"' || tag[len(tag)-1] != '"' {
+			continue
+		}
+
+		version, err := strconv.ParseInt(tag[1:len(tag)-1], 10, 64)
+		if err == nil && version >= 1 {
+			versions = append(versions, version)
+		}
+	}
+
+	return versions, false
+}
+
+// packSizeETag returns the entity tag of the version of a pack size
+func packSizeETag(packSize *entities.PackSize) string {
+	return strconv.Quote(strconv.FormatInt(packSize.Version, 10))
+}
+
+// Helper function to convert a pack size request to the optional properties of a pack size
+func toPackSizeAttributes(req CreatePackSizeRequest) entities.PackSizeAttributes {
+	return entities.PackSizeAttributes{
+		Weight:         req.Weight,
+		MaterialWeight: req.MaterialWeight,
+		EmissionFactor: req.EmissionFactor,
+		Dimensions:     toDimensions(req.Dimensions),
+		Name:           req.Name,
+		SKU:            req.SKU,
+		Tags:           req.Tags,
+		Active:         req.Active,
+		ValidFrom:      req.ValidFrom,
+		ValidTo:        req.ValidTo,
+	}
+}
+
+// Helper function to convert pack size requests to definitions
+func toPackSizeDefinitions(reqs []CreatePackSizeRequest) []entities.PackSizeDefinition {
+	definitions := make([]entities.PackSizeDefinition, len(reqs))
+	for i, req := range reqs {
+		definitions[i] = entities.PackSizeDefinition{
+			Size:       req.Size,
+			Attributes: toPackSizeAttributes(req),
+		}
+	}
+
+	return definitions
+}
+
+// Helper function to convert entities to a list response
+func toPackSizesResponse(packSizes []*entities.PackSize) PackSizesResponse {
+	response := PackSizesResponse{
+		Items: make([]PackSizeResponse, len(packSizes)),
+	}
+	for i, ps := range packSizes {
+		response.Items[i] = toPackSizeResponse(ps)
+	}
+
+	return response
+}
+
 // Helper function to convert entity to response
 func toPackSizeResponse(packSize *entities.PackSize) PackSizeResponse {
 	return PackSizeResponse{
-		ID:        packSize.ID,
-		Size:      packSize.Size,
-		CreatedAt: packSize.CreatedAt,
-		UpdatedAt: packSize.UpdatedAt,
+		ID:             packSize.ID,
+		Version:        packSize.Version,
+		Size:           packSize.Size,
+		Name:           packSize.Name,
+		SKU:            packSize.SKU,
+		Tags:           packSize.Tags,
+		Active:         packSize.Active,
+		Weight:         packSize.Weight,
+		MaterialWeight: packSize.MaterialWeight,
+		EmissionFactor: packSize.EmissionFactor,
+		Dimensions:     toDimensionsResponse(packSize.Dimensions),
+		ValidFrom:      packSize.ValidFrom,
+		ValidTo:        packSize.ValidTo,
+		CreatedAt:      packSize.CreatedAt,
+		UpdatedAt:      packSize.UpdatedAt,
+		DeletedAt:      packSize.DeletedAt,
 	}
 }
 
 // Helper function to convert calculation result to response
 func toCalculationResponse(result *entities.CalculationResult) CalculationResponse {
-	return CalculationResponse{
+	response := CalculationResponse{
+		ID:           result.ID,
 		ItemsOrdered: result.ItemsOrdered,
 		TotalItems:   result.TotalItems,
 		Packs:        result.Packs,
+		TotalWeight:  result.TotalWeight,
+		Footprint: PackagingFootprintResponse{
+			Material:  result.Footprint.Material,
+			Emissions: result.Footprint.Emissions,
+		},
+	}
+
+	for _, estimate := range result.ShippingEstimates {
+		response.ShippingEstimates = append(response.ShippingEstimates, ShippingEstimateResponse{
+			Carrier: estimate.Carrier,
+			Price:   estimate.Price,
+		})
 	}
+
+	return response
+}
+
+// Helper function to convert calculation result to the version 2 response
+func toCalculationResponseV2(result *entities.CalculationResult) CalculationResponseV2 {
+	v1 := toCalculationResponse(result)
+
+	response := CalculationResponseV2{
+		ID:                v1.ID,
+		ItemsOrdered:      v1.ItemsOrdered,
+		TotalItems:        v1.TotalItems,
+		TotalPacks:        result.TotalPacks(),
+		Overshoot:         result.Overshoot(),
+		Lines:             make([]PackLineResponse, 0, len(result.Packs)),
+		TotalWeight:       v1.TotalWeight,
+		ShippingEstimates: v1.ShippingEstimates,
+		Footprint:         v1.Footprint,
+	}
+
+	for _, line := range result.Lines() {
+		response.Lines = append(response.Lines, PackLineResponse{
+			PackSize: line.PackSize,
+			Quantity: line.Quantity,
+		})
+	}
+
+	return response
+}
+
+// Helper function to convert calculation table to response
+func toCalculationTableResponse(table *entities.CalculationTable) CalculationTableResponse {
+	response := CalculationTableResponse{
+		PackSizes: table.PackSizes,
+		Rows:      make([]CalculationTableRow, len(table.Results)),
+	}
+
+	for i, result := range table.Results {
+		response.Rows[i] = CalculationTableRow{
+			ItemsOrdered: result.ItemsOrdered,
+			TotalItems:   result.TotalItems,
+			TotalPacks:   result.TotalPacks(),
+			Overshoot:    result.Overshoot(),
+			Lines:        make([]PackLineResponse, 0, len(result.Packs)),
+			TotalWeight:  result.TotalWeight,
+		}
+		for _, line := range result.Lines() {
+			response.Rows[i].Lines = append(response.Rows[i].Lines, PackLineResponse{
+				PackSize: line.PackSize,
+				Quantity: line.Quantity,
+			})
+		}
+	}
+
+	return response
+}
+
+// Helper function to convert calculation table to CSV with one column per pack size
+func toCalculationTableCSV(table *entities.CalculationTable) []byte {
+	var buf bytes.Buffer
+	w := csv.NewWriter(&buf)
+
+	header := []string{"items_ordered", "total_items", "total_packs", "overshoot", "total_weight"}
+	for _, size := range table.PackSizes {
+		header = append(header, fmt.Sprintf("pack_%d", size))
+	}
+	_ = w.Write(header)
+
+	for _, result := range table.Results {
+		record := []string{
+			strconv.Itoa(result.ItemsOrdered),
+			strconv.Itoa(result.TotalItems),
+			strconv.Itoa(result.TotalPacks()),
+			strconv.Itoa(result.Overshoot()),
+			strconv.FormatFloat(result.TotalWeight, 'f', -1, 64),
+		}
+		for _, size := range table.PackSizes {
+			record = append(record, strconv.Itoa(result.Packs[size]))
+		}
+		_ = w.Write(record)
+	}
+	w.Flush()
+
+	return buf.Bytes()
+}
+
+// Helper function to convert packing verification to response
+func toVerificationResponse(verification *entities.PackingVerification) VerificationResponse {
+	response := VerificationResponse{
+		ItemsOrdered: verification.ItemsOrdered,
+		Valid:        verification.Valid(),
+		Violations:   make([]RuleViolationResponse, len(verification.Violations)),
+		Optimal:      toPackingSummaryResponse(verification.Optimal),
+	}
+
+	for i, violation := range verification.Violations {
+		response.Violations[i] = RuleViolationResponse{
+			Rule:    int(violation.Rule),
+			Message: violation.Message,
+		}
+	}
+	if verification.Proposed != nil {
+		proposed := toPackingSummaryResponse(verification.Proposed)
+		response.Proposed = &proposed
+	}
+
+	return response
+}
+
+// Helper function to convert consolidation result to response
+func toConsolidationResponse(result *entities.ConsolidationResult) ConsolidationResponse {
+	response := ConsolidationResponse{
+		Combined:    toCalculationResponse(result.Combined),
+		Separate:    make([]OrderCalculationResponse, len(result.Separate)),
+		Allocations: make([]OrderAllocationResponse, len(result.Allocations)),
+		Savings: ConsolidationSavingsResponse{
+			Items: result.Savings.Items,
+			Packs: result.Savings.Packs,
+		},
+	}
+
+	for i, separate := range result.Separate {
+		response.Separate[i] = OrderCalculationResponse{
+			OrderID:             result.Orders[i].ID,
+			CalculationResponse: toCalculationResponse(separate),
+		}
+	}
+
+	for i, allocation := range result.Allocations {
+		response.Allocations[i] = OrderAllocationResponse{
+			OrderID:        allocation.OrderID,
+			ItemsOrdered:   allocation.ItemsOrdered,
+			AllocatedItems: allocation.AllocatedItems,
+			Packs:          allocation.Packs,
+		}
+	}
+
+	return response
+}
+
+// Helper function to convert dimensions from a request
+func toDimensions(dimensions DimensionsRequest) entities.Dimensions {
+	return entities.Dimensions{
+		Length: dimensions.Length,
+		Width:  dimensions.Width,
+		Height: dimensions.Height,
+	}
+}
+
+// Helper function to convert dimensions to response
+func toDimensionsResponse(dimensions entities.Dimensions) DimensionsResponse {
+	return DimensionsResponse{
+		Length: dimensions.Length,
+		Width:  dimensions.Width,
+		Height: dimensions.Height,
+	}
+}
+
+// Helper function to convert box packing result to response
+func toBoxPackingResponse(result *entities.BoxPackingResult) BoxPackingResponse {
+	response := BoxPackingResponse{
+		Calculation: toCalculationResponse(result.Calculation),
+		TotalBoxes:  len(result.Boxes),
+		Boxes:       make([]BoxAssignmentResponse, len(result.Boxes)),
+	}
+
+	for i, assignment := range result.Boxes {
+		placements := make([]PackPlacementResponse, len(assignment.Placements))
+		for j, placement := range assignment.Placements {
+			placements[j] = PackPlacementResponse{
+				PackSize: placement.PackSize,
+				Position: PositionResponse{
+					X: placement.Position.X,
+					Y: placement.Position.Y,
+					Z: placement.Position.Z,
+				},
+				Dimensions: toDimensionsResponse(placement.Dimensions),
+			}
+		}
+
+		response.Boxes[i] = BoxAssignmentResponse{
+			Box: BoxResponse{
+				Name:       assignment.Box.Name,
+				Dimensions: toDimensionsResponse(assignment.Box.Dimensions),
+				MaxWeight:  assignment.Box.MaxWeight,
+			},
+			Packs:       assignment.Packs,
+			Placements:  placements,
+			TotalWeight: assignment.TotalWeight,
+		}
+	}
+
+	return response
 }
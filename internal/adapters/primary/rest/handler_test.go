@@ -2,11 +2,14 @@ package rest
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	stderrors "errors"
+	"fmt"
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/stretchr/testify/assert"
@@ -24,20 +27,41 @@ type mockPackSizeService struct {
 	paginatedItems []*entities.PackSize
 	totalCount     int64
 	isLastPage     bool
+	attributes     entities.PackSizeAttributes
+	version        int64 // Version required by the last update or deletion
+	definitions    []entities.PackSizeDefinition
+	ids            []string
+	changes        *entities.PackSizeChanges
 }
 
-func (m *mockPackSizeService) CreatePackSize(size int) (*entities.PackSize, error) {
+func (m *mockPackSizeService) CreatePackSizes(ctx context.Context, definitions []entities.PackSizeDefinition) ([]*entities.PackSize, error) {
+	m.definitions = definitions
+	return m.packSizes, m.err
+}
+
+func (m *mockPackSizeService) ReplacePackSizes(ctx context.Context, definitions []entities.PackSizeDefinition) (*entities.PackSizeChanges, error) {
+	m.definitions = definitions
+	return m.changes, m.err
+}
+
+func (m *mockPackSizeService) DeletePackSizes(ctx context.Context, ids []string) error {
+	m.ids = ids
+	return m.err
+}
+
+func (m *mockPackSizeService) CreatePackSize(ctx context.Context, size int, attributes entities.PackSizeAttributes) (*entities.PackSize, error) {
+	m.attributes = attributes
 	if m.err != nil {
 		return nil, m.err
 	}
 	return m.packSize, nil
 }
 
-func (m *mockPackSizeService) GetAllPackSizes() ([]*entities.PackSize, error) {
+func (m *mockPackSizeService) GetAllPackSizes(ctx context.Context) ([]*entities.PackSize, error) {
 	return m.packSizes, m.err
 }
 
-func (m *mockPackSizeService) GetAllPackSizesWithPagination(page, limit int64) (*types.Pagination, error) {
+func (m *mockPackSizeService) GetAllPackSizesWithPagination(ctx context.Context, page, limit int64) (*types.Pagination, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
@@ -51,30 +75,74 @@ func (m *mockPackSizeService) GetAllPackSizesWithPagination(page, limit int64) (
 	return types.NewPagination(page, limit, m.totalCount, m.isLastPage, items), nil
 }
 
-func (m *mockPackSizeService) GetPackSizeByID(id string) (*entities.PackSize, error) {
+func (m *mockPackSizeService) GetPackSizeByID(ctx context.Context, id string) (*entities.PackSize, error) {
 	return m.packSize, m.err
 }
 
-func (m *mockPackSizeService) UpdatePackSize(id string, size int) (*entities.PackSize, error) {
+func (m *mockPackSizeService) GetDeletedPackSizes(ctx context.Context) ([]*entities.PackSize, error) {
+	return m.packSizes, m.err
+}
+
+func (m *mockPackSizeService) RestorePackSize(ctx context.Context, id string) (*entities.PackSize, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.packSize, nil
+}
+
+func (m *mockPackSizeService) UpdatePackSize(ctx context.Context, id string, version int64, size int, attributes entities.PackSizeAttributes) (*entities.PackSize, error) {
+	m.version = version
 	if m.err != nil {
 		return nil, m.err
 	}
 	return m.packSize, nil
 }
 
-func (m *mockPackSizeService) DeletePackSize(id string) error {
+func (m *mockPackSizeService) DeletePackSize(ctx context.Context, id string, version int64) error {
+	m.version = version
 	return m.err
 }
 
 type mockCalculationService struct {
-	result *entities.CalculationResult
-	err    error
+	result              *entities.CalculationResult
+	consolidationResult *entities.ConsolidationResult
+	boxPackingResult    *entities.BoxPackingResult
+	table               *entities.CalculationTable
+	verification        *entities.PackingVerification
+	document            *entities.Document
+	err                 error
+	options             entities.CalculationOptions // Options of the last calculation
+}
+
+func (m *mockCalculationService) CalculatePacksForOrder(ctx context.Context, itemsOrdered int, options entities.CalculationOptions) (*entities.CalculationResult, error) {
+	m.options = options
+	return m.result, m.err
+}
+
+func (m *mockCalculationService) ConsolidateOrders(ctx context.Context, orders []entities.Order) (*entities.ConsolidationResult, error) {
+	return m.consolidationResult, m.err
+}
+
+func (m *mockCalculationService) PackOrderIntoBoxes(ctx context.Context, itemsOrdered int, boxes []entities.Box) (*entities.BoxPackingResult, error) {
+	return m.boxPackingResult, m.err
 }
 
-func (m *mockCalculationService) CalculatePacksForOrder(itemsOrdered int) (*entities.CalculationResult, error) {
+func (m *mockCalculationService) CalculatePackingTable(ctx context.Context, from, to, step int) (*entities.CalculationTable, error) {
+	return m.table, m.err
+}
+
+func (m *mockCalculationService) VerifyPacking(ctx context.Context, itemsOrdered int, proposed map[int]float64) (*entities.PackingVerification, error) {
+	return m.verification, m.err
+}
+
+func (m *mockCalculationService) GetCalculationByID(ctx context.Context, id string) (*entities.CalculationResult, error) {
 	return m.result, m.err
 }
 
+func (m *mockCalculationService) GeneratePackingSlip(ctx context.Context, calculationID string, format entities.DocumentFormat) (*entities.Document, error) {
+	return m.document, m.err
+}
+
 func setupRouter() *gin.Engine {
 	gin.SetMode(gin.TestMode)
 	return gin.New()
@@ -106,6 +174,13 @@ func TestPackCalculatorHandler_CreatePackSize(t *testing.T) {
 			mockErr:        nil,
 			expectedStatus: http.StatusBadRequest,
 		},
+		{
+			name:           "Duplicate size",
+			requestBody:    map[string]interface{}{"size": 100},
+			mockPackSize:   nil,
+			mockErr:        fmt.Errorf("%w: size 100", errors.ErrDuplicatePackSize),
+			expectedStatus: http.StatusConflict,
+		},
 		{
 			name:           "Service error",
 			requestBody:    map[string]interface{}{"size": 100},
@@ -152,6 +227,136 @@ func TestPackCalculatorHandler_CreatePackSize(t *testing.T) {
 	}
 }
 
+func TestPackCalculatorHandler_CreatePackSizeWithDetails(t *testing.T) {
+	// Create test pack size
+	testPackSize, _ := entities.NewPackSize(250)
+	testPackSize.ID = "test-id"
+	inactive := false
+	_ = testPackSize.SetAttributes(entities.PackSizeAttributes{
+		Name:   "Small box",
+		SKU:    "PK-250",
+		Tags:   []string{"small"},
+		Active: &inactive,
+	})
+
+	// Setup
+	router := setupRouter()
+	mockPackSizeService := &mockPackSizeService{packSize: testPackSize}
+	handler := NewPackCalculatorHandler(mockPackSizeService, &mockCalculationService{})
+	handler.RegisterRoutes(router)
+
+	// Perform request
+	reqBody := `{"size": 250, "name": "Small box", "sku": "PK-250", "tags": ["small"], "active": false}`
+	req, _ := http.NewRequest(http.MethodPost, "/api/pack-sizes", bytes.NewBufferString(reqBody))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Check the details reach the service and come back in the response
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Equal(t, "Small box", mockPackSizeService.attributes.Name)
+	assert.Equal(t, "PK-250", mockPackSizeService.attributes.SKU)
+	assert.Equal(t, []string{"small"}, mockPackSizeService.attributes.Tags)
+	if assert.NotNil(t, mockPackSizeService.attributes.Active) {
+		assert.False(t, *mockPackSizeService.attributes.Active)
+	}
+
+	var response PackSizeResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "Small box", response.Name)
+	assert.Equal(t, "PK-250", response.SKU)
+	assert.Equal(t, []string{"small"}, response.Tags)
+	assert.False(t, response.Active)
+}
+
+func TestPackCalculatorHandler_UpdatePackSize(t *testing.T) {
+	// Create test pack size
+	testPackSize, _ := entities.NewPackSize(500)
+	testPackSize.ID = "test-id"
+
+	tests := []struct {
+		name            string
+		requestBody     map[string]interface{}
+		ifMatch         string
+		mockPackSize    *entities.PackSize
+		mockErr         error
+		expectedStatus  int
+		expectedVersion int64
+	}{
+		{
+			name:           "Success",
+			requestBody:    map[string]interface{}{"size": 500},
+			mockPackSize:   testPackSize,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:            "Success with If-Match",
+			requestBody:     map[string]interface{}{"size": 500},
+			ifMatch:         `"3"`,
+			mockPackSize:    testPackSize,
+			expectedStatus:  http.StatusOK,
+			expectedVersion: 3,
+		},
+		{
+			name:            "Success with a list of versions",
+			requestBody:     map[string]interface{}{"size": 500},
+			ifMatch:         `"3", "4"`,
+			mockPackSize:    &entities.PackSize{ID: "test-id", Size: 500, Version: 4},
+			expectedStatus:  http.StatusOK,
+			expectedVersion: 4,
+		},
+		{
+			name:            "Version changed",
+			requestBody:     map[string]interface{}{"size": 500},
+			ifMatch:         `"3"`,
+			mockErr:         fmt.Errorf("%w: version 4, not 3", errors.ErrVersionMismatch),
+			expectedStatus:  http.StatusPreconditionFailed,
+			expectedVersion: 3,
+		},
+		{
+			name:           "Not found",
+			requestBody:    map[string]interface{}{"size": 500},
+			mockErr:        &errors.NotFoundError{ID: "test-id", Err: errors.ErrPackSizeNotFound},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Duplicate size",
+			requestBody:    map[string]interface{}{"size": 500},
+			mockErr:        fmt.Errorf("%w: size 500", errors.ErrDuplicatePackSize),
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			router := setupRouter()
+			mockPackSizeService := &mockPackSizeService{
+				packSize: tt.mockPackSize,
+				err:      tt.mockErr,
+			}
+
+			handler := NewPackCalculatorHandler(mockPackSizeService, &mockCalculationService{})
+			handler.RegisterRoutes(router)
+
+			// Perform request
+			reqBody, _ := json.Marshal(tt.requestBody)
+			req, _ := http.NewRequest(http.MethodPut, "/api/pack-sizes/test-id", bytes.NewBuffer(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Check response
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedVersion, mockPackSizeService.version)
+		})
+	}
+}
+
 func TestPackCalculatorHandler_GetAllPackSizes(t *testing.T) {
 	// Create test pack sizes
 	ps1, _ := entities.NewPackSize(100)
@@ -249,6 +454,7 @@ func TestPackCalculatorHandler_GetPackSizeByID(t *testing.T) {
 	// Create test pack size
 	testPackSize, _ := entities.NewPackSize(100)
 	testPackSize.ID = "test-id"
+	testPackSize.Version = 2
 
 	// Create a NotFoundError for the test
 	notFoundErr := &errors.NotFoundError{
@@ -309,42 +515,146 @@ func TestPackCalculatorHandler_GetPackSizeByID(t *testing.T) {
 				assert.NoError(t, err)
 				assert.Equal(t, testPackSize.ID, response.ID)
 				assert.Equal(t, testPackSize.Size, response.Size)
+				assert.Equal(t, int64(2), response.Version)
+				assert.Equal(t, `"2"`, w.Header().Get("ETag"))
 			}
 		})
 	}
 }
 
-func TestPackCalculatorHandler_CalculatePacks(t *testing.T) {
-	// Create test calculation result
-	testResult := entities.NewCalculationResult(10, map[int]int{5: 2})
+func TestPackCalculatorHandler_DeletePackSize(t *testing.T) {
+	current := &entities.PackSize{ID: "test-id", Size: 250, Version: 8}
+
+	tests := []struct {
+		name            string
+		ifMatch         string
+		requireIfMatch  bool
+		mockErr         error
+		expectedStatus  int
+		expectedVersion int64
+	}{
+		{
+			name:           "Any version without If-Match",
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:            "Version of If-Match",
+			ifMatch:         `"7"`,
+			expectedStatus:  http.StatusNoContent,
+			expectedVersion: 7,
+		},
+		{
+			name:           "Any version with wildcard",
+			ifMatch:        "*",
+			requireIfMatch: true,
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:            "Version changed",
+			ifMatch:         `"7"`,
+			mockErr:         fmt.Errorf("%w: version 8, not 7", errors.ErrVersionMismatch),
+			expectedStatus:  http.StatusPreconditionFailed,
+			expectedVersion: 7,
+		},
+		{
+			name:           "Weak entity tag never matches",
+			ifMatch:        `W/"7"`,
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:            "List with the current version",
+			ifMatch:         `"6", W/"7", "8"`,
+			expectedStatus:  http.StatusNoContent,
+			expectedVersion: 8,
+		},
+		{
+			name:            "List with one strong entity tag",
+			ifMatch:         `W/"8","7"`,
+			expectedStatus:  http.StatusNoContent,
+			expectedVersion: 7,
+		},
+		{
+			name:           "List without the current version",
+			ifMatch:        `"6", "7"`,
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:           "List of weak entity tags",
+			ifMatch:        `W/"7", W/"8"`,
+			expectedStatus: http.StatusPreconditionFailed,
+		},
+		{
+			name:           "List of a pack size not found",
+			ifMatch:        `"6", "7"`,
+			mockErr:        &errors.NotFoundError{ID: "test-id", Err: errors.ErrPackSizeNotFound},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "If-Match required",
+			requireIfMatch: true,
+			expectedStatus: http.StatusPreconditionRequired,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			router := setupRouter()
+			mockPackSizeService := &mockPackSizeService{packSize: current, err: tt.mockErr}
+
+			handler := NewPackCalculatorHandler(mockPackSizeService, &mockCalculationService{})
+			handler.SetRequireIfMatch(tt.requireIfMatch)
+			handler.RegisterRoutes(router)
+
+			// Perform request
+			req, _ := http.NewRequest(http.MethodDelete, "/api/pack-sizes/test-id", nil)
+			if tt.ifMatch != "" {
+				req.Header.Set("If-Match", tt.ifMatch)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Check response
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedVersion, mockPackSizeService.version)
+		})
+	}
+}
+
+func TestPackCalculatorHandler_ReplacePackSizes(t *testing.T) {
+	created, _ := entities.NewPackSize(300)
+	created.ID = "created-id"
+	deleted, _ := entities.NewPackSize(250)
+	deleted.ID = "deleted-id"
 
 	tests := []struct {
 		name           string
-		requestBody    map[string]interface{}
-		mockResult     *entities.CalculationResult
+		requestBody    string
+		changes        *entities.PackSizeChanges
 		mockErr        error
 		expectedStatus int
 	}{
 		{
 			name:           "Success",
-			requestBody:    map[string]interface{}{"items_ordered": 10},
-			mockResult:     testResult,
-			mockErr:        nil,
+			requestBody:    `{"pack_sizes": [{"size": 300, "name": "Small"}, {"size": 600}]}`,
+			changes:        &entities.PackSizeChanges{Created: []*entities.PackSize{created}, Updated: []*entities.PackSize{}, Deleted: []*entities.PackSize{deleted}},
 			expectedStatus: http.StatusOK,
 		},
 		{
-			name:           "Invalid request",
-			requestBody:    map[string]interface{}{"items_ordered": "invalid"},
-			mockResult:     nil,
-			mockErr:        nil,
+			name:           "Empty set",
+			requestBody:    `{"pack_sizes": []}`,
 			expectedStatus: http.StatusBadRequest,
 		},
 		{
-			name:           "Service error",
-			requestBody:    map[string]interface{}{"items_ordered": 10},
-			mockResult:     nil,
-			mockErr:        stderrors.New("service error"),
-			expectedStatus: http.StatusInternalServerError,
+			name:           "Invalid pack size",
+			requestBody:    `{"pack_sizes": [{"size": 300}, {"size": 0}]}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Duplicate size",
+			requestBody:    `{"pack_sizes": [{"size": 300}, {"size": 300}]}`,
+			mockErr:        fmt.Errorf("%w: size 300 at pack_sizes[0] and pack_sizes[1]", errors.ErrDuplicatePackSize),
+			expectedStatus: http.StatusConflict,
 		},
 	}
 
@@ -352,34 +662,991 @@ func TestPackCalculatorHandler_CalculatePacks(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Setup
 			router := setupRouter()
-			mockPackSizeService := &mockPackSizeService{}
-			mockCalculationService := &mockCalculationService{
-				result: tt.mockResult,
-				err:    tt.mockErr,
-			}
+			mockPackSizeService := &mockPackSizeService{changes: tt.changes, err: tt.mockErr}
 
-			handler := NewPackCalculatorHandler(mockPackSizeService, mockCalculationService)
+			handler := NewPackCalculatorHandler(mockPackSizeService, &mockCalculationService{})
 			handler.RegisterRoutes(router)
 
-			// Create request
-			reqBody, _ := json.Marshal(tt.requestBody)
-			req, _ := http.NewRequest(http.MethodPost, "/api/calculate-packs", bytes.NewBuffer(reqBody))
+			// Perform request
+			req, _ := http.NewRequest(http.MethodPut, "/api/pack-sizes", bytes.NewBufferString(tt.requestBody))
 			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Check response
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			if assert.Len(t, mockPackSizeService.definitions, 2) {
+				assert.Equal(t, 300, mockPackSizeService.definitions[0].Size)
+				assert.Equal(t, "Small", mockPackSizeService.definitions[0].Attributes.Name)
+			}
+
+			var response PackSizeChangesResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			if assert.Len(t, response.Created, 1) && assert.Len(t, response.Deleted, 1) {
+				assert.Equal(t, "created-id", response.Created[0].ID)
+				assert.Equal(t, "deleted-id", response.Deleted[0].ID)
+			}
+			assert.Empty(t, response.Updated)
+		})
+	}
+}
+
+func TestPackCalculatorHandler_CreatePackSizes(t *testing.T) {
+	ps1, _ := entities.NewPackSize(300)
+	ps2, _ := entities.NewPackSize(600)
+
+	// Setup
+	router := setupRouter()
+	mockPackSizeService := &mockPackSizeService{packSizes: []*entities.PackSize{ps1, ps2}}
+
+	handler := NewPackCalculatorHandler(mockPackSizeService, &mockCalculationService{})
+	handler.RegisterRoutes(router)
+
+	// Perform request
+	req, _ := http.NewRequest(http.MethodPost, "/api/pack-sizes/bulk", bytes.NewBufferString(`{"pack_sizes": [{"size": 300}, {"size": 600}]}`))
+	req.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Check response
+	assert.Equal(t, http.StatusCreated, w.Code)
+	assert.Len(t, mockPackSizeService.definitions, 2)
+
+	var response PackSizesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Len(t, response.Items, 2)
+}
+
+func TestPackCalculatorHandler_DeletePackSizes(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    string
+		mockErr        error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			requestBody:    `{"ids": ["a", "b"]}`,
+			expectedStatus: http.StatusNoContent,
+		},
+		{
+			name:           "No IDs",
+			requestBody:    `{"ids": []}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "One not found",
+			requestBody:    `{"ids": ["a", "missing"]}`,
+			mockErr:        &errors.NotFoundError{ID: "missing", Err: errors.ErrPackSizeNotFound},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			router := setupRouter()
+			mockPackSizeService := &mockPackSizeService{err: tt.mockErr}
+
+			handler := NewPackCalculatorHandler(mockPackSizeService, &mockCalculationService{})
+			handler.RegisterRoutes(router)
 
 			// Perform request
+			req, _ := http.NewRequest(http.MethodPost, "/api/pack-sizes/bulk-delete", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
 			w := httptest.NewRecorder()
 			router.ServeHTTP(w, req)
 
 			// Check response
 			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusNoContent {
+				assert.Equal(t, []string{"a", "b"}, mockPackSizeService.ids)
+			}
+		})
+	}
+}
 
-			// If success, check response body
-			if tt.expectedStatus == http.StatusOK {
-				var response CalculationResponse
-				err := json.Unmarshal(w.Body.Bytes(), &response)
-				assert.NoError(t, err)
-				assert.Equal(t, testResult.ItemsOrdered, response.ItemsOrdered)
-				assert.Equal(t, len(testResult.Packs), len(response.Packs))
+func TestPackCalculatorHandler_GetDeletedPackSizes(t *testing.T) {
+	// Create a pack size in the trash
+	deletedAt := time.Now()
+	testPackSize, _ := entities.NewPackSize(100)
+	testPackSize.ID = "test-id"
+	testPackSize.DeletedAt = &deletedAt
+
+	router := setupRouter()
+	mockPackSizeService := &mockPackSizeService{
+		packSizes: []*entities.PackSize{testPackSize},
+	}
+
+	handler := NewPackCalculatorHandler(mockPackSizeService, &mockCalculationService{})
+	handler.RegisterRoutes(router)
+
+	req, _ := http.NewRequest(http.MethodGet, "/api/pack-sizes/trash", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	var response PackSizesResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	if assert.Len(t, response.Items, 1) {
+		assert.Equal(t, testPackSize.ID, response.Items[0].ID)
+		if assert.NotNil(t, response.Items[0].DeletedAt) {
+			assert.True(t, deletedAt.Equal(*response.Items[0].DeletedAt))
+		}
+	}
+}
+
+func TestPackCalculatorHandler_RestorePackSize(t *testing.T) {
+	// Create test pack size
+	testPackSize, _ := entities.NewPackSize(100)
+	testPackSize.ID = "test-id"
+
+	tests := []struct {
+		name           string
+		id             string
+		mockPackSize   *entities.PackSize
+		mockErr        error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			id:             "test-id",
+			mockPackSize:   testPackSize,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Not in the trash",
+			id:             "non-existent-id",
+			mockErr:        &errors.NotFoundError{ID: "non-existent-id", Err: errors.ErrPackSizeNotFound},
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name:           "Size taken",
+			id:             "test-id",
+			mockErr:        fmt.Errorf("%w: size 100", errors.ErrDuplicatePackSize),
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			router := setupRouter()
+			mockPackSizeService := &mockPackSizeService{
+				packSize: tt.mockPackSize,
+				err:      tt.mockErr,
+			}
+
+			handler := NewPackCalculatorHandler(mockPackSizeService, &mockCalculationService{})
+			handler.RegisterRoutes(router)
+
+			// Perform request
+			req, _ := http.NewRequest(http.MethodPost, "/api/pack-sizes/"+tt.id+"/restore", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Check response
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			if tt.expectedStatus == http.StatusOK {
+				var response PackSizeResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, testPackSize.ID, response.ID)
+				assert.Nil(t, response.DeletedAt)
+			}
+		})
+	}
+}
+
+func TestPackCalculatorHandler_CalculatePacks(t *testing.T) {
+	// Create test calculation result
+	testResult := entities.NewCalculationResult(10, map[int]int{5: 2})
+	testResult.Footprint = entities.PackagingFootprint{Material: 0.4, Emissions: 0.6}
+
+	tests := []struct {
+		name               string
+		requestBody        map[string]interface{}
+		mockResult         *entities.CalculationResult
+		mockErr            error
+		expectedStatus     int
+		expectedRetryAfter string
+	}{
+		{
+			name:           "Success",
+			requestBody:    map[string]interface{}{"items_ordered": 10},
+			mockResult:     testResult,
+			mockErr:        nil,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Success with material objective",
+			requestBody:    map[string]interface{}{"items_ordered": 10, "objective": "material"},
+			mockResult:     testResult,
+			mockErr:        nil,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Invalid request",
+			requestBody:    map[string]interface{}{"items_ordered": "invalid"},
+			mockResult:     nil,
+			mockErr:        nil,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid objective",
+			requestBody:    map[string]interface{}{"items_ordered": 10, "objective": "cheapest"},
+			mockResult:     nil,
+			mockErr:        nil,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Calculation timed out",
+			requestBody:    map[string]interface{}{"items_ordered": 10},
+			mockResult:     nil,
+			mockErr:        context.DeadlineExceeded,
+			expectedStatus: http.StatusGatewayTimeout,
+		},
+		{
+			name:        "Calculation too large",
+			requestBody: map[string]interface{}{"items_ordered": 10},
+			mockResult:  nil,
+			mockErr: &errors.ValidationError{
+				Field: "items_ordered",
+				Err:   errors.ErrCalculationTooLarge,
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:        "Calculator busy",
+			requestBody: map[string]interface{}{"items_ordered": 10},
+			mockResult:  nil,
+			mockErr: &errors.RetryAfterError{
+				RetryAfter: 1500 * time.Millisecond,
+				Err:        errors.ErrCalculatorBusy,
+			},
+			expectedStatus:     http.StatusServiceUnavailable,
+			expectedRetryAfter: "2",
+		},
+		{
+			name:           "Service error",
+			requestBody:    map[string]interface{}{"items_ordered": 10},
+			mockResult:     nil,
+			mockErr:        stderrors.New("service error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			router := setupRouter()
+			mockPackSizeService := &mockPackSizeService{}
+			mockCalculationService := &mockCalculationService{
+				result: tt.mockResult,
+				err:    tt.mockErr,
+			}
+
+			handler := NewPackCalculatorHandler(mockPackSizeService, mockCalculationService)
+			handler.RegisterRoutes(router)
+
+			// Create request
+			reqBody, _ := json.Marshal(tt.requestBody)
+			req, _ := http.NewRequest(http.MethodPost, "/api/calculate-packs", bytes.NewBuffer(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			// Perform request
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Check response
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedRetryAfter, w.Header().Get("Retry-After"))
+
+			// If success, check response body
+			if tt.expectedStatus == http.StatusOK {
+				var response CalculationResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, testResult.ItemsOrdered, response.ItemsOrdered)
+				assert.Equal(t, len(testResult.Packs), len(response.Packs))
+				assert.Equal(t, testResult.Footprint.Material, response.Footprint.Material)
+				assert.Equal(t, testResult.Footprint.Emissions, response.Footprint.Emissions)
+			}
+		})
+	}
+}
+
+func TestPackCalculatorHandler_CalculatePacksAsOf(t *testing.T) {
+	tests := []struct {
+		name           string
+		requestBody    string
+		expectedStatus int
+		expectedAsOf   time.Time
+	}{
+		{
+			name:           "Current pack sizes",
+			requestBody:    `{"items_ordered": 10}`,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Pack sizes valid at the ship date",
+			requestBody:    `{"items_ordered": 10, "as_of": "2026-11-01T08:00:00+02:00"}`,
+			expectedStatus: http.StatusOK,
+			expectedAsOf:   time.Date(2026, 11, 1, 6, 0, 0, 0, time.UTC),
+		},
+		{
+			name:           "Invalid timestamp",
+			requestBody:    `{"items_ordered": 10, "as_of": "next monday"}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			router := setupRouter()
+			mockCalculationService := &mockCalculationService{
+				result: entities.NewCalculationResult(10, map[int]int{5: 2}),
+			}
+
+			handler := NewPackCalculatorHandler(&mockPackSizeService{}, mockCalculationService)
+			handler.RegisterRoutes(router)
+
+			// Perform request
+			req, _ := http.NewRequest(http.MethodPost, "/api/calculate-packs", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Check response and the time passed on
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedAsOf, mockCalculationService.options.AsOf)
+		})
+	}
+}
+
+func TestPackCalculatorHandler_ConsolidateOrders(t *testing.T) {
+	// Create test consolidation result
+	orders := []entities.Order{{ID: "a", ItemsOrdered: 1}, {ID: "b", ItemsOrdered: 1}}
+	testResult := &entities.ConsolidationResult{
+		Orders:   orders,
+		Combined: entities.NewCalculationResult(2, map[int]int{250: 1}),
+		Separate: []*entities.CalculationResult{
+			entities.NewCalculationResult(1, map[int]int{250: 1}),
+			entities.NewCalculationResult(1, map[int]int{250: 1}),
+		},
+		Allocations: []entities.OrderAllocation{
+			{OrderID: "a", ItemsOrdered: 1, AllocatedItems: 250, Packs: map[int]int{250: 1}},
+			{OrderID: "b", ItemsOrdered: 1, AllocatedItems: 0, Packs: map[int]int{}},
+		},
+		Savings: entities.ConsolidationSavings{Items: 250, Packs: 1},
+	}
+
+	tests := []struct {
+		name           string
+		requestBody    map[string]interface{}
+		mockResult     *entities.ConsolidationResult
+		mockErr        error
+		expectedStatus int
+	}{
+		{
+			name: "Success",
+			requestBody: map[string]interface{}{"orders": []map[string]interface{}{
+				{"order_id": "a", "items_ordered": 1},
+				{"order_id": "b", "items_ordered": 1},
+			}},
+			mockResult:     testResult,
+			mockErr:        nil,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Missing orders",
+			requestBody:    map[string]interface{}{"orders": []map[string]interface{}{}},
+			mockResult:     nil,
+			mockErr:        nil,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid order",
+			requestBody: map[string]interface{}{"orders": []map[string]interface{}{
+				{"order_id": "a", "items_ordered": 0},
+			}},
+			mockResult:     nil,
+			mockErr:        nil,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Duplicate order IDs",
+			requestBody: map[string]interface{}{"orders": []map[string]interface{}{
+				{"order_id": "a", "items_ordered": 1},
+				{"order_id": "a", "items_ordered": 1},
+			}},
+			mockResult:     nil,
+			mockErr:        errors.ErrDuplicateOrderID,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Service error",
+			requestBody: map[string]interface{}{"orders": []map[string]interface{}{
+				{"order_id": "a", "items_ordered": 1},
+			}},
+			mockResult:     nil,
+			mockErr:        stderrors.New("service error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			router := setupRouter()
+			mockPackSizeService := &mockPackSizeService{}
+			mockCalculationService := &mockCalculationService{
+				consolidationResult: tt.mockResult,
+				err:                 tt.mockErr,
+			}
+
+			handler := NewPackCalculatorHandler(mockPackSizeService, mockCalculationService)
+			handler.RegisterRoutes(router)
+
+			// Create request
+			reqBody, _ := json.Marshal(tt.requestBody)
+			req, _ := http.NewRequest(http.MethodPost, "/api/calculate-packs/consolidate", bytes.NewBuffer(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			// Perform request
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Check response
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			// If success, check response body
+			if tt.expectedStatus == http.StatusOK {
+				var response ConsolidationResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, 2, response.Combined.ItemsOrdered)
+				assert.Len(t, response.Separate, 2)
+				assert.Equal(t, "a", response.Separate[0].OrderID)
+				assert.Len(t, response.Allocations, 2)
+				assert.Equal(t, 250, response.Savings.Items)
+				assert.Equal(t, 1, response.Savings.Packs)
+			}
+		})
+	}
+}
+
+func TestPackCalculatorHandler_PackIntoBoxes(t *testing.T) {
+	// Create test box packing result
+	box := entities.Box{Name: "M", Dimensions: entities.Dimensions{Length: 20, Width: 10, Height: 10}, MaxWeight: 10}
+	testResult := &entities.BoxPackingResult{
+		Calculation: entities.NewCalculationResult(300, map[int]int{250: 2}),
+		Boxes: []entities.BoxAssignment{
+			{
+				Box:   box,
+				Packs: map[int]int{250: 2},
+				Placements: []entities.PackPlacement{
+					{PackSize: 250, Dimensions: entities.Dimensions{Length: 10, Width: 10, Height: 10}},
+					{PackSize: 250, Position: entities.Position{X: 10}, Dimensions: entities.Dimensions{Length: 10, Width: 10, Height: 10}},
+				},
+				TotalWeight: 2,
+			},
+		},
+	}
+	validBoxes := []map[string]interface{}{
+		{"name": "M", "dimensions": map[string]interface{}{"length": 20, "width": 10, "height": 10}, "max_weight": 10},
+	}
+
+	tests := []struct {
+		name           string
+		requestBody    map[string]interface{}
+		mockResult     *entities.BoxPackingResult
+		mockErr        error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			requestBody:    map[string]interface{}{"items_ordered": 300, "boxes": validBoxes},
+			mockResult:     testResult,
+			mockErr:        nil,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Missing boxes",
+			requestBody:    map[string]interface{}{"items_ordered": 300},
+			mockResult:     nil,
+			mockErr:        nil,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Pack does not fit",
+			requestBody:    map[string]interface{}{"items_ordered": 300, "boxes": validBoxes},
+			mockResult:     nil,
+			mockErr:        errors.ErrPackDoesNotFitBox,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Service error",
+			requestBody:    map[string]interface{}{"items_ordered": 300, "boxes": validBoxes},
+			mockResult:     nil,
+			mockErr:        stderrors.New("service error"),
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			router := setupRouter()
+			mockCalculationService := &mockCalculationService{
+				boxPackingResult: tt.mockResult,
+				err:              tt.mockErr,
+			}
+
+			handler := NewPackCalculatorHandler(&mockPackSizeService{}, mockCalculationService)
+			handler.RegisterRoutes(router)
+
+			// Create request
+			reqBody, _ := json.Marshal(tt.requestBody)
+			req, _ := http.NewRequest(http.MethodPost, "/api/calculate-packs/boxes", bytes.NewBuffer(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+
+			// Perform request
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Check response
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			// If success, check response body
+			if tt.expectedStatus == http.StatusOK {
+				var response BoxPackingResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, 1, response.TotalBoxes)
+				assert.Equal(t, "M", response.Boxes[0].Box.Name)
+				assert.Len(t, response.Boxes[0].Placements, 2)
+				assert.Equal(t, 10.0, response.Boxes[0].Placements[1].Position.X)
+			}
+		})
+	}
+}
+
+func TestPackCalculatorHandler_GetCalculationByID(t *testing.T) {
+	// Create test calculation result
+	testResult := entities.NewCalculationResult(10, map[int]int{5: 2})
+	testResult.ID = "calc-id"
+
+	tests := []struct {
+		name           string
+		mockResult     *entities.CalculationResult
+		mockErr        error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			mockResult:     testResult,
+			mockErr:        nil,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Not found",
+			mockResult:     nil,
+			mockErr:        &errors.NotFoundError{ID: "calc-id", Err: errors.ErrCalculationNotFound},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			router := setupRouter()
+			mockCalculationService := &mockCalculationService{
+				result: tt.mockResult,
+				err:    tt.mockErr,
+			}
+
+			handler := NewPackCalculatorHandler(&mockPackSizeService{}, mockCalculationService)
+			handler.RegisterRoutes(router)
+
+			// Perform request
+			req, _ := http.NewRequest(http.MethodGet, "/api/calculations/calc-id", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Check response
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			// If success, check response body
+			if tt.expectedStatus == http.StatusOK {
+				var response CalculationResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, "calc-id", response.ID)
+				assert.Equal(t, testResult.Packs, response.Packs)
+			}
+		})
+	}
+}
+
+func TestPackCalculatorHandler_CalculatePacksV2(t *testing.T) {
+	// Create test calculation result
+	testResult := entities.NewCalculationResult(1200, map[int]int{250: 1, 1000: 1, 500: 0})
+	testResult.ID = "calc-id"
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		requestBody    map[string]interface{}
+		mockResult     *entities.CalculationResult
+		mockErr        error
+		expectedStatus int
+	}{
+		{
+			name:           "Calculate",
+			method:         http.MethodPost,
+			path:           "/api/v2/calculate-packs",
+			requestBody:    map[string]interface{}{"items_ordered": 1200},
+			mockResult:     testResult,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Calculate with invalid request",
+			method:         http.MethodPost,
+			path:           "/api/v2/calculate-packs",
+			requestBody:    map[string]interface{}{"items_ordered": 0},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Get stored calculation",
+			method:         http.MethodGet,
+			path:           "/api/v2/calculations/calc-id",
+			mockResult:     testResult,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Stored calculation not found",
+			method:         http.MethodGet,
+			path:           "/api/v2/calculations/calc-id",
+			mockErr:        &errors.NotFoundError{ID: "calc-id", Err: errors.ErrCalculationNotFound},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			router := setupRouter()
+			mockCalculationService := &mockCalculationService{
+				result: tt.mockResult,
+				err:    tt.mockErr,
+			}
+
+			handler := NewPackCalculatorHandler(&mockPackSizeService{}, mockCalculationService)
+			handler.RegisterRoutes(router)
+
+			// Perform request
+			reqBody, _ := json.Marshal(tt.requestBody)
+			req, _ := http.NewRequest(tt.method, tt.path, bytes.NewBuffer(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Check response
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			// If success, check the lines are ordered largest first
+			if tt.expectedStatus == http.StatusOK {
+				var response CalculationResponseV2
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, "calc-id", response.ID)
+				assert.Equal(t, []PackLineResponse{{PackSize: 1000, Quantity: 1}, {PackSize: 250, Quantity: 1}}, response.Lines)
+				assert.Equal(t, 2, response.TotalPacks)
+				assert.Equal(t, 50, response.Overshoot)
+			}
+		})
+	}
+}
+
+func TestPackCalculatorHandler_GetCalculationTable(t *testing.T) {
+	// Create test calculation table
+	testTable := &entities.CalculationTable{
+		PackSizes: []int{500, 250},
+		Results: []*entities.CalculationResult{
+			entities.NewCalculationResult(250, map[int]int{250: 1}),
+			entities.NewCalculationResult(500, map[int]int{500: 1}),
+			entities.NewCalculationResult(750, map[int]int{500: 1, 250: 1}),
+		},
+	}
+
+	tests := []struct {
+		name               string
+		path               string
+		mockTable          *entities.CalculationTable
+		mockErr            error
+		expectedStatus     int
+		expectedRetryAfter string
+		expectedType       string
+		expectedBody       string
+	}{
+		{
+			name:           "JSON",
+			path:           "/api/calculate-packs/table?from=250&to=750&step=250",
+			mockTable:      testTable,
+			expectedStatus: http.StatusOK,
+			expectedType:   "application/json; charset=utf-8",
+		},
+		{
+			name:           "CSV",
+			path:           "/api/calculate-packs/table?from=250&to=750&step=250&format=csv",
+			mockTable:      testTable,
+			expectedStatus: http.StatusOK,
+			expectedType:   "text/csv; charset=utf-8",
+			expectedBody: "items_ordered,total_items,total_packs,overshoot,total_weight,pack_500,pack_250\n" +
+				"250,250,1,0,0,0,1\n" +
+				"500,500,1,0,0,1,0\n" +
+				"750,750,2,0,0,1,1\n",
+		},
+		{
+			name:           "Missing upper bound",
+			path:           "/api/calculate-packs/table?from=1",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Unsupported format",
+			path:           "/api/calculate-packs/table?to=10&format=xlsx",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid range",
+			path:           "/api/calculate-packs/table?from=10&to=1",
+			mockErr:        &errors.ValidationError{Field: "to", Err: errors.ErrInvalidRange},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Range too large",
+			path:           "/api/calculate-packs/table?to=100000000",
+			mockErr:        &errors.ValidationError{Field: "to", Err: errors.ErrCalculationTooLarge},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:               "Calculator busy",
+			path:               "/api/calculate-packs/table?to=1000",
+			mockErr:            &errors.RetryAfterError{RetryAfter: 5 * time.Second, Err: errors.ErrCalculatorBusy},
+			expectedStatus:     http.StatusServiceUnavailable,
+			expectedRetryAfter: "5",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			router := setupRouter()
+			mockCalculationService := &mockCalculationService{
+				table: tt.mockTable,
+				err:   tt.mockErr,
+			}
+
+			handler := NewPackCalculatorHandler(&mockPackSizeService{}, mockCalculationService)
+			handler.RegisterRoutes(router)
+
+			// Perform request
+			req, _ := http.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Check response
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			assert.Equal(t, tt.expectedRetryAfter, w.Header().Get("Retry-After"))
+			if tt.expectedStatus != http.StatusOK {
+				return
+			}
+
+			assert.Equal(t, tt.expectedType, w.Header().Get("Content-Type"))
+			if tt.expectedBody != "" {
+				assert.Equal(t, tt.expectedBody, w.Body.String())
+				return
+			}
+
+			var response CalculationTableResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			assert.Equal(t, []int{500, 250}, response.PackSizes)
+			assert.Len(t, response.Rows, 3)
+			assert.Equal(t, []PackLineResponse{{PackSize: 500, Quantity: 1}, {PackSize: 250, Quantity: 1}}, response.Rows[2].Lines)
+			assert.Equal(t, 2, response.Rows[2].TotalPacks)
+		})
+	}
+}
+
+func TestPackCalculatorHandler_VerifyPacking(t *testing.T) {
+	// Create test verifications
+	optimal := entities.NewCalculationResult(251, map[int]int{500: 1})
+	tooManyPacks := &entities.PackingVerification{
+		ItemsOrdered: 251,
+		Proposed:     entities.NewCalculationResult(251, map[int]int{250: 2}),
+		Optimal:      optimal,
+		Violations:   []entities.RuleViolation{{Rule: entities.RuleFewestPacks, Message: "uses 2 packs, 1 more than the optimal 1"}},
+	}
+	brokenPacks := &entities.PackingVerification{
+		ItemsOrdered: 251,
+		Optimal:      optimal,
+		Violations:   []entities.RuleViolation{{Rule: entities.RuleWholePacks, Message: "pack size 300 is not configured"}},
+	}
+
+	tests := []struct {
+		name             string
+		requestBody      string
+		mockVerification *entities.PackingVerification
+		mockErr          error
+		expectedStatus   int
+		expectedRule     int
+		expectProposed   bool
+	}{
+		{
+			name:             "Too many packs",
+			requestBody:      `{"items_ordered": 251, "packs": {"250": 2}}`,
+			mockVerification: tooManyPacks,
+			expectedStatus:   http.StatusOK,
+			expectedRule:     3,
+			expectProposed:   true,
+		},
+		{
+			name:             "Unconfigured pack size",
+			requestBody:      `{"items_ordered": 251, "packs": {"300": 1}}`,
+			mockVerification: brokenPacks,
+			expectedStatus:   http.StatusOK,
+			expectedRule:     1,
+		},
+		{
+			name:           "Missing packs",
+			requestBody:    `{"items_ordered": 251}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid pack size key",
+			requestBody:    `{"items_ordered": 251, "packs": {"large": 1}}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "No pack sizes",
+			requestBody:    `{"items_ordered": 251, "packs": {"250": 2}}`,
+			mockErr:        errors.ErrNoPackSizesAvailable,
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			router := setupRouter()
+			mockCalculationService := &mockCalculationService{
+				verification: tt.mockVerification,
+				err:          tt.mockErr,
+			}
+
+			handler := NewPackCalculatorHandler(&mockPackSizeService{}, mockCalculationService)
+			handler.RegisterRoutes(router)
+
+			// Perform request
+			req, _ := http.NewRequest(http.MethodPost, "/api/calculate-packs/verify", bytes.NewBufferString(tt.requestBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Check response
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			// If success, check the violation and the optimal packing
+			if tt.expectedStatus == http.StatusOK {
+				var response VerificationResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.False(t, response.Valid)
+				assert.Len(t, response.Violations, 1)
+				assert.Equal(t, tt.expectedRule, response.Violations[0].Rule)
+				assert.Equal(t, tt.expectProposed, response.Proposed != nil)
+				assert.Equal(t, []PackLineResponse{{PackSize: 500, Quantity: 1}}, response.Optimal.Lines)
+			}
+		})
+	}
+}
+
+func TestPackCalculatorHandler_GetPackingSlip(t *testing.T) {
+	// Create test document
+	testDocument := &entities.Document{
+		Filename:    "packing-slip-calc-id.txt",
+		ContentType: "text/plain; charset=utf-8",
+		Content:     []byte("PACKING SLIP"),
+	}
+
+	tests := []struct {
+		name           string
+		query          string
+		mockDocument   *entities.Document
+		mockErr        error
+		expectedStatus int
+	}{
+		{
+			name:           "Success",
+			query:          "?format=txt",
+			mockDocument:   testDocument,
+			mockErr:        nil,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "Unsupported format",
+			query:          "?format=docx",
+			mockDocument:   nil,
+			mockErr:        &errors.ValidationError{Field: "format", Err: errors.ErrUnsupportedFormat},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Calculation not found",
+			query:          "",
+			mockDocument:   nil,
+			mockErr:        &errors.NotFoundError{ID: "calc-id", Err: errors.ErrCalculationNotFound},
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			router := setupRouter()
+			mockCalculationService := &mockCalculationService{
+				document: tt.mockDocument,
+				err:      tt.mockErr,
+			}
+
+			handler := NewPackCalculatorHandler(&mockPackSizeService{}, mockCalculationService)
+			handler.RegisterRoutes(router)
+
+			// Perform request
+			req, _ := http.NewRequest(http.MethodGet, "/api/calculations/calc-id/packing-slip"+tt.query, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Check response
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			// If success, check response body
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, testDocument.ContentType, w.Header().Get("Content-Type"))
+				assert.Contains(t, w.Header().Get("Content-Disposition"), testDocument.Filename)
+				assert.Equal(t, "PACKING SLIP", w.Body.String())
 			}
 		})
 	}
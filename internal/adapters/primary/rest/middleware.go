@@ -0,0 +1,60 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/google/uuid"
+
+	"go-pack-calculator/internal/shared/types"
+)
+
+// Headers identifying who makes a request
+const (
+	ActorHeader     = "X-Actor"
+	RequestIDHeader = "X-Request-ID"
+)
+
+// anonymousActor is the actor of requests without an actor header
+const anonymousActor = "anonymous"
+
+// maxRequestInfoLength bounds the actor and request ID headers, the size of their audit columns
+const maxRequestInfoLength = 255
+
+// RequestInfo adds the actor and request ID of each request to its context, so the changes it
+// makes are attributed to them in the audit trail. The API does not authenticate clients, so the
+// actor is only what the X-Actor header claims and must not be trusted. A request without an ID
+// gets a generated one, the ID is echoed in the response. Longer headers than the audit trail
+// keeps are rejected.
+func RequestInfo() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		actor := strings.TrimSpace(c.GetHeader(ActorHeader))
+		if actor == "" {
+			actor = anonymousActor
+		}
+
+		requestID := strings.TrimSpace(c.GetHeader(RequestIDHeader))
+		if requestID == "" {
+			requestID = uuid.New().String()
+		}
+
+		for _, header := range [][2]string{{ActorHeader, actor}, {RequestIDHeader, requestID}} {
+			if len(header[1]) > maxRequestInfoLength {
+				c.AbortWithStatusJSON(http.StatusBadRequest, ErrorResponse{
+					Error: fmt.Sprintf("%s header must be at most %d bytes", header[0], maxRequestInfoLength),
+				})
+				return
+			}
+		}
+
+		c.Header(RequestIDHeader, requestID)
+		c.Request = c.Request.WithContext(types.WithRequestInfo(c.Request.Context(), types.RequestInfo{
+			ClaimedActor: actor,
+			RequestID:    requestID,
+		}))
+
+		c.Next()
+	}
+}
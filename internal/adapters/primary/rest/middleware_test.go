@@ -0,0 +1,110 @@
+package rest
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/assert"
+
+	"go-pack-calculator/internal/shared/types"
+)
+
+func TestRequestInfo(t *testing.T) {
+	tests := []struct {
+		name          string
+		actor         string
+		requestID     string
+		wantActor     string
+		wantRequestID string
+	}{
+		{
+			name:          "From headers",
+			actor:         "alice",
+			requestID:     "req-1",
+			wantActor:     "alice",
+			wantRequestID: "req-1",
+		},
+		{
+			name:      "Anonymous with generated ID",
+			wantActor: "anonymous",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			router := setupRouter()
+			router.Use(RequestInfo())
+
+			var info types.RequestInfo
+			router.GET("/test", func(c *gin.Context) {
+				info = types.RequestInfoFromContext(c.Request.Context())
+				c.Status(http.StatusNoContent)
+			})
+
+			// Perform request
+			req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+			if tt.actor != "" {
+				req.Header.Set(ActorHeader, tt.actor)
+			}
+			if tt.requestID != "" {
+				req.Header.Set(RequestIDHeader, tt.requestID)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Check request info
+			assert.Equal(t, tt.wantActor, info.ClaimedActor)
+			if tt.wantRequestID != "" {
+				assert.Equal(t, tt.wantRequestID, info.RequestID)
+			} else {
+				assert.NotEmpty(t, info.RequestID)
+			}
+			assert.Equal(t, info.RequestID, w.Header().Get(RequestIDHeader))
+		})
+	}
+}
+
+func TestRequestInfoHeaderTooLong(t *testing.T) {
+	tests := []struct {
+		name   string
+		header string
+	}{
+		{
+			name:   "Actor",
+			header: ActorHeader,
+		},
+		{
+			name:   "Request ID",
+			header: RequestIDHeader,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			router := setupRouter()
+			router.Use(RequestInfo())
+
+			called := false
+			router.GET("/test", func(c *gin.Context) {
+				called = true
+				c.Status(http.StatusNoContent)
+			})
+
+			// Perform request
+			req, _ := http.NewRequest(http.MethodGet, "/test", nil)
+			req.Header.Set(tt.header, strings.Repeat("a", maxRequestInfoLength+1))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Check response
+			assert.Equal(t, http.StatusBadRequest, w.Code)
+			assert.Contains(t, w.Body.String(), tt.header)
+			assert.False(t, called)
+		})
+	}
+}
@@ -8,27 +8,103 @@ import (
 
 // CreatePackSizeRequest represents a request to create a pack size
 type CreatePackSizeRequest struct {
-	Size int `json:"size" binding:"required,gt=0"`
+	Size           int               `json:"size" binding:"required,gt=0"`
+	Name           string            `json:"name" binding:"max=100"`
+	SKU            string            `json:"sku" binding:"max=64"`
+	Tags           []string          `json:"tags" binding:"max=20"`
+	Active         *bool             `json:"active"` // true when omitted
+	Weight         float64           `json:"weight" binding:"gte=0"`
+	MaterialWeight float64           `json:"material_weight" binding:"gte=0"`
+	EmissionFactor float64           `json:"emission_factor" binding:"gte=0"`
+	Dimensions     DimensionsRequest `json:"dimensions"`
+	ValidFrom      *time.Time        `json:"valid_from"` // Valid from the start when omitted
+	ValidTo        *time.Time        `json:"valid_to"`   // Valid indefinitely when omitted
 }
 
 // UpdatePackSizeRequest represents a request to update a pack size
 type UpdatePackSizeRequest struct {
-	Size int `json:"size" binding:"required,gt=0"`
+	Size           int               `json:"size" binding:"required,gt=0"`
+	Name           string            `json:"name" binding:"max=100"`
+	SKU            string            `json:"sku" binding:"max=64"`
+	Tags           []string          `json:"tags" binding:"max=20"`
+	Active         *bool             `json:"active"` // true when omitted
+	Weight         float64           `json:"weight" binding:"gte=0"`
+	MaterialWeight float64           `json:"material_weight" binding:"gte=0"`
+	EmissionFactor float64           `json:"emission_factor" binding:"gte=0"`
+	Dimensions     DimensionsRequest `json:"dimensions"`
+	ValidFrom      *time.Time        `json:"valid_from"` // Valid from the start when omitted
+	ValidTo        *time.Time        `json:"valid_to"`   // Valid indefinitely when omitted
+}
+
+// PackSizesRequest represents a request with several pack sizes
+type PackSizesRequest struct {
+	PackSizes []CreatePackSizeRequest `json:"pack_sizes" binding:"required,min=1,dive"`
+}
+
+// DeletePackSizesRequest represents a request to delete several pack sizes
+type DeletePackSizesRequest struct {
+	IDs []string `json:"ids" binding:"required,min=1"`
 }
 
 // CalculationRequest represents a request to calculate packs
 type CalculationRequest struct {
-	ItemsOrdered int `json:"items_ordered" binding:"required,gt=0"`
+	ItemsOrdered     int        `json:"items_ordered" binding:"required,gt=0"`
+	Objective        string     `json:"objective" binding:"omitempty,oneof=packs material" enums:"packs,material"`
+	OptimizeShipping bool       `json:"optimize_shipping"`
+	AsOf             *time.Time `json:"as_of"` // Use the pack sizes valid at this time, now when omitted
+}
+
+// DimensionsRequest represents dimensions in centimetres
+type DimensionsRequest struct {
+	Length float64 `json:"length" binding:"gte=0"`
+	Width  float64 `json:"width" binding:"gte=0"`
+	Height float64 `json:"height" binding:"gte=0"`
+}
+
+// BoxRequest represents a shipping box of the box catalog
+type BoxRequest struct {
+	Name       string            `json:"name" binding:"required"`
+	Dimensions DimensionsRequest `json:"dimensions"`
+	MaxWeight  float64           `json:"max_weight" binding:"required,gt=0"`
+}
+
+// BoxPackingRequest represents a request to calculate packs and assign them to shipping boxes
+type BoxPackingRequest struct {
+	ItemsOrdered int          `json:"items_ordered" binding:"required,gt=0"`
+	Boxes        []BoxRequest `json:"boxes" binding:"required,min=1,dive"`
+}
+
+// ConsolidationOrderRequest represents a single order in a consolidation request
+type ConsolidationOrderRequest struct {
+	OrderID      string `json:"order_id"`
+	ItemsOrdered int    `json:"items_ordered" binding:"required,gt=0"`
+}
+
+// ConsolidationRequest represents a request to consolidate several orders
+type ConsolidationRequest struct {
+	Orders []ConsolidationOrderRequest `json:"orders" binding:"required,min=1,dive"`
 }
 
 // Response models
 
 // PackSizeResponse represents a pack size response
 type PackSizeResponse struct {
-	ID        string    `json:"id"`
-	Size      int       `json:"size"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID             string             `json:"id"`
+	Version        int64              `json:"version"` // Also returned as the ETag header
+	Size           int                `json:"size"`
+	Name           string             `json:"name"`
+	SKU            string             `json:"sku"`
+	Tags           []string           `json:"tags"`
+	Active         bool               `json:"active"`
+	Weight         float64            `json:"weight"`
+	MaterialWeight float64            `json:"material_weight"`
+	EmissionFactor float64            `json:"emission_factor"`
+	Dimensions     DimensionsResponse `json:"dimensions"`
+	ValidFrom      *time.Time         `json:"valid_from,omitempty"`
+	ValidTo        *time.Time         `json:"valid_to,omitempty"`
+	CreatedAt      time.Time          `json:"created_at"`
+	UpdatedAt      time.Time          `json:"updated_at"`
+	DeletedAt      *time.Time         `json:"deleted_at,omitempty"` // Set for pack sizes in the trash
 }
 
 // PackSizesResponse represents a list of pack sizes
@@ -45,14 +121,309 @@ type PaginatedPackSizesResponse struct {
 	Items      []PackSizeResponse `json:"items"`
 }
 
+// PackSizeImportReportResponse represents the outcome of a pack size import, row by row
+type PackSizeImportReportResponse struct {
+	Strategy  string                      `json:"strategy"`
+	DryRun    bool                        `json:"dry_run"`
+	Applied   bool                        `json:"applied"` // Whether the pack sizes were changed
+	Valid     bool                        `json:"valid"`   // Whether every row is valid
+	Created   int                         `json:"created"`
+	Updated   int                         `json:"updated"`
+	Unchanged int                         `json:"unchanged"`
+	Invalid   int                         `json:"invalid"`
+	Rows      []PackSizeImportRowResponse `json:"rows"`
+	Deleted   []PackSizeResponse          `json:"deleted"` // Pack sizes deleted by the replace strategy
+}
+
+// PackSizeImportRowResponse represents the outcome of an imported row
+type PackSizeImportRowResponse struct {
+	Row      int               `json:"row"` // Position of the pack size in the file, from 1
+	Size     int               `json:"size"`
+	Action   string            `json:"action" enums:"create,update,unchanged,invalid"`
+	Errors   []string          `json:"errors"`
+	PackSize *PackSizeResponse `json:"pack_size,omitempty"` // The pack size after the import
+}
+
+// PackSizeChangesResponse represents the pack sizes created, updated and deleted by replacing the pack size set
+type PackSizeChangesResponse struct {
+	Created []PackSizeResponse `json:"created"`
+	Updated []PackSizeResponse `json:"updated"`
+	Deleted []PackSizeResponse `json:"deleted"`
+}
+
+// PackSizeAuditEntryResponse represents a recorded change of a pack size
+type PackSizeAuditEntryResponse struct {
+	ID           string            `json:"id"`
+	PackSizeID   string            `json:"pack_size_id"`
+	Action       string            `json:"action"`        // created, updated, deleted, restored or purged
+	ClaimedActor string            `json:"claimed_actor"` // Self-reported in the X-Actor header, not verified
+	RequestID    string            `json:"request_id"`
+	Before       *PackSizeResponse `json:"before,omitempty"` // Omitted for a creation
+	After        *PackSizeResponse `json:"after,omitempty"`  // Omitted for a deletion
+	Timestamp    time.Time         `json:"timestamp"`
+}
+
+// PackSizeHistoryResponse represents the recorded changes of a pack size, oldest first
+type PackSizeHistoryResponse struct {
+	Items []PackSizeAuditEntryResponse `json:"items"`
+}
+
+// PaginatedPackSizeAuditResponse represents a paginated list of the recorded changes of every pack size, newest first
+type PaginatedPackSizeAuditResponse struct {
+	Page       int64                        `json:"page"`
+	Limit      int64                        `json:"limit"`
+	Total      int64                        `json:"total"`
+	IsLastPage bool                         `json:"is_last_page"`
+	Items      []PackSizeAuditEntryResponse `json:"items"`
+}
+
 // CalculationResponse represents a calculation result
 type CalculationResponse struct {
-	ItemsOrdered int         `json:"items_ordered"`
-	TotalItems   int         `json:"total_items"`
-	Packs        map[int]int `json:"packs"`
+	ID                string                     `json:"id,omitempty"`
+	ItemsOrdered      int                        `json:"items_ordered"`
+	TotalItems        int                        `json:"total_items"`
+	Packs             map[int]int                `json:"packs"`
+	TotalWeight       float64                    `json:"total_weight"`
+	ShippingEstimates []ShippingEstimateResponse `json:"shipping_estimates,omitempty"`
+	Footprint         PackagingFootprintResponse `json:"footprint"`
+}
+
+// CalculationResponseV2 represents a calculation result with its packs as ordered lines
+type CalculationResponseV2 struct {
+	ID                string                     `json:"id,omitempty"`
+	ItemsOrdered      int                        `json:"items_ordered"`
+	TotalItems        int                        `json:"total_items"`
+	TotalPacks        int                        `json:"total_packs"`
+	Overshoot         int                        `json:"overshoot"` // Items sent beyond the items ordered
+	Lines             []PackLineResponse         `json:"lines"`     // Sorted by pack size, largest first
+	TotalWeight       float64                    `json:"total_weight"`
+	ShippingEstimates []ShippingEstimateResponse `json:"shipping_estimates,omitempty"`
+	Footprint         PackagingFootprintResponse `json:"footprint"`
+}
+
+// VerificationRequest represents a request to verify a proposed packing
+type VerificationRequest struct {
+	ItemsOrdered int             `json:"items_ordered" binding:"required,gt=0"`
+	Packs        map[int]float64 `json:"packs" binding:"required"` // Quantity by pack size, e.g. {"500": 1, "250": 1}
+}
+
+// VerificationResponse represents the check of a proposed packing against the business rules
+type VerificationResponse struct {
+	ItemsOrdered int                     `json:"items_ordered"`
+	Valid        bool                    `json:"valid"`
+	Violations   []RuleViolationResponse `json:"violations"`
+	Proposed     *PackingSummaryResponse `json:"proposed,omitempty"` // Omitted when the packing breaks rule 1
+	Optimal      PackingSummaryResponse  `json:"optimal"`
+}
+
+// RuleViolationResponse represents a business rule broken by a proposed packing
+type RuleViolationResponse struct {
+	Rule    int    `json:"rule"` // 1: whole packs of configured sizes, 2: fewest items, 3: fewest packs
+	Message string `json:"message"`
+}
+
+// ExportPackSizesRequest represents the query of a pack size export
+type ExportPackSizesRequest struct {
+	Format string `form:"format"` // csv, json or yaml, csv when omitted
+}
+
+// ImportPackSizesRequest represents the query of a pack size import
+type ImportPackSizesRequest struct {
+	Format   string `form:"format"`   // csv, json or yaml, from the Content-Type when omitted
+	Strategy string `form:"strategy"` // merge or replace, merge when omitted
+	DryRun   bool   `form:"dry_run"`  // Only report what the import would do
+}
+
+// CalculationTableRequest represents the query of a calculation table
+type CalculationTableRequest struct {
+	From   int    `form:"from"` // First quantity, 1 when omitted
+	To     int    `form:"to" binding:"required"`
+	Step   int    `form:"step"`   // Distance between quantities, 1 when omitted
+	Format string `form:"format"` // json or csv, json when omitted
+}
+
+// CalculationTableResponse represents the packings of a range of quantities
+type CalculationTableResponse struct {
+	PackSizes []int                 `json:"pack_sizes"` // Sorted largest first
+	Rows      []CalculationTableRow `json:"rows"`
+}
+
+// CalculationTableRow represents the packing of one quantity of a calculation table
+type CalculationTableRow struct {
+	ItemsOrdered int                `json:"items_ordered"`
+	TotalItems   int                `json:"total_items"`
+	TotalPacks   int                `json:"total_packs"`
+	Overshoot    int                `json:"overshoot"`
+	Lines        []PackLineResponse `json:"lines"` // Sorted by pack size, largest first
+	TotalWeight  float64            `json:"total_weight"`
+}
+
+// PackLineResponse represents the quantity of one pack size in a packing
+type PackLineResponse struct {
+	PackSize int `json:"pack_size"`
+	Quantity int `json:"quantity"`
+}
+
+// PackagingFootprintResponse represents the packaging material of a calculation and its emissions
+type PackagingFootprintResponse struct {
+	Material  float64 `json:"material"`  // Kilograms of packaging material
+	Emissions float64 `json:"emissions"` // Kilograms of CO2e
+}
+
+// ShippingEstimateResponse represents the estimated cost of shipping a packing with a carrier
+type ShippingEstimateResponse struct {
+	Carrier string  `json:"carrier"`
+	Price   float64 `json:"price"`
+}
+
+// ShippingRateResponse represents a carrier rate table row
+type ShippingRateResponse struct {
+	ID         string    `json:"id"`
+	Carrier    string    `json:"carrier"`
+	MaxWeight  float64   `json:"max_weight"`
+	MaxParcels int       `json:"max_parcels"`
+	Price      float64   `json:"price"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ShippingRatesResponse represents a carrier rate table
+type ShippingRatesResponse struct {
+	Items []ShippingRateResponse `json:"items"`
+}
+
+// OrderCalculationResponse represents the separate calculation result of one order
+type OrderCalculationResponse struct {
+	OrderID string `json:"order_id"`
+	CalculationResponse
+}
+
+// OrderAllocationResponse represents the packs of a consolidated packing allocated to one order
+type OrderAllocationResponse struct {
+	OrderID        string      `json:"order_id"`
+	ItemsOrdered   int         `json:"items_ordered"`
+	AllocatedItems int         `json:"allocated_items"`
+	Packs          map[int]int `json:"packs"`
+}
+
+// ConsolidationSavingsResponse represents what is saved by packing orders together
+type ConsolidationSavingsResponse struct {
+	Items int `json:"items"`
+	Packs int `json:"packs"`
+}
+
+// ConsolidationResponse represents a consolidation result
+type ConsolidationResponse struct {
+	Combined    CalculationResponse          `json:"combined"`
+	Separate    []OrderCalculationResponse   `json:"separate"`
+	Allocations []OrderAllocationResponse    `json:"allocations"`
+	Savings     ConsolidationSavingsResponse `json:"savings"`
+}
+
+// DimensionsResponse represents dimensions in centimetres
+type DimensionsResponse struct {
+	Length float64 `json:"length"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// PositionResponse represents the position of a pack inside a box
+type PositionResponse struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	Z float64 `json:"z"`
+}
+
+// BoxResponse represents a shipping box of the box catalog
+type BoxResponse struct {
+	Name       string             `json:"name"`
+	Dimensions DimensionsResponse `json:"dimensions"`
+	MaxWeight  float64            `json:"max_weight"`
+}
+
+// PackPlacementResponse represents a pack placed in a box, with its dimensions as rotated
+type PackPlacementResponse struct {
+	PackSize   int                `json:"pack_size"`
+	Position   PositionResponse   `json:"position"`
+	Dimensions DimensionsResponse `json:"dimensions"`
+}
+
+// BoxAssignmentResponse represents a box and the packs placed in it
+type BoxAssignmentResponse struct {
+	Box         BoxResponse             `json:"box"`
+	Packs       map[int]int             `json:"packs"`
+	Placements  []PackPlacementResponse `json:"placements"`
+	TotalWeight float64                 `json:"total_weight"`
+}
+
+// BoxPackingResponse represents the packs of a calculation assigned to shipping boxes
+type BoxPackingResponse struct {
+	Calculation CalculationResponse     `json:"calculation"`
+	TotalBoxes  int                     `json:"total_boxes"`
+	Boxes       []BoxAssignmentResponse `json:"boxes"`
 }
 
 // ErrorResponse represents an error response
 type ErrorResponse struct {
 	Error string `json:"error"`
 }
+
+// CacheStatsResponse represents the usage of the calculation cache
+type CacheStatsResponse struct {
+	Hits      int64   `json:"hits"`
+	Misses    int64   `json:"misses"`
+	HitRatio  float64 `json:"hit_ratio"`
+	Evictions int64   `json:"evictions"`
+	Entries   int     `json:"entries"`
+	Capacity  int     `json:"capacity"`
+	Version   uint64  `json:"version"` // Version of the pack size set, increased on every change
+}
+
+// PackSetComparisonRequest represents a request to compare two pack size sets
+type PackSetComparisonRequest struct {
+	CurrentSizes   []int                   `json:"current_sizes"` // Stored pack sizes when omitted
+	CandidateSizes []int                   `json:"candidate_sizes" binding:"required,min=1"`
+	Quantities     []QuantityDemandRequest `json:"quantities" binding:"required,min=1,dive"`
+}
+
+// QuantityDemandRequest represents one quantity of a demand distribution
+type QuantityDemandRequest struct {
+	ItemsOrdered int `json:"items_ordered" binding:"required,gt=0"`
+	Frequency    int `json:"frequency" binding:"gte=0"` // 1 when omitted
+}
+
+// PackSetComparisonResponse represents the comparison of two pack size sets
+type PackSetComparisonResponse struct {
+	Current    PackSetMetricsResponse       `json:"current"`
+	Candidate  PackSetMetricsResponse       `json:"candidate"`
+	Quantities []QuantityComparisonResponse `json:"quantities"`
+}
+
+// PackSetMetricsResponse represents the aggregate metrics of a pack size set, averages weighted by frequency
+type PackSetMetricsResponse struct {
+	PackSizes           []int   `json:"pack_sizes"` // Sorted largest first
+	AverageOvershoot    float64 `json:"average_overshoot"`
+	AveragePacks        float64 `json:"average_packs"`
+	OvershootRatio      float64 `json:"overshoot_ratio"` // Items shipped beyond the items ordered per item ordered
+	WorstOvershoot      int     `json:"worst_overshoot"`
+	WorstOvershootItems int     `json:"worst_overshoot_items_ordered"`
+	MostPacks           int     `json:"most_packs"`
+	MostPacksItems      int     `json:"most_packs_items_ordered"`
+}
+
+// QuantityComparisonResponse represents the packings of one quantity with both pack size sets
+type QuantityComparisonResponse struct {
+	ItemsOrdered        int                    `json:"items_ordered"`
+	Frequency           int                    `json:"frequency"`
+	Current             PackingSummaryResponse `json:"current"`
+	Candidate           PackingSummaryResponse `json:"candidate"`
+	OvershootDifference int                    `json:"overshoot_difference"` // Candidate minus current
+	PacksDifference     int                    `json:"packs_difference"`     // Candidate minus current
+}
+
+// PackingSummaryResponse represents the packing of one quantity
+type PackingSummaryResponse struct {
+	TotalItems int                `json:"total_items"`
+	TotalPacks int                `json:"total_packs"`
+	Overshoot  int                `json:"overshoot"`
+	Lines      []PackLineResponse `json:"lines"` // Sorted by pack size, largest first
+}
@@ -0,0 +1,123 @@
+package rest
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/ports/primary"
+)
+
+// PackSetComparisonHandler handles HTTP requests for comparing pack size sets
+type PackSetComparisonHandler struct {
+	comparisonService primary.PackSetComparisonService
+}
+
+// NewPackSetComparisonHandler creates a new pack set comparison handler
+func NewPackSetComparisonHandler(comparisonService primary.PackSetComparisonService) *PackSetComparisonHandler {
+	return &PackSetComparisonHandler{
+		comparisonService: comparisonService,
+	}
+}
+
+// RegisterRoutes registers the REST API routes
+func (h *PackSetComparisonHandler) RegisterRoutes(r *gin.Engine) {
+	r.POST("/api/pack-sizes/compare", h.ComparePackSets)
+}
+
+// ComparePackSets godoc
+// @Summary Compare two pack size sets
+// @Description Pack every quantity of a distribution with the current and a candidate pack size set and return the average overshoot and pack count, the worst cases and the per-quantity differences of both sets. The stored pack sizes are the current set unless current_sizes is given. The results are not stored.
+// @Tags pack-sizes
+// @Accept json
+// @Produce json
+// @Param comparison body PackSetComparisonRequest true "Pack Set Comparison Request"
+// @Success 200 {object} PackSetComparisonResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /pack-sizes/compare [post]
+func (h *PackSetComparisonHandler) ComparePackSets(c *gin.Context) {
+	var req PackSetComparisonRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request: " + err.Error()})
+
+		return
+	}
+
+	demand := make([]entities.QuantityDemand, len(req.Quantities))
+	for i, quantity := range req.Quantities {
+		demand[i] = entities.QuantityDemand{
+			ItemsOrdered: quantity.ItemsOrdered,
+			Frequency:    quantity.Frequency,
+		}
+		// An omitted frequency counts the quantity once
+		if demand[i].Frequency == 0 {
+			demand[i].Frequency = 1
+		}
+	}
+
+	comparison, err := h.comparisonService.ComparePackSets(c.Request.Context(), req.CurrentSizes, req.CandidateSizes, demand)
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, toPackSetComparisonResponse(comparison))
+}
+
+// Helper function to convert pack set comparison to response
+func toPackSetComparisonResponse(comparison *entities.PackSetComparison) PackSetComparisonResponse {
+	response := PackSetComparisonResponse{
+		Current:    toPackSetMetricsResponse(comparison.Current),
+		Candidate:  toPackSetMetricsResponse(comparison.Candidate),
+		Quantities: make([]QuantityComparisonResponse, len(comparison.Quantities)),
+	}
+
+	for i, quantity := range comparison.Quantities {
+		response.Quantities[i] = QuantityComparisonResponse{
+			ItemsOrdered:        quantity.ItemsOrdered,
+			Frequency:           quantity.Frequency,
+			Current:             toPackingSummaryResponse(quantity.Current),
+			Candidate:           toPackingSummaryResponse(quantity.Candidate),
+			OvershootDifference: quantity.OvershootDifference(),
+			PacksDifference:     quantity.PacksDifference(),
+		}
+	}
+
+	return response
+}
+
+// Helper function to convert pack set metrics to response
+func toPackSetMetricsResponse(metrics entities.PackSetMetrics) PackSetMetricsResponse {
+	return PackSetMetricsResponse{
+		PackSizes:           metrics.PackSizes,
+		AverageOvershoot:    metrics.AverageOvershoot,
+		AveragePacks:        metrics.AveragePacks,
+		OvershootRatio:      metrics.OvershootRatio,
+		WorstOvershoot:      metrics.WorstOvershoot,
+		WorstOvershootItems: metrics.WorstOvershootItems,
+		MostPacks:           metrics.MostPacks,
+		MostPacksItems:      metrics.MostPacksItems,
+	}
+}
+
+// Helper function to convert the packing of one quantity to response
+func toPackingSummaryResponse(result *entities.CalculationResult) PackingSummaryResponse {
+	response := PackingSummaryResponse{
+		TotalItems: result.TotalItems,
+		TotalPacks: result.TotalPacks(),
+		Overshoot:  result.Overshoot(),
+		Lines:      make([]PackLineResponse, 0, len(result.Packs)),
+	}
+
+	for _, line := range result.Lines() {
+		response.Lines = append(response.Lines, PackLineResponse{
+			PackSize: line.PackSize,
+			Quantity: line.Quantity,
+		})
+	}
+
+	return response
+}
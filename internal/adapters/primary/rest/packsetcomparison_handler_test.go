@@ -0,0 +1,132 @@
+package rest
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+)
+
+type mockPackSetComparisonService struct {
+	comparison *entities.PackSetComparison
+	err        error
+	demand     []entities.QuantityDemand
+}
+
+func (m *mockPackSetComparisonService) ComparePackSets(
+	ctx context.Context,
+	current, candidate []int,
+	demand []entities.QuantityDemand,
+) (*entities.PackSetComparison, error) {
+	m.demand = demand
+	return m.comparison, m.err
+}
+
+func TestPackSetComparisonHandler_ComparePackSets(t *testing.T) {
+	// Create test comparison
+	current := entities.NewCalculationResult(251, map[int]int{500: 1})
+	candidate := entities.NewCalculationResult(251, map[int]int{300: 1})
+	demand := []entities.QuantityDemand{{ItemsOrdered: 251, Frequency: 1}}
+	testComparison := &entities.PackSetComparison{
+		Current:   entities.NewPackSetMetrics([]int{500, 250}, demand, []*entities.CalculationResult{current}),
+		Candidate: entities.NewPackSetMetrics([]int{300}, demand, []*entities.CalculationResult{candidate}),
+		Quantities: []entities.QuantityComparison{
+			{ItemsOrdered: 251, Frequency: 1, Current: current, Candidate: candidate},
+		},
+	}
+
+	tests := []struct {
+		name           string
+		requestBody    map[string]interface{}
+		mockComparison *entities.PackSetComparison
+		mockErr        error
+		expectedStatus int
+	}{
+		{
+			name: "Compare",
+			requestBody: map[string]interface{}{
+				"candidate_sizes": []int{300},
+				"quantities":      []map[string]int{{"items_ordered": 251}},
+			},
+			mockComparison: testComparison,
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "Missing candidate sizes",
+			requestBody: map[string]interface{}{
+				"quantities": []map[string]int{{"items_ordered": 251}},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid quantity",
+			requestBody: map[string]interface{}{
+				"candidate_sizes": []int{300},
+				"quantities":      []map[string]int{{"items_ordered": 0}},
+			},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Invalid candidate set",
+			requestBody: map[string]interface{}{
+				"candidate_sizes": []int{-1},
+				"quantities":      []map[string]int{{"items_ordered": 251}},
+			},
+			mockErr:        &errors.ValidationError{Field: "candidate_sizes", Err: errors.ErrInvalidPackSize},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "Internal error",
+			requestBody: map[string]interface{}{
+				"candidate_sizes": []int{300},
+				"quantities":      []map[string]int{{"items_ordered": 251}},
+			},
+			mockErr:        errors.ErrDatabaseOperation,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup, with the pack size routes registered alongside
+			router := setupRouter()
+			NewPackCalculatorHandler(&mockPackSizeService{}, &mockCalculationService{}).RegisterRoutes(router)
+			service := &mockPackSetComparisonService{comparison: tt.mockComparison, err: tt.mockErr}
+			handler := NewPackSetComparisonHandler(service)
+			handler.RegisterRoutes(router)
+
+			// Perform request
+			reqBody, _ := json.Marshal(tt.requestBody)
+			req, _ := http.NewRequest(http.MethodPost, "/api/pack-sizes/compare", bytes.NewBuffer(reqBody))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Check response
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			// If success, check the omitted frequency counts once and the differences
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, demand, service.demand)
+
+				var response PackSetComparisonResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, []int{500, 250}, response.Current.PackSizes)
+				assert.Equal(t, 249, response.Current.WorstOvershoot)
+				assert.Equal(t, 49.0, response.Candidate.AverageOvershoot)
+				assert.Len(t, response.Quantities, 1)
+				assert.Equal(t, -200, response.Quantities[0].OvershootDifference)
+				assert.Equal(t, 0, response.Quantities[0].PacksDifference)
+				assert.Equal(t, []PackLineResponse{{PackSize: 300, Quantity: 1}}, response.Quantities[0].Candidate.Lines)
+			}
+		})
+	}
+}
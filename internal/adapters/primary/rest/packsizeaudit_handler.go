@@ -0,0 +1,128 @@
+package rest
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/ports/primary"
+)
+
+// PackSizeAuditHandler handles HTTP requests for the audit trail of pack sizes
+type PackSizeAuditHandler struct {
+	auditService primary.PackSizeAuditService
+}
+
+// NewPackSizeAuditHandler creates a new pack size audit handler
+func NewPackSizeAuditHandler(auditService primary.PackSizeAuditService) *PackSizeAuditHandler {
+	return &PackSizeAuditHandler{
+		auditService: auditService,
+	}
+}
+
+// RegisterRoutes registers the REST API routes
+func (h *PackSizeAuditHandler) RegisterRoutes(r *gin.Engine) {
+	packSizes := r.Group("/api/pack-sizes")
+
+	packSizes.GET("/audit", h.GetAuditFeed)
+	packSizes.GET("/:id/history", h.GetPackSizeHistory)
+}
+
+// GetPackSizeHistory godoc
+// @Summary Get the history of a pack size
+// @Description Get the recorded creation, updates, deletion, restores and purge of a pack size, oldest first. The history of purged pack sizes is kept. Changes are attributed to the X-Actor header as claimed_actor, which clients set freely: it is not authenticated and must not be trusted.
+// @Tags pack-sizes
+// @Produce json
+// @Param id path string true "Pack Size ID"
+// @Success 200 {object} PackSizeHistoryResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /pack-sizes/{id}/history [get]
+func (h *PackSizeAuditHandler) GetPackSizeHistory(c *gin.Context) {
+	entries, err := h.auditService.GetPackSizeHistory(c.Request.Context(), c.Param("id"))
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	response := PackSizeHistoryResponse{
+		Items: make([]PackSizeAuditEntryResponse, len(entries)),
+	}
+	for i, entry := range entries {
+		response.Items[i] = toPackSizeAuditEntryResponse(entry)
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// GetAuditFeed godoc
+// @Summary Get the audit feed of pack sizes
+// @Description Get the recorded changes of every pack size, newest first. Changes are attributed to the X-Actor header as claimed_actor, which clients set freely: it is not authenticated and must not be trusted.
+// @Tags pack-sizes
+// @Produce json
+// @Param page query int false "Page number (default: 1)"
+// @Param limit query int false "Items per page (default: 10)"
+// @Success 200 {object} PaginatedPackSizeAuditResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /pack-sizes/audit [get]
+func (h *PackSizeAuditHandler) GetAuditFeed(c *gin.Context) {
+	// Parse pagination parameters
+	page, err := strconv.ParseInt(c.DefaultQuery("page", "1"), 10, 64)
+	if err != nil || page < 1 {
+		page = 1
+	}
+
+	limit, err := strconv.ParseInt(c.DefaultQuery("limit", "10"), 10, 64)
+	if err != nil || limit < 1 {
+		limit = 10
+	}
+
+	pagination, err := h.auditService.GetAuditFeed(c.Request.Context(), page, limit)
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	// Convert to response format
+	response := PaginatedPackSizeAuditResponse{
+		Page:       pagination.Page,
+		Limit:      pagination.Limit,
+		Total:      pagination.Total,
+		IsLastPage: pagination.IsLastPage,
+		Items:      make([]PackSizeAuditEntryResponse, len(pagination.Items)),
+	}
+
+	for i, item := range pagination.Items {
+		if entry, ok := item.(*entities.PackSizeAuditEntry); ok {
+			response.Items[i] = toPackSizeAuditEntryResponse(entry)
+		}
+	}
+
+	c.JSON(http.StatusOK, response)
+}
+
+// Helper function to convert an audit entry to response
+func toPackSizeAuditEntryResponse(entry *entities.PackSizeAuditEntry) PackSizeAuditEntryResponse {
+	response := PackSizeAuditEntryResponse{
+		ID:           entry.ID,
+		PackSizeID:   entry.PackSizeID,
+		Action:       string(entry.Action),
+		ClaimedActor: entry.ClaimedActor,
+		RequestID:    entry.RequestID,
+		Timestamp:    entry.Timestamp,
+	}
+	if entry.Before != nil {
+		before := toPackSizeResponse(entry.Before)
+		response.Before = &before
+	}
+	if entry.After != nil {
+		after := toPackSizeResponse(entry.After)
+		response.After = &after
+	}
+
+	return response
+}
@@ -0,0 +1,131 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/shared/types"
+)
+
+type mockPackSizeAuditService struct {
+	entries    []*entities.PackSizeAuditEntry
+	pagination *types.Pagination
+	err        error
+	page       int64
+	limit      int64
+}
+
+func (m *mockPackSizeAuditService) GetPackSizeHistory(ctx context.Context, id string) ([]*entities.PackSizeAuditEntry, error) {
+	return m.entries, m.err
+}
+
+func (m *mockPackSizeAuditService) GetAuditFeed(ctx context.Context, page, limit int64) (*types.Pagination, error) {
+	m.page = page
+	m.limit = limit
+	return m.pagination, m.err
+}
+
+func TestPackSizeAuditHandler_GetPackSizeHistory(t *testing.T) {
+	before, _ := entities.NewPackSize(100)
+	before.ID = "test-id"
+	after, _ := entities.NewPackSize(200)
+	after.ID = "test-id"
+	updated := entities.NewPackSizeAuditEntry(entities.AuditActionUpdated, before, after, "alice", "req-1")
+	updated.ID = "entry-id"
+
+	tests := []struct {
+		name       string
+		entries    []*entities.PackSizeAuditEntry
+		err        error
+		wantStatus int
+	}{
+		{
+			name:       "Success",
+			entries:    []*entities.PackSizeAuditEntry{updated},
+			wantStatus: http.StatusOK,
+		},
+		{
+			name:       "Not found",
+			err:        &errors.NotFoundError{ID: "test-id", Err: errors.ErrPackSizeNotFound},
+			wantStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			router := setupRouter()
+			handler := NewPackSizeAuditHandler(&mockPackSizeAuditService{entries: tt.entries, err: tt.err})
+			handler.RegisterRoutes(router)
+
+			// Perform request
+			req, _ := http.NewRequest(http.MethodGet, "/api/pack-sizes/test-id/history", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Check response
+			assert.Equal(t, tt.wantStatus, w.Code)
+			if tt.wantStatus != http.StatusOK {
+				return
+			}
+
+			var response PackSizeHistoryResponse
+			err := json.Unmarshal(w.Body.Bytes(), &response)
+			assert.NoError(t, err)
+			if assert.Len(t, response.Items, 1) {
+				item := response.Items[0]
+				assert.Equal(t, "entry-id", item.ID)
+				assert.Equal(t, "test-id", item.PackSizeID)
+				assert.Equal(t, "updated", item.Action)
+				assert.Equal(t, "alice", item.ClaimedActor)
+				assert.Equal(t, "req-1", item.RequestID)
+				if assert.NotNil(t, item.Before) && assert.NotNil(t, item.After) {
+					assert.Equal(t, 100, item.Before.Size)
+					assert.Equal(t, 200, item.After.Size)
+				}
+			}
+		})
+	}
+}
+
+func TestPackSizeAuditHandler_GetAuditFeed(t *testing.T) {
+	packSize, _ := entities.NewPackSize(100)
+	packSize.ID = "test-id"
+	deleted := entities.NewPackSizeAuditEntry(entities.AuditActionDeleted, packSize, nil, "bob", "req-2")
+
+	// Setup
+	router := setupRouter()
+	service := &mockPackSizeAuditService{
+		pagination: types.NewPagination(2, 5, 6, true, []interface{}{deleted}),
+	}
+	handler := NewPackSizeAuditHandler(service)
+	handler.RegisterRoutes(router)
+
+	// Perform request
+	req, _ := http.NewRequest(http.MethodGet, "/api/pack-sizes/audit?page=2&limit=5", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+
+	// Check response
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, int64(2), service.page)
+	assert.Equal(t, int64(5), service.limit)
+
+	var response PaginatedPackSizeAuditResponse
+	err := json.Unmarshal(w.Body.Bytes(), &response)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), response.Total)
+	assert.True(t, response.IsLastPage)
+	if assert.Len(t, response.Items, 1) {
+		assert.Equal(t, "deleted", response.Items[0].Action)
+		assert.NotNil(t, response.Items[0].Before)
+		assert.Nil(t, response.Items[0].After)
+	}
+}
@@ -0,0 +1,615 @@
+package rest
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	stderr "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"gopkg.in/yaml.v3"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/ports/primary"
+)
+
+// Pack size file formats
+const (
+	packSizeFormatCSV  = "csv"
+	packSizeFormatJSON = "json"
+	packSizeFormatYAML = "yaml"
+)
+
+// Limits of a pack size import, so a single request cannot exhaust the memory of the server
+const (
+	maxImportBytes = 1 << 20 // Size of the file
+	maxImportRows  = 1000    // Pack sizes in the file
+)
+
+// packSizeFormatContentTypes maps the pack size file formats to the content type of the files
+var packSizeFormatContentTypes = map[string]string{
+	packSizeFormatCSV:  "text/csv; charset=utf-8",
+	packSizeFormatJSON: "application/json; charset=utf-8",
+	packSizeFormatYAML: "application/yaml; charset=utf-8",
+}
+
+// packSizeCSVColumns lists the columns of a pack size CSV file, of which only size is required.
+// Tags are separated by semicolons and dimensions are in centimetres.
+var packSizeCSVColumns = []string{
+	"size", "name", "sku", "tags", "active", "weight", "material_weight", "emission_factor",
+	"length", "width", "height", "valid_from", "valid_to",
+}
+
+// PackSizeTransferHandler handles HTTP requests for exporting and importing pack sizes
+type PackSizeTransferHandler struct {
+	transferService primary.PackSizeTransferService
+}
+
+// NewPackSizeTransferHandler creates a new pack size transfer handler
+func NewPackSizeTransferHandler(transferService primary.PackSizeTransferService) *PackSizeTransferHandler {
+	return &PackSizeTransferHandler{
+		transferService: transferService,
+	}
+}
+
+// RegisterRoutes registers the REST API routes
+func (h *PackSizeTransferHandler) RegisterRoutes(r *gin.Engine) {
+	r.GET("/api/pack-sizes/export", h.ExportPackSizes)
+	r.POST("/api/pack-sizes/import", h.ImportPackSizes)
+}
+
+// ExportPackSizes godoc
+// @Summary Export the pack sizes
+// @Description Download the pack sizes, smallest first, as a CSV, JSON or YAML file that can be imported again, e.g. into another environment. IDs, versions and timestamps are left out.
+// @Tags pack-sizes
+// @Produce text/csv
+// @Produce json
+// @Produce application/yaml
+// @Param format query string false "File format: csv, json or yaml (default: csv)"
+// @Success 200 {file} file
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /pack-sizes/export [get]
+func (h *PackSizeTransferHandler) ExportPackSizes(c *gin.Context) {
+	req := ExportPackSizesRequest{Format: packSizeFormatCSV}
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request: " + err.Error()})
+
+		return
+	}
+	contentType, ok := packSizeFormatContentTypes[req.Format]
+	if !ok {
+		handleError(c, errors.ErrUnsupportedFormat)
+
+		return
+	}
+
+	packSizes, err := h.transferService.ExportPackSizes(c.Request.Context())
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	content, err := encodePackSizes(packSizes, req.Format)
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.Header("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "pack-sizes."+req.Format))
+	c.Data(http.StatusOK, contentType, content)
+}
+
+// ImportPackSizes godoc
+// @Summary Import pack sizes
+// @Description Import the pack sizes of a CSV, JSON or YAML file as exported. The merge strategy creates the missing sizes and updates the others, the replace strategy also deletes the pack sizes of sizes that are not in the file. The import is applied entirely or not at all: when a row is invalid nothing is changed and the report lists the errors of every row. A dry run only reports what the import would do. Files are limited to 1 MB and 1000 pack sizes.
+// @Tags pack-sizes
+// @Accept text/csv
+// @Accept json
+// @Accept application/yaml
+// @Produce json
+// @Param format query string false "File format: csv, json or yaml (default: from the Content-Type)"
+// @Param strategy query string false "Import strategy: merge or replace (default: merge)"
+// @Param dry_run query bool false "Only report what the import would do"
+// @Param file body string true "Pack size file"
+// @Success 200 {object} PackSizeImportReportResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse
+// @Failure 413 {object} ErrorResponse
+// @Failure 422 {object} PackSizeImportReportResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /pack-sizes/import [post]
+func (h *PackSizeTransferHandler) ImportPackSizes(c *gin.Context) {
+	var req ImportPackSizesRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid request: " + err.Error()})
+
+		return
+	}
+	if req.Format == "" {
+		req.Format = packSizeFormatOf(c.ContentType())
+	}
+
+	// Read the whole file up to the size limit before decoding it
+	content, err := io.ReadAll(http.MaxBytesReader(c.Writer, c.Request.Body, maxImportBytes))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if stderr.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, ErrorResponse{
+				Error: fmt.Sprintf("File too large: at most %d bytes per import", maxImportBytes),
+			})
+
+			return
+		}
+
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid file: " + err.Error()})
+
+		return
+	}
+
+	rows, err := decodePackSizes(bytes.NewReader(content), req.Format)
+	if err != nil {
+		var fileErr *packSizeFileError
+		if stderr.As(err, &fileErr) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid file: " + err.Error()})
+
+			return
+		}
+
+		handleError(c, err)
+
+		return
+	}
+
+	report, err := h.transferService.ImportPackSizes(c.Request.Context(), rows, entities.ImportStrategy(req.Strategy), req.DryRun)
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	status := http.StatusOK
+	if !report.Valid() {
+		status = http.StatusUnprocessableEntity
+	}
+	c.JSON(status, toPackSizeImportReportResponse(report))
+}
+
+// packSizeFormatOf returns the pack size file format of a content type, empty when unknown
+func packSizeFormatOf(contentType string) string {
+	switch contentType {
+	case "text/csv":
+		return packSizeFormatCSV
+	case "application/json":
+		return packSizeFormatJSON
+	case "application/yaml", "application/x-yaml", "text/yaml":
+		return packSizeFormatYAML
+	default:
+		return ""
+	}
+}
+
+// packSizeFileError represents a malformed pack size file
+type packSizeFileError struct {
+	msg string
+}
+
+// Error returns the error message
+func (e *packSizeFileError) Error() string {
+	return e.msg
+}
+
+// packSizeRecord represents a pack size in a JSON or YAML file
+type packSizeRecord struct {
+	Size           int                       `json:"size" yaml:"size"`
+	Name           string                    `json:"name,omitempty" yaml:"name,omitempty"`
+	SKU            string                    `json:"sku,omitempty" yaml:"sku,omitempty"`
+	Tags           []string                  `json:"tags,omitempty" yaml:"tags,omitempty"`
+	Active         *bool                     `json:"active,omitempty" yaml:"active,omitempty"` // true when omitted
+	Weight         float64                   `json:"weight,omitempty" yaml:"weight,omitempty"`
+	MaterialWeight float64                   `json:"material_weight,omitempty" yaml:"material_weight,omitempty"`
+	EmissionFactor float64                   `json:"emission_factor,omitempty" yaml:"emission_factor,omitempty"`
+	Dimensions     *packSizeDimensionsRecord `json:"dimensions,omitempty" yaml:"dimensions,omitempty"`
+	ValidFrom      *time.Time                `json:"valid_from,omitempty" yaml:"valid_from,omitempty"`
+	ValidTo        *time.Time                `json:"valid_to,omitempty" yaml:"valid_to,omitempty"`
+}
+
+// packSizeDimensionsRecord represents the dimensions of a pack size in a JSON or YAML file
+type packSizeDimensionsRecord struct {
+	Length float64 `json:"length" yaml:"length"`
+	Width  float64 `json:"width" yaml:"width"`
+	Height float64 `json:"height" yaml:"height"`
+}
+
+// packSizeRecordKeys lists the keys of a pack size record, to reject unknown keys in YAML files
+var packSizeRecordKeys = map[string]bool{
+	"size": true, "name": true, "sku": true, "tags": true, "active": true, "weight": true,
+	"material_weight": true, "emission_factor": true, "dimensions": true, "valid_from": true, "valid_to": true,
+}
+
+// encodePackSizes writes pack sizes in a file format
+func encodePackSizes(packSizes []*entities.PackSize, format string) ([]byte, error) {
+	if format == packSizeFormatCSV {
+		return encodePackSizesCSV(packSizes)
+	}
+
+	records := make([]packSizeRecord, len(packSizes))
+	for i, ps := range packSizes {
+		records[i] = toPackSizeRecord(ps)
+	}
+
+	if format == packSizeFormatYAML {
+		return yaml.Marshal(records)
+	}
+
+	return json.MarshalIndent(records, "", "  ")
+}
+
+// encodePackSizesCSV writes pack sizes as CSV with a header row
+func encodePackSizesCSV(packSizes []*entities.PackSize) ([]byte, error) {
+	var buf bytes.Buffer
+	writer := csv.NewWriter(&buf)
+	if err := writer.Write(packSizeCSVColumns); err != nil {
+		return nil, err
+	}
+
+	formatFloat := func(f float64) string {
+		if f == 0 {
+			return ""
+		}
+		return strconv.FormatFloat(f, 'f', -1, 64)
+	}
+	formatTime := func(t *time.Time) string {
+		if t == nil {
+			return ""
+		}
+		return t.Format(time.RFC3339Nano)
+	}
+
+	for _, ps := range packSizes {
+		record := []string{
+			strconv.Itoa(ps.Size),
+			ps.Name,
+			ps.SKU,
+			strings.Join(ps.Tags, ";"),
+			strconv.FormatBool(ps.Active),
+			formatFloat(ps.Weight),
+			formatFloat(ps.MaterialWeight),
+			formatFloat(ps.EmissionFactor),
+			formatFloat(ps.Dimensions.Length),
+			formatFloat(ps.Dimensions.Width),
+			formatFloat(ps.Dimensions.Height),
+			formatTime(ps.ValidFrom),
+			formatTime(ps.ValidTo),
+		}
+		if err := writer.Write(record); err != nil {
+			return nil, err
+		}
+	}
+	writer.Flush()
+
+	return buf.Bytes(), writer.Error()
+}
+
+// decodePackSizes reads the rows of a pack size file. A malformed file is an error, while the
+// problems of a row are reported on the row.
+func decodePackSizes(r io.Reader, format string) ([]entities.PackSizeImportRow, error) {
+	var rows []entities.PackSizeImportRow
+	var err error
+	switch format {
+	case packSizeFormatCSV:
+		rows, err = decodePackSizesCSV(r)
+	case packSizeFormatJSON:
+		rows, err = decodePackSizesJSON(r)
+	case packSizeFormatYAML:
+		rows, err = decodePackSizesYAML(r)
+	default:
+		return nil, errors.ErrUnsupportedFormat
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(rows) > maxImportRows {
+		return nil, &packSizeFileError{msg: fmt.Sprintf("%d pack sizes, at most %d per import", len(rows), maxImportRows)}
+	}
+
+	return rows, nil
+}
+
+// decodePackSizesCSV reads pack sizes from CSV, locating the columns by the header row
+func decodePackSizesCSV(r io.Reader) ([]entities.PackSizeImportRow, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if stderr.Is(err, io.EOF) {
+			return nil, &packSizeFileError{msg: "missing header row"}
+		}
+
+		return nil, &packSizeFileError{msg: err.Error()}
+	}
+
+	known := make(map[string]bool, len(packSizeCSVColumns))
+	for _, name := range packSizeCSVColumns {
+		known[name] = true
+	}
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		name = strings.ToLower(strings.TrimSpace(name))
+		if !known[name] {
+			return nil, &packSizeFileError{msg: fmt.Sprintf("unknown column %q", name)}
+		}
+		columns[name] = i
+	}
+	if _, ok := columns["size"]; !ok {
+		return nil, &packSizeFileError{msg: `missing column "size"`}
+	}
+
+	rows := make([]entities.PackSizeImportRow, 0)
+	for row := 1; ; row++ {
+		record, err := reader.Read()
+		if stderr.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, &packSizeFileError{msg: err.Error()}
+		}
+
+		rows = append(rows, parsePackSizeCSVRecord(row, record, columns))
+	}
+
+	return rows, nil
+}
+
+// parsePackSizeCSVRecord converts a CSV record into an import row, an empty cell leaving the
+// property unset
+func parsePackSizeCSVRecord(row int, record []string, columns map[string]int) entities.PackSizeImportRow {
+	result := entities.PackSizeImportRow{Row: row, Errors: []string{}}
+	field := func(name string) string {
+		i, ok := columns[name]
+		if !ok {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+	parseFloat := func(name string) float64 {
+		value := field(name)
+		if value == "" {
+			return 0
+		}
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("invalid %s %q", name, value))
+		}
+		return f
+	}
+	parseTime := func(name string) *time.Time {
+		value := field(name)
+		if value == "" {
+			return nil
+		}
+		t, err := time.Parse(time.RFC3339Nano, value)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("invalid %s %q, expected an RFC 3339 timestamp", name, value))
+			return nil
+		}
+		return &t
+	}
+
+	size, err := strconv.Atoi(field("size"))
+	if err != nil {
+		result.Errors = append(result.Errors, fmt.Sprintf("invalid size %q", field("size")))
+	}
+
+	var active *bool
+	if value := field("active"); value != "" {
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			result.Errors = append(result.Errors, fmt.Sprintf("invalid active %q", value))
+		}
+		active = &b
+	}
+
+	var tags []string
+	if value := field("tags"); value != "" {
+		tags = strings.Split(value, ";")
+	}
+
+	result.Definition = entities.PackSizeDefinition{
+		Size: size,
+		Attributes: entities.PackSizeAttributes{
+			Weight:         parseFloat("weight"),
+			MaterialWeight: parseFloat("material_weight"),
+			EmissionFactor: parseFloat("emission_factor"),
+			Dimensions: entities.Dimensions{
+				Length: parseFloat("length"),
+				Width:  parseFloat("width"),
+				Height: parseFloat("height"),
+			},
+			Name:      field("name"),
+			SKU:       field("sku"),
+			Tags:      tags,
+			Active:    active,
+			ValidFrom: parseTime("valid_from"),
+			ValidTo:   parseTime("valid_to"),
+		},
+	}
+
+	return result
+}
+
+// decodePackSizesJSON reads pack sizes from a JSON array, decoding every element on its own
+func decodePackSizesJSON(r io.Reader) ([]entities.PackSizeImportRow, error) {
+	var elements []json.RawMessage
+	decoder := json.NewDecoder(r)
+	if err := decoder.Decode(&elements); err != nil {
+		return nil, &packSizeFileError{msg: "expected an array of pack sizes: " + err.Error()}
+	}
+	if _, err := decoder.Token(); err != io.EOF {
+		return nil, &packSizeFileError{msg: "unexpected data after the array of pack sizes"}
+	}
+
+	rows := make([]entities.PackSizeImportRow, len(elements))
+	for i, element := range elements {
+		rows[i] = entities.PackSizeImportRow{Row: i + 1, Errors: []string{}}
+
+		var record packSizeRecord
+		decoder := json.NewDecoder(bytes.NewReader(element))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&record); err != nil {
+			rows[i].Errors = append(rows[i].Errors, err.Error())
+
+			continue
+		}
+		rows[i].Definition = record.toDefinition()
+	}
+
+	return rows, nil
+}
+
+// decodePackSizesYAML reads pack sizes from a YAML sequence, decoding every element on its own
+func decodePackSizesYAML(r io.Reader) ([]entities.PackSizeImportRow, error) {
+	var elements []yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&elements); err != nil {
+		if stderr.Is(err, io.EOF) {
+			return []entities.PackSizeImportRow{}, nil
+		}
+
+		return nil, &packSizeFileError{msg: "expected a sequence of pack sizes: " + err.Error()}
+	}
+
+	rows := make([]entities.PackSizeImportRow, len(elements))
+	for i, element := range elements {
+		rows[i] = entities.PackSizeImportRow{Row: i + 1, Errors: []string{}}
+
+		if element.Kind == yaml.MappingNode {
+			for j := 0; j < len(element.Content); j += 2 {
+				if key := element.Content[j].Value; !packSizeRecordKeys[key] {
+					rows[i].Errors = append(rows[i].Errors, fmt.Sprintf("unknown field %q", key))
+				}
+			}
+		}
+
+		var record packSizeRecord
+		if err := element.Decode(&record); err != nil {
+			var typeErr *yaml.TypeError
+			if stderr.As(err, &typeErr) {
+				rows[i].Errors = append(rows[i].Errors, typeErr.Errors...)
+			} else {
+				rows[i].Errors = append(rows[i].Errors, err.Error())
+			}
+		}
+		if len(rows[i].Errors) == 0 {
+			rows[i].Definition = record.toDefinition()
+		}
+	}
+
+	return rows, nil
+}
+
+// toDefinition converts a pack size record into a pack size definition
+func (r packSizeRecord) toDefinition() entities.PackSizeDefinition {
+	var dimensions entities.Dimensions
+	if r.Dimensions != nil {
+		dimensions = entities.Dimensions{
+			Length: r.Dimensions.Length,
+			Width:  r.Dimensions.Width,
+			Height: r.Dimensions.Height,
+		}
+	}
+
+	return entities.PackSizeDefinition{
+		Size: r.Size,
+		Attributes: entities.PackSizeAttributes{
+			Weight:         r.Weight,
+			MaterialWeight: r.MaterialWeight,
+			EmissionFactor: r.EmissionFactor,
+			Dimensions:     dimensions,
+			Name:           r.Name,
+			SKU:            r.SKU,
+			Tags:           r.Tags,
+			Active:         r.Active,
+			ValidFrom:      r.ValidFrom,
+			ValidTo:        r.ValidTo,
+		},
+	}
+}
+
+// Helper function to convert a pack size to a file record
+func toPackSizeRecord(packSize *entities.PackSize) packSizeRecord {
+	active := packSize.Active
+	record := packSizeRecord{
+		Size:           packSize.Size,
+		Name:           packSize.Name,
+		SKU:            packSize.SKU,
+		Tags:           packSize.Tags,
+		Active:         &active,
+		Weight:         packSize.Weight,
+		MaterialWeight: packSize.MaterialWeight,
+		EmissionFactor: packSize.EmissionFactor,
+		ValidFrom:      packSize.ValidFrom,
+		ValidTo:        packSize.ValidTo,
+	}
+	if !packSize.Dimensions.IsZero() {
+		record.Dimensions = &packSizeDimensionsRecord{
+			Length: packSize.Dimensions.Length,
+			Width:  packSize.Dimensions.Width,
+			Height: packSize.Dimensions.Height,
+		}
+	}
+
+	return record
+}
+
+// Helper function to convert an import report to response
+func toPackSizeImportReportResponse(report *entities.PackSizeImportReport) PackSizeImportReportResponse {
+	response := PackSizeImportReportResponse{
+		Strategy: string(report.Strategy),
+		DryRun:   report.DryRun,
+		Applied:  report.Applied,
+		Valid:    report.Valid(),
+		Rows:     make([]PackSizeImportRowResponse, len(report.Rows)),
+		Deleted:  make([]PackSizeResponse, len(report.Deleted)),
+	}
+
+	for i, row := range report.Rows {
+		response.Rows[i] = PackSizeImportRowResponse{
+			Row:    row.Row,
+			Size:   row.Size,
+			Action: string(row.Action),
+			Errors: row.Errors,
+		}
+		if row.PackSize != nil {
+			packSize := toPackSizeResponse(row.PackSize)
+			response.Rows[i].PackSize = &packSize
+		}
+
+		switch row.Action {
+		case entities.ImportActionCreate:
+			response.Created++
+		case entities.ImportActionUpdate:
+			response.Updated++
+		case entities.ImportActionUnchanged:
+			response.Unchanged++
+		case entities.ImportActionInvalid:
+			response.Invalid++
+		}
+	}
+
+	for i, ps := range report.Deleted {
+		response.Deleted[i] = toPackSizeResponse(ps)
+	}
+
+	return response
+}
@@ -0,0 +1,385 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+)
+
+type mockPackSizeTransferService struct {
+	packSizes []*entities.PackSize
+	report    *entities.PackSizeImportReport
+	err       error
+	rows      []entities.PackSizeImportRow
+	strategy  entities.ImportStrategy
+	dryRun    bool
+}
+
+func (m *mockPackSizeTransferService) ExportPackSizes(ctx context.Context) ([]*entities.PackSize, error) {
+	return m.packSizes, m.err
+}
+
+func (m *mockPackSizeTransferService) ImportPackSizes(
+	ctx context.Context,
+	rows []entities.PackSizeImportRow,
+	strategy entities.ImportStrategy,
+	dryRun bool,
+) (*entities.PackSizeImportReport, error) {
+	m.rows = rows
+	m.strategy = strategy
+	m.dryRun = dryRun
+	return m.report, m.err
+}
+
+func TestPackSizeTransferHandler_ExportPackSizes(t *testing.T) {
+	validFrom := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	small := &entities.PackSize{ID: "1", Size: 250, Name: "Small", Tags: []string{"a", "b"}, Active: true, Weight: 0.4}
+	large := &entities.PackSize{
+		ID:         "2",
+		Size:       1000,
+		Dimensions: entities.Dimensions{Length: 30, Width: 20, Height: 10},
+		ValidFrom:  &validFrom,
+	}
+
+	tests := []struct {
+		name           string
+		format         string
+		mockErr        error
+		expectedStatus int
+		expectedType   string
+		expectedBody   string
+	}{
+		{
+			name:           "CSV by default",
+			expectedStatus: http.StatusOK,
+			expectedType:   "text/csv; charset=utf-8",
+			expectedBody: "size,name,sku,tags,active,weight,material_weight,emission_factor,length,width,height,valid_from,valid_to\n" +
+				"250,Small,,a;b,true,0.4,,,,,,,\n" +
+				"1000,,,,false,,,,30,20,10,2026-01-01T00:00:00Z,\n",
+		},
+		{
+			name:           "JSON",
+			format:         "json",
+			expectedStatus: http.StatusOK,
+			expectedType:   "application/json; charset=utf-8",
+			expectedBody: `[
+  {
+    "size": 250,
+    "name": "Small",
+    "tags": [
+      "a",
+      "b"
+    ],
+    "active": true,
+    "weight": 0.4
+  },
+  {
+    "size": 1000,
+    "active": false,
+    "dimensions": {
+      "length": 30,
+      "width": 20,
+      "height": 10
+    },
+    "valid_from": "2026-01-01T00:00:00Z"
+  }
+]`,
+		},
+		{
+			name:           "YAML",
+			format:         "yaml",
+			expectedStatus: http.StatusOK,
+			expectedType:   "application/yaml; charset=utf-8",
+			expectedBody: `- size: 250
+  name: Small
+  tags:
+    - a
+    - b
+  active: true
+  weight: 0.4
+- size: 1000
+  active: false
+  dimensions:
+    length: 30
+    width: 20
+    height: 10
+  valid_from: 2026-01-01T00:00:00Z
+`,
+		},
+		{
+			name:           "Unsupported format",
+			format:         "xml",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Internal error",
+			mockErr:        errors.ErrDatabaseOperation,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup, with the pack size routes registered alongside
+			router := setupRouter()
+			NewPackCalculatorHandler(&mockPackSizeService{}, &mockCalculationService{}).RegisterRoutes(router)
+			service := &mockPackSizeTransferService{packSizes: []*entities.PackSize{small, large}, err: tt.mockErr}
+			NewPackSizeTransferHandler(service).RegisterRoutes(router)
+
+			// Perform request
+			url := "/api/pack-sizes/export"
+			if tt.format != "" {
+				url += "?format=" + tt.format
+			}
+			req, _ := http.NewRequest(http.MethodGet, url, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Check response
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedStatus == http.StatusOK {
+				assert.Equal(t, tt.expectedType, w.Header().Get("Content-Type"))
+				assert.Contains(t, w.Header().Get("Content-Disposition"), "pack-sizes.")
+				assert.Equal(t, tt.expectedBody, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestPackSizeTransferHandler_ImportPackSizes(t *testing.T) {
+	inactive := false
+	validReport := &entities.PackSizeImportReport{
+		Strategy: entities.ImportStrategyReplace,
+		Applied:  true,
+		Rows: []entities.PackSizeImportRowResult{
+			{Row: 1, Size: 250, Action: entities.ImportActionCreate, Errors: []string{}, PackSize: &entities.PackSize{ID: "1", Size: 250}},
+			{Row: 2, Size: 500, Action: entities.ImportActionUnchanged, Errors: []string{}, PackSize: &entities.PackSize{ID: "2", Size: 500}},
+		},
+		Deleted: []*entities.PackSize{{ID: "3", Size: 1000}},
+	}
+	invalidReport := &entities.PackSizeImportReport{
+		Strategy: entities.ImportStrategyMerge,
+		Rows: []entities.PackSizeImportRowResult{
+			{Row: 1, Size: 250, Action: entities.ImportActionCreate, Errors: []string{}},
+			{Row: 2, Action: entities.ImportActionInvalid, Errors: []string{`invalid size "x"`}},
+		},
+		Deleted: []*entities.PackSize{},
+	}
+
+	tests := []struct {
+		name             string
+		query            string
+		contentType      string
+		body             string
+		mockReport       *entities.PackSizeImportReport
+		mockErr          error
+		expectedStatus   int
+		expectedStrategy entities.ImportStrategy
+		expectedDryRun   bool
+		expectedRows     []entities.PackSizeImportRow
+	}{
+		{
+			name:             "CSV",
+			query:            "?strategy=replace&dry_run=true",
+			contentType:      "text/csv",
+			body:             "Size,Name,Tags,Active,Length,Width,Height\n250,Small,a;b,false,30,20,10\n500,,,,,,\n",
+			mockReport:       validReport,
+			expectedStatus:   http.StatusOK,
+			expectedStrategy: entities.ImportStrategyReplace,
+			expectedDryRun:   true,
+			expectedRows: []entities.PackSizeImportRow{
+				{Row: 1, Errors: []string{}, Definition: entities.PackSizeDefinition{Size: 250, Attributes: entities.PackSizeAttributes{
+					Name:       "Small",
+					Tags:       []string{"a", "b"},
+					Active:     &inactive,
+					Dimensions: entities.Dimensions{Length: 30, Width: 20, Height: 10},
+				}}},
+				{Row: 2, Errors: []string{}, Definition: entities.PackSizeDefinition{Size: 500}},
+			},
+		},
+		{
+			name:           "CSV row errors",
+			query:          "?format=csv",
+			body:           "size,weight,valid_from\nx,heavy,tomorrow\n",
+			mockReport:     invalidReport,
+			expectedStatus: http.StatusUnprocessableEntity,
+			expectedRows: []entities.PackSizeImportRow{
+				{Row: 1, Errors: []string{
+					`invalid size "x"`,
+					`invalid weight "heavy"`,
+					`invalid valid_from "tomorrow", expected an RFC 3339 timestamp`,
+				}},
+			},
+		},
+		{
+			name:           "JSON",
+			contentType:    "application/json",
+			body:           `[{"size": 250, "weight": 0.4}, {"size": 500, "colour": "red"}]`,
+			mockReport:     validReport,
+			expectedStatus: http.StatusOK,
+			expectedRows: []entities.PackSizeImportRow{
+				{Row: 1, Errors: []string{}, Definition: entities.PackSizeDefinition{Size: 250, Attributes: entities.PackSizeAttributes{Weight: 0.4}}},
+				{Row: 2, Errors: []string{`json: unknown field "colour"`}},
+			},
+		},
+		{
+			name:           "YAML",
+			query:          "?format=yaml",
+			body:           "- size: 250\n  dimensions: {length: 30, width: 20, height: 10}\n- size: x\n- size: 500\n  colour: red\n",
+			mockReport:     validReport,
+			expectedStatus: http.StatusOK,
+			expectedRows: []entities.PackSizeImportRow{
+				{Row: 1, Errors: []string{}, Definition: entities.PackSizeDefinition{Size: 250, Attributes: entities.PackSizeAttributes{
+					Dimensions: entities.Dimensions{Length: 30, Width: 20, Height: 10},
+				}}},
+				{Row: 2, Errors: []string{"line 3: cannot unmarshal !!str `x` into int"}},
+				{Row: 3, Errors: []string{`unknown field "colour"`}},
+			},
+		},
+		{
+			name:           "Missing size column",
+			contentType:    "text/csv",
+			body:           "name\nSmall\n",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Unknown column",
+			contentType:    "text/csv",
+			body:           "size,colour\n250,red\n",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Malformed JSON",
+			contentType:    "application/json",
+			body:           `{"size": 250}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "JSON with a second value",
+			contentType:    "application/json",
+			body:           `[{"size": 250}] [{"size": 500}]`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "JSON with trailing data",
+			contentType:    "application/json",
+			body:           `[{"size": 250}]]`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "File too large",
+			contentType:    "text/csv",
+			body:           "size,name\n250," + strings.Repeat("a", maxImportBytes) + "\n",
+			expectedStatus: http.StatusRequestEntityTooLarge,
+		},
+		{
+			name:           "Too many pack sizes",
+			contentType:    "text/csv",
+			body:           "size\n" + strings.Repeat("250\n", maxImportRows+1),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Unknown format",
+			contentType:    "application/xml",
+			body:           "<pack-sizes/>",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid strategy",
+			query:          "?strategy=upsert",
+			contentType:    "text/csv",
+			body:           "size\n250\n",
+			mockErr:        &errors.ValidationError{Field: "strategy", Err: errors.ErrInvalidImport},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Conflict",
+			contentType:    "text/csv",
+			body:           "size\n250\n",
+			mockErr:        errors.ErrDuplicatePackSize,
+			expectedStatus: http.StatusConflict,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup, with the pack size routes registered alongside
+			router := setupRouter()
+			NewPackCalculatorHandler(&mockPackSizeService{}, &mockCalculationService{}).RegisterRoutes(router)
+			service := &mockPackSizeTransferService{report: tt.mockReport, err: tt.mockErr}
+			NewPackSizeTransferHandler(service).RegisterRoutes(router)
+
+			// Perform request
+			req, _ := http.NewRequest(http.MethodPost, "/api/pack-sizes/import"+tt.query, strings.NewReader(tt.body))
+			if tt.contentType != "" {
+				req.Header.Set("Content-Type", tt.contentType)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Check response
+			assert.Equal(t, tt.expectedStatus, w.Code)
+			if tt.expectedRows != nil {
+				assert.Equal(t, tt.expectedRows, service.rows)
+				assert.Equal(t, tt.expectedStrategy, service.strategy)
+				assert.Equal(t, tt.expectedDryRun, service.dryRun)
+			}
+			if tt.mockReport != nil {
+				var response PackSizeImportReportResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Equal(t, tt.mockReport.Valid(), response.Valid)
+				assert.Len(t, response.Rows, len(tt.mockReport.Rows))
+				assert.Len(t, response.Deleted, len(tt.mockReport.Deleted))
+			}
+		})
+	}
+
+	// Check the counts of a report
+	response := toPackSizeImportReportResponse(validReport)
+	assert.Equal(t, 1, response.Created)
+	assert.Equal(t, 1, response.Unchanged)
+	assert.Equal(t, 0, response.Invalid)
+	if assert.NotNil(t, response.Rows[0].PackSize) {
+		assert.Equal(t, "1", response.Rows[0].PackSize.ID)
+	}
+}
+
+func TestPackSizeTransferHandler_CSVRoundTrip(t *testing.T) {
+	validFrom := time.Date(2026, 1, 1, 8, 30, 15, 123456789, time.UTC)
+	packSize := &entities.PackSize{ID: "1", Size: 250, Active: true, ValidFrom: &validFrom}
+
+	router := setupRouter()
+	service := &mockPackSizeTransferService{
+		packSizes: []*entities.PackSize{packSize},
+		report:    &entities.PackSizeImportReport{Rows: []entities.PackSizeImportRowResult{}, Deleted: []*entities.PackSize{}},
+	}
+	NewPackSizeTransferHandler(service).RegisterRoutes(router)
+
+	// Export the pack sizes
+	req, _ := http.NewRequest(http.MethodGet, "/api/pack-sizes/export", nil)
+	w := httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// Import the export unchanged, the timestamps keep their precision
+	req, _ = http.NewRequest(http.MethodPost, "/api/pack-sizes/import", strings.NewReader(w.Body.String()))
+	req.Header.Set("Content-Type", "text/csv")
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	if assert.Len(t, service.rows, 1) && assert.NotNil(t, service.rows[0].Definition.Attributes.ValidFrom) {
+		assert.Empty(t, service.rows[0].Errors)
+		assert.True(t, validFrom.Equal(*service.rows[0].Definition.Attributes.ValidFrom))
+	}
+}
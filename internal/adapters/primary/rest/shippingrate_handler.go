@@ -0,0 +1,193 @@
+package rest
+
+import (
+	"encoding/csv"
+	stderr "errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/ports/primary"
+)
+
+// shippingRateCSVColumns lists the columns required in an imported rate table
+var shippingRateCSVColumns = []string{"carrier", "max_weight", "max_parcels", "price"}
+
+// ShippingRateHandler handles HTTP requests for the carrier rate table
+type ShippingRateHandler struct {
+	shippingRateService primary.ShippingRateService
+}
+
+// NewShippingRateHandler creates a new shipping rate handler
+func NewShippingRateHandler(shippingRateService primary.ShippingRateService) *ShippingRateHandler {
+	return &ShippingRateHandler{
+		shippingRateService: shippingRateService,
+	}
+}
+
+// RegisterRoutes registers the REST API routes
+func (h *ShippingRateHandler) RegisterRoutes(r *gin.Engine) {
+	shippingRates := r.Group("/api/shipping-rates")
+
+	shippingRates.GET("", h.GetAllShippingRates)
+	shippingRates.POST("/import", h.ImportShippingRates)
+}
+
+// GetAllShippingRates godoc
+// @Summary Get the carrier rate table
+// @Description Get all carrier shipping rates
+// @Tags shipping-rates
+// @Produce json
+// @Success 200 {object} ShippingRatesResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /shipping-rates [get]
+func (h *ShippingRateHandler) GetAllShippingRates(c *gin.Context) {
+	rates, err := h.shippingRateService.GetAllShippingRates(c.Request.Context())
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, toShippingRatesResponse(rates))
+}
+
+// ImportShippingRates godoc
+// @Summary Import the carrier rate table
+// @Description Replace the carrier rate table with the rates of a CSV file with the columns carrier, max_weight, max_parcels and price
+// @Tags shipping-rates
+// @Accept text/csv
+// @Produce json
+// @Param rates body string true "Rate table in CSV format"
+// @Success 200 {object} ShippingRatesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+// @Router /shipping-rates/import [post]
+func (h *ShippingRateHandler) ImportShippingRates(c *gin.Context) {
+	rates, err := parseShippingRatesCSV(c.Request.Body)
+	if err != nil {
+		var csvErr *shippingRateCSVError
+		if stderr.As(err, &csvErr) {
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "Invalid CSV: " + err.Error()})
+
+			return
+		}
+
+		handleError(c, err)
+
+		return
+	}
+
+	imported, err := h.shippingRateService.ImportShippingRates(c.Request.Context(), rates)
+	if err != nil {
+		handleError(c, err)
+
+		return
+	}
+
+	c.JSON(http.StatusOK, toShippingRatesResponse(imported))
+}
+
+// shippingRateCSVError represents a malformed rate table file
+type shippingRateCSVError struct {
+	msg string
+}
+
+// Error returns the error message
+func (e *shippingRateCSVError) Error() string {
+	return e.msg
+}
+
+// parseShippingRatesCSV reads a rate table from CSV, locating the columns by the header row
+func parseShippingRatesCSV(r io.Reader) ([]*entities.ShippingRate, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if err != nil {
+		if stderr.Is(err, io.EOF) {
+			return nil, &shippingRateCSVError{msg: "missing header row"}
+		}
+
+		return nil, &shippingRateCSVError{msg: err.Error()}
+	}
+
+	columns := make(map[string]int, len(header))
+	for i, name := range header {
+		columns[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range shippingRateCSVColumns {
+		if _, ok := columns[name]; !ok {
+			return nil, &shippingRateCSVError{msg: fmt.Sprintf("missing column %q", name)}
+		}
+	}
+
+	rates := make([]*entities.ShippingRate, 0)
+	for line := 2; ; line++ {
+		record, err := reader.Read()
+		if stderr.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, &shippingRateCSVError{msg: err.Error()}
+		}
+
+		rate, err := parseShippingRateRecord(record, columns)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+
+		rates = append(rates, rate)
+	}
+
+	return rates, nil
+}
+
+// parseShippingRateRecord converts a CSV record into a shipping rate
+func parseShippingRateRecord(record []string, columns map[string]int) (*entities.ShippingRate, error) {
+	field := func(name string) string {
+		return strings.TrimSpace(record[columns[name]])
+	}
+
+	maxWeight, err := strconv.ParseFloat(field("max_weight"), 64)
+	if err != nil {
+		return nil, &shippingRateCSVError{msg: fmt.Sprintf("invalid max_weight %q", field("max_weight"))}
+	}
+
+	maxParcels, err := strconv.Atoi(field("max_parcels"))
+	if err != nil {
+		return nil, &shippingRateCSVError{msg: fmt.Sprintf("invalid max_parcels %q", field("max_parcels"))}
+	}
+
+	price, err := strconv.ParseFloat(field("price"), 64)
+	if err != nil {
+		return nil, &shippingRateCSVError{msg: fmt.Sprintf("invalid price %q", field("price"))}
+	}
+
+	return entities.NewShippingRate(field("carrier"), maxWeight, maxParcels, price)
+}
+
+// Helper function to convert shipping rates to response
+func toShippingRatesResponse(rates []*entities.ShippingRate) ShippingRatesResponse {
+	response := ShippingRatesResponse{
+		Items: make([]ShippingRateResponse, len(rates)),
+	}
+
+	for i, rate := range rates {
+		response.Items[i] = ShippingRateResponse{
+			ID:         rate.ID,
+			Carrier:    rate.Carrier,
+			MaxWeight:  rate.MaxWeight,
+			MaxParcels: rate.MaxParcels,
+			Price:      rate.Price,
+			CreatedAt:  rate.CreatedAt,
+		}
+	}
+
+	return response
+}
@@ -0,0 +1,162 @@
+package rest
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+)
+
+type mockShippingRateService struct {
+	rates []*entities.ShippingRate
+	err   error
+}
+
+func (m *mockShippingRateService) ImportShippingRates(ctx context.Context, rates []*entities.ShippingRate) ([]*entities.ShippingRate, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return rates, nil
+}
+
+func (m *mockShippingRateService) GetAllShippingRates(ctx context.Context) ([]*entities.ShippingRate, error) {
+	return m.rates, m.err
+}
+
+func TestShippingRateHandler_GetAllShippingRates(t *testing.T) {
+	// Create test shipping rates
+	rate, _ := entities.NewShippingRate("DHL", 10, 2, 7.5)
+
+	tests := []struct {
+		name           string
+		mockRates      []*entities.ShippingRate
+		mockErr        error
+		expectedStatus int
+		expectedCount  int
+	}{
+		{
+			name:           "Success",
+			mockRates:      []*entities.ShippingRate{rate},
+			mockErr:        nil,
+			expectedStatus: http.StatusOK,
+			expectedCount:  1,
+		},
+		{
+			name:           "Service error",
+			mockRates:      nil,
+			mockErr:        errors.ErrDatabaseOperation,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			router := setupRouter()
+			handler := NewShippingRateHandler(&mockShippingRateService{rates: tt.mockRates, err: tt.mockErr})
+			handler.RegisterRoutes(router)
+
+			// Perform request
+			req, _ := http.NewRequest(http.MethodGet, "/api/shipping-rates", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Check response
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			// If success, check response body
+			if tt.expectedStatus == http.StatusOK {
+				var response ShippingRatesResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Len(t, response.Items, tt.expectedCount)
+				assert.Equal(t, "DHL", response.Items[0].Carrier)
+			}
+		})
+	}
+}
+
+func TestShippingRateHandler_ImportShippingRates(t *testing.T) {
+	tests := []struct {
+		name           string
+		body           string
+		mockErr        error
+		expectedStatus int
+		expectedCount  int
+	}{
+		{
+			name:           "Success",
+			body:           "carrier,max_weight,max_parcels,price\nDHL,10,2,7.5\nUPS,20,5,12\n",
+			expectedStatus: http.StatusOK,
+			expectedCount:  2,
+		},
+		{
+			name:           "Columns in any order",
+			body:           "price, carrier, max_parcels, max_weight\n7.5, DHL, 2, 10\n",
+			expectedStatus: http.StatusOK,
+			expectedCount:  1,
+		},
+		{
+			name:           "Empty file",
+			body:           "",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Missing column",
+			body:           "carrier,max_weight,price\nDHL,10,7.5\n",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid number",
+			body:           "carrier,max_weight,max_parcels,price\nDHL,ten,2,7.5\n",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Invalid rate",
+			body:           "carrier,max_weight,max_parcels,price\nDHL,10,2,-1\n",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "Service error",
+			body:           "carrier,max_weight,max_parcels,price\nDHL,10,2,7.5\n",
+			mockErr:        errors.ErrDatabaseOperation,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Setup
+			router := setupRouter()
+			mockService := &mockShippingRateService{err: tt.mockErr}
+			handler := NewShippingRateHandler(mockService)
+			handler.RegisterRoutes(router)
+
+			// Perform request
+			req, _ := http.NewRequest(http.MethodPost, "/api/shipping-rates/import", strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "text/csv")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			// Check response
+			assert.Equal(t, tt.expectedStatus, w.Code)
+
+			// If success, check response body
+			if tt.expectedStatus == http.StatusOK {
+				var response ShippingRatesResponse
+				err := json.Unmarshal(w.Body.Bytes(), &response)
+				assert.NoError(t, err)
+				assert.Len(t, response.Items, tt.expectedCount)
+				assert.Equal(t, "DHL", response.Items[0].Carrier)
+				assert.Equal(t, 7.5, response.Items[0].Price)
+			}
+		})
+	}
+}
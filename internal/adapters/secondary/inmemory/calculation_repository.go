@@ -0,0 +1,105 @@
+package inmemory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// CalculationRepository is an in-memory implementation of CalculationRepository
+type CalculationRepository struct {
+	calculations map[string]*entities.CalculationResult
+	mutex        sync.RWMutex
+}
+
+// Ensure CalculationRepository implements the CalculationRepository interface
+var _ secondary.CalculationRepository = (*CalculationRepository)(nil)
+
+// NewCalculationRepository creates a new in-memory calculation repository
+func NewCalculationRepository() *CalculationRepository {
+	return &CalculationRepository{
+		calculations: make(map[string]*entities.CalculationResult),
+	}
+}
+
+// Create stores a calculation result in memory
+func (r *CalculationRepository) Create(ctx context.Context, result *entities.CalculationResult) (*entities.CalculationResult, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Generate UUID if not provided
+	if result.ID == "" {
+		result.ID = uuid.New().String()
+	}
+
+	// Set timestamp
+	if result.CreatedAt.IsZero() {
+		result.CreatedAt = time.Now()
+	}
+
+	// Store a copy in memory
+	r.calculations[result.ID] = r.clone(result)
+
+	// Return a copy to avoid mutation
+	return r.clone(result), nil
+}
+
+// FindByID retrieves a calculation result by ID from memory
+func (r *CalculationRepository) FindByID(ctx context.Context, id string) (*entities.CalculationResult, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	result, exists := r.calculations[id]
+	if !exists {
+		return nil, errors.ErrCalculationNotFound
+	}
+
+	return r.clone(result), nil
+}
+
+// PurgeBefore removes the calculation results created before the given time from memory
+func (r *CalculationRepository) PurgeBefore(ctx context.Context, before time.Time) (int64, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	var purged int64
+	for id, result := range r.calculations {
+		if result.CreatedAt.Before(before) {
+			delete(r.calculations, id)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+// Helper method to clone a calculation result to avoid mutation
+func (r *CalculationRepository) clone(result *entities.CalculationResult) *entities.CalculationResult {
+	packs := make(map[int]int, len(result.Packs))
+	for size, quantity := range result.Packs {
+		packs[size] = quantity
+	}
+
+	var estimates []entities.ShippingEstimate
+	if result.ShippingEstimates != nil {
+		estimates = make([]entities.ShippingEstimate, len(result.ShippingEstimates))
+		copy(estimates, result.ShippingEstimates)
+	}
+
+	return &entities.CalculationResult{
+		ID:                result.ID,
+		ItemsOrdered:      result.ItemsOrdered,
+		TotalItems:        result.TotalItems,
+		Packs:             packs,
+		TotalWeight:       result.TotalWeight,
+		ShippingEstimates: estimates,
+		Footprint:         result.Footprint,
+		CreatedAt:         result.CreatedAt,
+	}
+}
@@ -0,0 +1,71 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+)
+
+func TestCalculationRepository_Create(t *testing.T) {
+	repo := NewCalculationRepository()
+
+	// Save a calculation result
+	result := entities.NewCalculationResult(751, map[int]int{500: 1, 100: 3})
+	created, err := repo.Create(context.Background(), result)
+	require.NoError(t, err)
+	assert.NotEmpty(t, created.ID)
+	assert.Equal(t, 751, created.ItemsOrdered)
+	assert.Equal(t, 800, created.TotalItems)
+	assert.False(t, created.CreatedAt.IsZero())
+
+	// Mutating the returned copy must not affect the stored result
+	created.Packs[500] = 10
+
+	stored, err := repo.FindByID(context.Background(), created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, map[int]int{500: 1, 100: 3}, stored.Packs)
+}
+
+func TestCalculationRepository_FindByID(t *testing.T) {
+	repo := NewCalculationRepository()
+
+	created, err := repo.Create(context.Background(), entities.NewCalculationResult(10, map[int]int{5: 2}))
+	require.NoError(t, err)
+
+	// Find by ID
+	found, err := repo.FindByID(context.Background(), created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID, found.ID)
+	assert.Equal(t, created.Packs, found.Packs)
+
+	// Find non-existent ID
+	_, err = repo.FindByID(context.Background(), "non-existent-id")
+	assert.ErrorIs(t, err, errors.ErrCalculationNotFound)
+}
+
+func TestCalculationRepository_PurgeBefore(t *testing.T) {
+	repo := NewCalculationRepository()
+
+	old := entities.NewCalculationResult(10, map[int]int{5: 2})
+	old.CreatedAt = time.Now().Add(-48 * time.Hour)
+	old, err := repo.Create(context.Background(), old)
+	require.NoError(t, err)
+	recent, err := repo.Create(context.Background(), entities.NewCalculationResult(20, map[int]int{5: 4}))
+	require.NoError(t, err)
+
+	// Only the results created before the time are removed
+	purged, err := repo.PurgeBefore(context.Background(), time.Now().Add(-24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	_, err = repo.FindByID(context.Background(), old.ID)
+	assert.ErrorIs(t, err, errors.ErrCalculationNotFound)
+	_, err = repo.FindByID(context.Background(), recent.ID)
+	assert.NoError(t, err)
+}
@@ -0,0 +1,180 @@
+package inmemory
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// outboxRecord is an entry of the in-memory outbox
+type outboxRecord struct {
+	entry entities.OutboxEntry
+	dead  bool // Given up, never claimed again
+}
+
+// OutboxRepository is an in-memory implementation of OutboxRepository keeping the outbox with
+// the pack sizes of the in-memory pack size repository, so transactions roll back both. Delivered
+// entries are forgotten.
+type OutboxRepository struct {
+	packSizes *PackSizeRepository
+}
+
+// Ensure OutboxRepository implements the OutboxRepository interface
+var _ secondary.OutboxRepository = (*OutboxRepository)(nil)
+
+// NewOutboxRepository creates a new in-memory outbox repository of the pack size repository
+func NewOutboxRepository(packSizes *PackSizeRepository) *OutboxRepository {
+	return &OutboxRepository{
+		packSizes: packSizes,
+	}
+}
+
+// Add adds events to the outbox, due for delivery now
+func (r *OutboxRepository) Add(ctx context.Context, events ...*entities.PackSizeEvent) error {
+	r.packSizes.mutex.Lock()
+	defer r.packSizes.mutex.Unlock()
+
+	return r.packSizes.addEvents(ctx, events)
+}
+
+// ClaimPending retrieves the entries due for delivery, oldest first, hiding them for the lease
+func (r *OutboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*entities.OutboxEntry, error) {
+	r.packSizes.mutex.Lock()
+	defer r.packSizes.mutex.Unlock()
+
+	return r.packSizes.claimPending(ctx, limit, lease)
+}
+
+// MarkDelivered removes a delivered entry from the outbox
+func (r *OutboxRepository) MarkDelivered(ctx context.Context, id string) error {
+	r.packSizes.mutex.Lock()
+	defer r.packSizes.mutex.Unlock()
+
+	return r.packSizes.markDelivered(ctx, id)
+}
+
+// MarkFailed records a failed delivery of an entry, delivered again from the given time
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id string, reason string, nextAttemptAt time.Time) error {
+	r.packSizes.mutex.Lock()
+	defer r.packSizes.mutex.Unlock()
+
+	return r.packSizes.markFailed(ctx, id, reason, nextAttemptAt)
+}
+
+// MarkDead records the last failed delivery of an entry, which is never delivered again
+func (r *OutboxRepository) MarkDead(ctx context.Context, id string, reason string) error {
+	r.packSizes.mutex.Lock()
+	defer r.packSizes.mutex.Unlock()
+
+	return r.packSizes.markDead(ctx, id, reason)
+}
+
+// addEvents is Add for callers holding the mutex, assigning the IDs of the events
+func (r *PackSizeRepository) addEvents(ctx context.Context, events []*entities.PackSizeEvent) error {
+	now := time.Now()
+	for _, event := range events {
+		if event.ID == "" {
+			event.ID = uuid.New().String()
+		}
+
+		// Keep a copy so callers cannot change the outbox
+		stored := *event
+		stored.PackSize = r.clone(event.PackSize)
+		r.outbox = append(r.outbox, &outboxRecord{
+			entry: entities.OutboxEntry{
+				Event:         &stored,
+				NextAttemptAt: now,
+			},
+		})
+	}
+
+	return nil
+}
+
+// claimPending is ClaimPending for callers holding the mutex
+func (r *PackSizeRepository) claimPending(ctx context.Context, limit int, lease time.Duration) ([]*entities.OutboxEntry, error) {
+	now := time.Now()
+	entries := make([]*entities.OutboxEntry, 0, limit)
+	for _, record := range r.outbox {
+		if len(entries) == limit {
+			break
+		}
+		if record.dead || record.entry.NextAttemptAt.After(now) {
+			continue
+		}
+
+		record.entry.NextAttemptAt = now.Add(lease)
+		entries = append(entries, r.cloneOutboxEntry(&record.entry))
+	}
+
+	return entries, nil
+}
+
+// markDelivered is MarkDelivered for callers holding the mutex
+func (r *PackSizeRepository) markDelivered(ctx context.Context, id string) error {
+	i, err := r.findOutboxRecord(id)
+	if err != nil {
+		return err
+	}
+	r.outbox = slices.Delete(r.outbox, i, i+1)
+
+	return nil
+}
+
+// markFailed is MarkFailed for callers holding the mutex
+func (r *PackSizeRepository) markFailed(ctx context.Context, id string, reason string, nextAttemptAt time.Time) error {
+	i, err := r.findOutboxRecord(id)
+	if err != nil {
+		return err
+	}
+	record := r.outbox[i]
+	record.entry.Attempts++
+	record.entry.LastError = reason
+	record.entry.NextAttemptAt = nextAttemptAt
+
+	return nil
+}
+
+// markDead is MarkDead for callers holding the mutex
+func (r *PackSizeRepository) markDead(ctx context.Context, id string, reason string) error {
+	i, err := r.findOutboxRecord(id)
+	if err != nil {
+		return err
+	}
+	record := r.outbox[i]
+	record.entry.Attempts++
+	record.entry.LastError = reason
+	record.dead = true
+
+	return nil
+}
+
+// findOutboxRecord returns the position of the outbox record of an event. The caller must hold
+// the mutex.
+func (r *PackSizeRepository) findOutboxRecord(id string) (int, error) {
+	i := slices.IndexFunc(r.outbox, func(record *outboxRecord) bool {
+		return record.entry.Event.ID == id
+	})
+	if i < 0 {
+		return 0, fmt.Errorf("%w: %s", errors.ErrOutboxEntryNotFound, id)
+	}
+
+	return i, nil
+}
+
+// cloneOutboxEntry copies an outbox entry so callers cannot change the outbox
+func (r *PackSizeRepository) cloneOutboxEntry(entry *entities.OutboxEntry) *entities.OutboxEntry {
+	copied := *entry
+	event := *entry.Event
+	event.PackSize = r.clone(entry.Event.PackSize)
+	copied.Event = &event
+
+	return &copied
+}
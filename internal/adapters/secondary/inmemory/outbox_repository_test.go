@@ -0,0 +1,86 @@
+package inmemory
+
+import (
+	"context"
+	stderr "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+func TestOutboxRepository_ClaimPending(t *testing.T) {
+	packSizes := NewPackSizeRepository()
+	repo := NewOutboxRepository(packSizes)
+	ctx := context.Background()
+
+	events := make([]*entities.PackSizeEvent, 3)
+	for i := range events {
+		events[i] = entities.NewPackSizeEvent(entities.PackSizeCreated, &entities.PackSize{ID: "id", Size: 100 * (i + 1)}, "alice", "req-1")
+	}
+	require.NoError(t, repo.Add(ctx, events...))
+	for _, event := range events {
+		assert.NotEmpty(t, event.ID)
+	}
+
+	// Claimed entries are hidden for the lease, oldest first
+	claimed, err := repo.ClaimPending(ctx, 2, time.Hour)
+	require.NoError(t, err)
+	if assert.Len(t, claimed, 2) {
+		assert.Equal(t, events[0].ID, claimed[0].Event.ID)
+		assert.Equal(t, 200, claimed[1].Event.PackSize.Size)
+	}
+	claimed, err = repo.ClaimPending(ctx, 2, time.Hour)
+	require.NoError(t, err)
+	if assert.Len(t, claimed, 1) {
+		assert.Equal(t, events[2].ID, claimed[0].Event.ID)
+	}
+
+	// Delivered entries are gone, failed ones come back when due and given up ones never
+	require.NoError(t, repo.MarkDelivered(ctx, events[0].ID))
+	require.NoError(t, repo.MarkFailed(ctx, events[1].ID, "unavailable", time.Now().Add(-time.Second)))
+	require.NoError(t, repo.MarkDead(ctx, events[2].ID, "unavailable"))
+
+	claimed, err = repo.ClaimPending(ctx, 10, time.Hour)
+	require.NoError(t, err)
+	if assert.Len(t, claimed, 1) {
+		assert.Equal(t, events[1].ID, claimed[0].Event.ID)
+		assert.Equal(t, 1, claimed[0].Attempts)
+		assert.Equal(t, "unavailable", claimed[0].LastError)
+	}
+
+	err = repo.MarkDelivered(ctx, events[0].ID)
+	assert.True(t, stderr.Is(err, errors.ErrOutboxEntryNotFound))
+}
+
+func TestOutboxRepository_RolledBackWithTransaction(t *testing.T) {
+	packSizes := NewPackSizeRepository()
+	repo := NewOutboxRepository(packSizes)
+	transactions := NewTransactionManager(packSizes)
+	ctx := context.Background()
+
+	// Only the events of the successful transaction are kept, the failed one rolls back its pack size too
+	for _, failure := range []error{stderr.New("failed"), nil} {
+		err := transactions.WithinTransaction(ctx, func(tx secondary.Transaction) error {
+			packSize, _ := entities.NewPackSize(250)
+			created, err := tx.PackSizes().Create(ctx, packSize)
+			if err != nil {
+				return err
+			}
+			if err := tx.Outbox().Add(ctx, entities.NewPackSizeEvent(entities.PackSizeCreated, created, "", "")); err != nil {
+				return err
+			}
+			return failure
+		})
+		assert.Equal(t, failure, err)
+	}
+
+	claimed, err := repo.ClaimPending(ctx, 10, time.Hour)
+	require.NoError(t, err)
+	assert.Len(t, claimed, 1)
+}
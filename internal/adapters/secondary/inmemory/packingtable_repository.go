@@ -0,0 +1,50 @@
+package inmemory
+
+import (
+	"context"
+	"sync"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// PackingTableRepository is an in-memory implementation of PackingTableRepository
+type PackingTableRepository struct {
+	tables map[string]*entities.PackingTable
+	mutex  sync.RWMutex
+}
+
+// Ensure PackingTableRepository implements the PackingTableRepository interface
+var _ secondary.PackingTableRepository = (*PackingTableRepository)(nil)
+
+// NewPackingTableRepository creates a new in-memory packing table repository
+func NewPackingTableRepository() *PackingTableRepository {
+	return &PackingTableRepository{
+		tables: make(map[string]*entities.PackingTable),
+	}
+}
+
+// Save stores the table, replacing the table of the same pack size set. Tables are never
+// modified once built, so they are stored without copying.
+func (r *PackingTableRepository) Save(ctx context.Context, table *entities.PackingTable) error {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	r.tables[table.Key()] = table
+
+	return nil
+}
+
+// FindByKey retrieves the table of a pack size set from memory
+func (r *PackingTableRepository) FindByKey(ctx context.Context, key string) (*entities.PackingTable, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	table, ok := r.tables[key]
+	if !ok {
+		return nil, errors.ErrPackingTableNotFound
+	}
+
+	return table, nil
+}
@@ -0,0 +1,28 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+)
+
+func TestPackingTableRepository(t *testing.T) {
+	repo := NewPackingTableRepository()
+	table := &entities.PackingTable{Sizes: []int{500, 250}, Ceiling: 1}
+
+	// Missing table
+	_, err := repo.FindByKey(context.Background(), table.Key())
+	assert.ErrorIs(t, err, errors.ErrPackingTableNotFound)
+
+	// Stored table is found by the key of its pack size set
+	require.NoError(t, repo.Save(context.Background(), table))
+
+	found, err := repo.FindByKey(context.Background(), entities.PackSizeSetKey([]int{250, 500}))
+	require.NoError(t, err)
+	assert.Same(t, table, found)
+}
@@ -1,6 +1,10 @@
 package inmemory
 
 import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
 	"sync"
 	"time"
 
@@ -9,11 +13,14 @@ import (
 	"go-pack-calculator/internal/domain/entities"
 	"go-pack-calculator/internal/domain/errors"
 	"go-pack-calculator/internal/ports/secondary"
+	"go-pack-calculator/internal/shared/types"
 )
 
 // PackSizeRepository is an in-memory implementation of PackSizeRepository
 type PackSizeRepository struct {
 	packSizes map[string]*entities.PackSize
+	audit     []*entities.PackSizeAuditEntry // Oldest first
+	outbox    []*outboxRecord                // Oldest first
 	mutex     sync.RWMutex
 }
 
@@ -28,49 +35,75 @@ func NewPackSizeRepository() *PackSizeRepository {
 }
 
 // Create creates a new pack size in memory
-func (r *PackSizeRepository) Create(packSize *entities.PackSize) (*entities.PackSize, error) {
+func (r *PackSizeRepository) Create(ctx context.Context, packSize *entities.PackSize) (*entities.PackSize, error) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
+	return r.create(ctx, packSize)
+}
+
+// create is Create for callers holding the mutex
+func (r *PackSizeRepository) create(ctx context.Context, packSize *entities.PackSize) (*entities.PackSize, error) {
+	// Reject a second pack size of the same size
+	if r.hasSize(packSize.Size, packSize.ID) {
+		return nil, fmt.Errorf("%w: size %d", errors.ErrDuplicatePackSize, packSize.Size)
+	}
+
 	// Generate UUID if not provided
 	if packSize.ID == "" {
 		packSize.ID = uuid.New().String()
 	}
 
-	// Set timestamps
+	// Set the first version and timestamps
+	packSize.Version = 1
 	now := time.Now()
 	packSize.CreatedAt = now
 	packSize.UpdatedAt = now
 
 	// Store in memory
 	r.packSizes[packSize.ID] = packSize
+	r.record(ctx, entities.AuditActionCreated, nil, packSize)
 
 	// Return a copy to avoid mutation
 	return r.clone(packSize), nil
 }
 
 // FindAll retrieves all pack sizes from memory
-func (r *PackSizeRepository) FindAll() ([]*entities.PackSize, error) {
+func (r *PackSizeRepository) FindAll(ctx context.Context) ([]*entities.PackSize, error) {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
+	return r.findAll(ctx)
+}
+
+// findAll is FindAll for callers holding the mutex
+func (r *PackSizeRepository) findAll(ctx context.Context) ([]*entities.PackSize, error) {
 	packSizes := make([]*entities.PackSize, 0, len(r.packSizes))
 	for _, ps := range r.packSizes {
-		packSizes = append(packSizes, r.clone(ps))
+		if ps.DeletedAt == nil {
+			packSizes = append(packSizes, r.clone(ps))
+		}
 	}
 
 	return packSizes, nil
 }
 
 // FindAllPaginated retrieves pack sizes with pagination from memory
-func (r *PackSizeRepository) FindAllPaginated(page, limit int64) ([]*entities.PackSize, int64, error) {
+func (r *PackSizeRepository) FindAllPaginated(ctx context.Context, page, limit int64) ([]*entities.PackSize, int64, error) {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
+	return r.findAllPaginated(ctx, page, limit)
+}
+
+// findAllPaginated is FindAllPaginated for callers holding the mutex
+func (r *PackSizeRepository) findAllPaginated(ctx context.Context, page, limit int64) ([]*entities.PackSize, int64, error) {
 	// Get all pack sizes
 	packSizes := make([]*entities.PackSize, 0, len(r.packSizes))
 	for _, ps := range r.packSizes {
-		packSizes = append(packSizes, r.clone(ps))
+		if ps.DeletedAt == nil {
+			packSizes = append(packSizes, r.clone(ps))
+		}
 	}
 
 	// Get total count
@@ -93,12 +126,17 @@ func (r *PackSizeRepository) FindAllPaginated(page, limit int64) ([]*entities.Pa
 }
 
 // FindByID retrieves a pack size by ID from memory
-func (r *PackSizeRepository) FindByID(id string) (*entities.PackSize, error) {
+func (r *PackSizeRepository) FindByID(ctx context.Context, id string) (*entities.PackSize, error) {
 	r.mutex.RLock()
 	defer r.mutex.RUnlock()
 
+	return r.findByID(ctx, id)
+}
+
+// findByID is FindByID for callers holding the mutex
+func (r *PackSizeRepository) findByID(ctx context.Context, id string) (*entities.PackSize, error) {
 	packSize, exists := r.packSizes[id]
-	if !exists {
+	if !exists || packSize.DeletedAt != nil {
 		return nil, errors.ErrPackSizeNotFound
 	}
 
@@ -106,46 +144,194 @@ func (r *PackSizeRepository) FindByID(id string) (*entities.PackSize, error) {
 }
 
 // Update updates a pack size in memory
-func (r *PackSizeRepository) Update(packSize *entities.PackSize) (*entities.PackSize, error) {
+func (r *PackSizeRepository) Update(ctx context.Context, packSize *entities.PackSize) (*entities.PackSize, error) {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	_, exists := r.packSizes[packSize.ID]
-	if !exists {
+	return r.update(ctx, packSize)
+}
+
+// update is Update for callers holding the mutex
+func (r *PackSizeRepository) update(ctx context.Context, packSize *entities.PackSize) (*entities.PackSize, error) {
+	existing, exists := r.packSizes[packSize.ID]
+	if !exists || existing.DeletedAt != nil {
 		return nil, errors.ErrPackSizeNotFound
 	}
+	if existing.Version != packSize.Version {
+		return nil, fmt.Errorf("%w: version %d, not %d", errors.ErrVersionMismatch, existing.Version, packSize.Version)
+	}
+	if r.hasSize(packSize.Size, packSize.ID) {
+		return nil, fmt.Errorf("%w: size %d", errors.ErrDuplicatePackSize, packSize.Size)
+	}
 
-	// Update timestamp
+	// Update version and timestamp
+	packSize.Version++
 	packSize.UpdatedAt = time.Now()
 
 	// Store in memory
 	r.packSizes[packSize.ID] = packSize
+	r.record(ctx, entities.AuditActionUpdated, existing, packSize)
 
 	// Return a copy to avoid mutation
 	return r.clone(packSize), nil
 }
 
-// Delete deletes a pack size from memory
-func (r *PackSizeRepository) Delete(id string) error {
+// Delete moves a pack size to the trash
+func (r *PackSizeRepository) Delete(ctx context.Context, id string, version int64) error {
 	r.mutex.Lock()
 	defer r.mutex.Unlock()
 
-	_, exists := r.packSizes[id]
-	if !exists {
+	return r.delete(ctx, id, version)
+}
+
+// delete is Delete for callers holding the mutex
+func (r *PackSizeRepository) delete(ctx context.Context, id string, version int64) error {
+	packSize, exists := r.packSizes[id]
+	if !exists || packSize.DeletedAt != nil {
 		return errors.ErrPackSizeNotFound
 	}
+	if version != 0 && packSize.Version != version {
+		return fmt.Errorf("%w: version %d, not %d", errors.ErrVersionMismatch, packSize.Version, version)
+	}
 
-	delete(r.packSizes, id)
+	before := r.clone(packSize)
+	now := time.Now()
+	packSize.DeletedAt = &now
+	packSize.Version++
+	r.record(ctx, entities.AuditActionDeleted, before, nil)
 
 	return nil
 }
 
+// FindDeleted retrieves the pack sizes in the trash, most recently deleted first
+func (r *PackSizeRepository) FindDeleted(ctx context.Context) ([]*entities.PackSize, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.findDeleted(ctx)
+}
+
+// findDeleted is FindDeleted for callers holding the mutex
+func (r *PackSizeRepository) findDeleted(ctx context.Context) ([]*entities.PackSize, error) {
+	packSizes := make([]*entities.PackSize, 0)
+	for _, ps := range r.packSizes {
+		if ps.DeletedAt != nil {
+			packSizes = append(packSizes, r.clone(ps))
+		}
+	}
+	sort.Slice(packSizes, func(i, j int) bool {
+		return packSizes[i].DeletedAt.After(*packSizes[j].DeletedAt)
+	})
+
+	return packSizes, nil
+}
+
+// Restore takes a pack size out of the trash
+func (r *PackSizeRepository) Restore(ctx context.Context, id string) (*entities.PackSize, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.restore(ctx, id)
+}
+
+// restore is Restore for callers holding the mutex
+func (r *PackSizeRepository) restore(ctx context.Context, id string) (*entities.PackSize, error) {
+	packSize, exists := r.packSizes[id]
+	if !exists || packSize.DeletedAt == nil {
+		return nil, errors.ErrPackSizeNotFound
+	}
+	if r.hasSize(packSize.Size, packSize.ID) {
+		return nil, fmt.Errorf("%w: size %d", errors.ErrDuplicatePackSize, packSize.Size)
+	}
+
+	before := r.clone(packSize)
+	packSize.DeletedAt = nil
+	packSize.Version++
+	packSize.UpdatedAt = time.Now()
+	r.record(ctx, entities.AuditActionRestored, before, packSize)
+
+	return r.clone(packSize), nil
+}
+
+// PurgeDeleted permanently removes the pack sizes deleted before the given time, recording an
+// audit entry of each of them
+func (r *PackSizeRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	return r.purgeDeleted(ctx, before)
+}
+
+// purgeDeleted is PurgeDeleted for callers holding the mutex
+func (r *PackSizeRepository) purgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	for id, ps := range r.packSizes {
+		if ps.DeletedAt != nil && ps.DeletedAt.Before(before) {
+			delete(r.packSizes, id)
+			r.record(ctx, entities.AuditActionPurged, ps, nil)
+			purged++
+		}
+	}
+
+	return purged, nil
+}
+
+// record appends an audit entry of a change attributed to the request info of the context.
+// The caller must hold the mutex.
+func (r *PackSizeRepository) record(ctx context.Context, action entities.AuditAction, before, after *entities.PackSize) {
+	if before != nil {
+		before = r.clone(before)
+	}
+	if after != nil {
+		after = r.clone(after)
+	}
+
+	info := types.RequestInfoFromContext(ctx)
+	entry := entities.NewPackSizeAuditEntry(action, before, after, info.ClaimedActor, info.RequestID)
+	entry.ID = uuid.New().String()
+	r.audit = append(r.audit, entry)
+}
+
+// hasSize returns whether a pack size outside the trash other than the one with the given ID
+// has the size. The caller must hold the mutex.
+func (r *PackSizeRepository) hasSize(size int, id string) bool {
+	for _, ps := range r.packSizes {
+		if ps.Size == size && ps.ID != id && ps.DeletedAt == nil {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Helper method to clone a pack size to avoid mutation
 func (r *PackSizeRepository) clone(packSize *entities.PackSize) *entities.PackSize {
 	return &entities.PackSize{
-		ID:        packSize.ID,
-		Size:      packSize.Size,
-		CreatedAt: packSize.CreatedAt,
-		UpdatedAt: packSize.UpdatedAt,
+		ID:             packSize.ID,
+		Version:        packSize.Version,
+		Size:           packSize.Size,
+		Name:           packSize.Name,
+		SKU:            packSize.SKU,
+		Tags:           slices.Clone(packSize.Tags),
+		Active:         packSize.Active,
+		Weight:         packSize.Weight,
+		MaterialWeight: packSize.MaterialWeight,
+		EmissionFactor: packSize.EmissionFactor,
+		Dimensions:     packSize.Dimensions,
+		ValidFrom:      cloneTime(packSize.ValidFrom),
+		ValidTo:        cloneTime(packSize.ValidTo),
+		CreatedAt:      packSize.CreatedAt,
+		UpdatedAt:      packSize.UpdatedAt,
+		DeletedAt:      cloneTime(packSize.DeletedAt),
 	}
 }
+
+// cloneTime copies an optional time so the copy does not share the original's memory
+func cloneTime(t *time.Time) *time.Time {
+	if t == nil {
+		return nil
+	}
+
+	copied := *t
+	return &copied
+}
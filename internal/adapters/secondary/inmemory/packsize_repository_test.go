@@ -1,6 +1,7 @@
 package inmemory
 
 import (
+	"context"
 	"testing"
 	"time"
 
@@ -19,7 +20,7 @@ func TestPackSizeRepository_Create(t *testing.T) {
 	require.NoError(t, err)
 
 	// Save to repository
-	createdPackSize, err := repo.Create(packSize)
+	createdPackSize, err := repo.Create(context.Background(), packSize)
 	require.NoError(t, err)
 	assert.NotEmpty(t, createdPackSize.ID)
 	assert.Equal(t, packSize.Size, createdPackSize.Size)
@@ -27,7 +28,7 @@ func TestPackSizeRepository_Create(t *testing.T) {
 	assert.False(t, createdPackSize.UpdatedAt.IsZero())
 
 	// Verify it was stored
-	storedPackSize, err := repo.FindByID(createdPackSize.ID)
+	storedPackSize, err := repo.FindByID(context.Background(), createdPackSize.ID)
 	require.NoError(t, err)
 	assert.Equal(t, createdPackSize.ID, storedPackSize.ID)
 	assert.Equal(t, createdPackSize.Size, storedPackSize.Size)
@@ -41,15 +42,15 @@ func TestPackSizeRepository_FindAll(t *testing.T) {
 	ps2, _ := entities.NewPackSize(250)
 	ps3, _ := entities.NewPackSize(500)
 
-	_, err := repo.Create(ps1)
+	_, err := repo.Create(context.Background(), ps1)
 	require.NoError(t, err)
-	_, err = repo.Create(ps2)
+	_, err = repo.Create(context.Background(), ps2)
 	require.NoError(t, err)
-	_, err = repo.Create(ps3)
+	_, err = repo.Create(context.Background(), ps3)
 	require.NoError(t, err)
 
 	// Find all
-	packSizes, err := repo.FindAll()
+	packSizes, err := repo.FindAll(context.Background())
 	require.NoError(t, err)
 	assert.Len(t, packSizes, 3)
 
@@ -69,30 +70,30 @@ func TestPackSizeRepository_FindAllPaginated(t *testing.T) {
 	// Create some pack sizes
 	for i := 1; i <= 10; i++ {
 		ps, _ := entities.NewPackSize(i * 100)
-		_, err := repo.Create(ps)
+		_, err := repo.Create(context.Background(), ps)
 		require.NoError(t, err)
 	}
 
 	// Test first page
-	packSizes, total, err := repo.FindAllPaginated(1, 3)
+	packSizes, total, err := repo.FindAllPaginated(context.Background(), 1, 3)
 	require.NoError(t, err)
 	assert.Len(t, packSizes, 3)
 	assert.Equal(t, int64(10), total)
 
 	// Test second page
-	packSizes, total, err = repo.FindAllPaginated(2, 3)
+	packSizes, total, err = repo.FindAllPaginated(context.Background(), 2, 3)
 	require.NoError(t, err)
 	assert.Len(t, packSizes, 3)
 	assert.Equal(t, int64(10), total)
 
 	// Test last page
-	packSizes, total, err = repo.FindAllPaginated(4, 3)
+	packSizes, total, err = repo.FindAllPaginated(context.Background(), 4, 3)
 	require.NoError(t, err)
 	assert.Len(t, packSizes, 1)
 	assert.Equal(t, int64(10), total)
 
 	// Test out of bounds
-	packSizes, total, err = repo.FindAllPaginated(5, 3)
+	packSizes, total, err = repo.FindAllPaginated(context.Background(), 5, 3)
 	require.NoError(t, err)
 	assert.Len(t, packSizes, 0)
 	assert.Equal(t, int64(10), total)
@@ -103,17 +104,17 @@ func TestPackSizeRepository_FindByID(t *testing.T) {
 
 	// Create a pack size
 	packSize, _ := entities.NewPackSize(100)
-	createdPackSize, err := repo.Create(packSize)
+	createdPackSize, err := repo.Create(context.Background(), packSize)
 	require.NoError(t, err)
 
 	// Find by ID
-	foundPackSize, err := repo.FindByID(createdPackSize.ID)
+	foundPackSize, err := repo.FindByID(context.Background(), createdPackSize.ID)
 	require.NoError(t, err)
 	assert.Equal(t, createdPackSize.ID, foundPackSize.ID)
 	assert.Equal(t, createdPackSize.Size, foundPackSize.Size)
 
 	// Find non-existent ID
-	_, err = repo.FindByID("non-existent-id")
+	_, err = repo.FindByID(context.Background(), "non-existent-id")
 	assert.ErrorIs(t, err, errors.ErrPackSizeNotFound)
 }
 
@@ -122,56 +123,181 @@ func TestPackSizeRepository_Update(t *testing.T) {
 
 	// Create a pack size
 	packSize, _ := entities.NewPackSize(100)
-	createdPackSize, err := repo.Create(packSize)
+	createdPackSize, err := repo.Create(context.Background(), packSize)
 	require.NoError(t, err)
 
+	assert.Equal(t, int64(1), createdPackSize.Version)
+
 	// Update the pack size
 	createdPackSize.Size = 200
-	updatedPackSize, err := repo.Update(createdPackSize)
+	updatedPackSize, err := repo.Update(context.Background(), createdPackSize)
 	require.NoError(t, err)
 	assert.Equal(t, createdPackSize.ID, updatedPackSize.ID)
 	assert.Equal(t, 200, updatedPackSize.Size)
+	assert.Equal(t, int64(2), updatedPackSize.Version)
 
 	// Verify it was updated
-	foundPackSize, err := repo.FindByID(createdPackSize.ID)
+	foundPackSize, err := repo.FindByID(context.Background(), createdPackSize.ID)
 	require.NoError(t, err)
 	assert.Equal(t, 200, foundPackSize.Size)
 
 	// Update non-existent ID
 	nonExistentPackSize := &entities.PackSize{ID: "non-existent-id", Size: 300}
-	_, err = repo.Update(nonExistentPackSize)
+	_, err = repo.Update(context.Background(), nonExistentPackSize)
 	assert.ErrorIs(t, err, errors.ErrPackSizeNotFound)
 }
 
+func TestPackSizeRepository_DuplicateSize(t *testing.T) {
+	repo := NewPackSizeRepository()
+
+	// Create two pack sizes
+	small, _ := entities.NewPackSize(250)
+	small, err := repo.Create(context.Background(), small)
+	require.NoError(t, err)
+	large, _ := entities.NewPackSize(500)
+	large, err = repo.Create(context.Background(), large)
+	require.NoError(t, err)
+
+	// Create a second pack size of an existing size
+	duplicate, _ := entities.NewPackSize(250)
+	_, err = repo.Create(context.Background(), duplicate)
+	assert.ErrorIs(t, err, errors.ErrDuplicatePackSize)
+
+	// Update a pack size to the size of another
+	large.Size = 250
+	_, err = repo.Update(context.Background(), large)
+	assert.ErrorIs(t, err, errors.ErrDuplicatePackSize)
+
+	// Keeping its own size is not a duplicate
+	small.Weight = 1.5
+	_, err = repo.Update(context.Background(), small)
+	assert.NoError(t, err)
+
+	// The size is free again once deleted
+	require.NoError(t, repo.Delete(context.Background(), small.ID, 0))
+	_, err = repo.Create(context.Background(), duplicate)
+	assert.NoError(t, err)
+
+	packSizes, err := repo.FindAll(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, packSizes, 2)
+}
+
 func TestPackSizeRepository_Delete(t *testing.T) {
 	repo := NewPackSizeRepository()
 
 	// Create a pack size
 	packSize, _ := entities.NewPackSize(100)
-	createdPackSize, err := repo.Create(packSize)
+	createdPackSize, err := repo.Create(context.Background(), packSize)
 	require.NoError(t, err)
 
 	// Delete the pack size
-	err = repo.Delete(createdPackSize.ID)
+	err = repo.Delete(context.Background(), createdPackSize.ID, 0)
 	require.NoError(t, err)
 
 	// Verify it was deleted
-	_, err = repo.FindByID(createdPackSize.ID)
+	_, err = repo.FindByID(context.Background(), createdPackSize.ID)
 	assert.ErrorIs(t, err, errors.ErrPackSizeNotFound)
 
 	// Delete non-existent ID
-	err = repo.Delete("non-existent-id")
+	err = repo.Delete(context.Background(), "non-existent-id", 0)
 	assert.ErrorIs(t, err, errors.ErrPackSizeNotFound)
 }
 
+func TestPackSizeRepository_Trash(t *testing.T) {
+	repo := NewPackSizeRepository()
+
+	// Create and delete a pack size
+	packSize, _ := entities.NewPackSize(250)
+	packSize, err := repo.Create(context.Background(), packSize)
+	require.NoError(t, err)
+	require.NoError(t, repo.Delete(context.Background(), packSize.ID, 0))
+
+	// It is hidden from the regular queries and cannot be deleted twice
+	packSizes, err := repo.FindAll(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, packSizes)
+	_, err = repo.FindByID(context.Background(), packSize.ID)
+	assert.ErrorIs(t, err, errors.ErrPackSizeNotFound)
+	assert.ErrorIs(t, repo.Delete(context.Background(), packSize.ID, 0), errors.ErrPackSizeNotFound)
+
+	// It is listed in the trash with its deletion time
+	deleted, err := repo.FindDeleted(context.Background())
+	require.NoError(t, err)
+	require.Len(t, deleted, 1)
+	assert.Equal(t, packSize.ID, deleted[0].ID)
+	assert.NotNil(t, deleted[0].DeletedAt)
+
+	// Restoring fails while another pack size has the size
+	other, _ := entities.NewPackSize(250)
+	other, err = repo.Create(context.Background(), other)
+	require.NoError(t, err)
+	_, err = repo.Restore(context.Background(), packSize.ID)
+	assert.ErrorIs(t, err, errors.ErrDuplicatePackSize)
+
+	// Restoring succeeds once the size is free again
+	require.NoError(t, repo.Delete(context.Background(), other.ID, 0))
+	restored, err := repo.Restore(context.Background(), packSize.ID)
+	require.NoError(t, err)
+	assert.Nil(t, restored.DeletedAt)
+	_, err = repo.FindByID(context.Background(), packSize.ID)
+	assert.NoError(t, err)
+
+	// Only pack sizes in the trash can be restored
+	_, err = repo.Restore(context.Background(), packSize.ID)
+	assert.ErrorIs(t, err, errors.ErrPackSizeNotFound)
+}
+
+func TestPackSizeRepository_PurgeDeleted(t *testing.T) {
+	repo := NewPackSizeRepository()
+
+	// Create a pack size in the trash and one in use
+	deleted, _ := entities.NewPackSize(250)
+	deleted, err := repo.Create(context.Background(), deleted)
+	require.NoError(t, err)
+	require.NoError(t, repo.Delete(context.Background(), deleted.ID, 0))
+	kept, _ := entities.NewPackSize(500)
+	_, err = repo.Create(context.Background(), kept)
+	require.NoError(t, err)
+
+	// Nothing was deleted before an hour ago
+	purged, err := repo.PurgeDeleted(context.Background(), time.Now().Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), purged)
+
+	// Purging up to now removes the deleted pack size only
+	purged, err = repo.PurgeDeleted(context.Background(), time.Now().Add(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), purged)
+
+	trash, err := repo.FindDeleted(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, trash)
+	packSizes, err := repo.FindAll(context.Background())
+	require.NoError(t, err)
+	assert.Len(t, packSizes, 1)
+
+	// The purge is the last entry of the history of the pack size
+	entries, err := NewPackSizeAuditRepository(repo).FindByPackSizeID(context.Background(), deleted.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+	assert.Equal(t, entities.AuditActionPurged, entries[2].Action)
+	assert.Equal(t, 250, entries[2].Before.Size)
+	assert.Nil(t, entries[2].After)
+}
+
 func TestPackSizeRepository_Clone(t *testing.T) {
 	repo := NewPackSizeRepository()
 
 	// Create a pack size
 	now := time.Now()
+	validFrom := now.Add(time.Hour)
 	packSize := &entities.PackSize{
 		ID:        "test-id",
 		Size:      100,
+		Tags:      []string{"fragile"},
+		Active:    true,
+		ValidFrom: &validFrom,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}
@@ -187,5 +313,47 @@ func TestPackSizeRepository_Clone(t *testing.T) {
 
 	// Modify the original and verify the clone is unchanged
 	packSize.Size = 200
+	packSize.Tags[0] = "bulk"
+	*packSize.ValidFrom = now
 	assert.Equal(t, 100, clonedPackSize.Size)
+	assert.Equal(t, []string{"fragile"}, clonedPackSize.Tags)
+	assert.Equal(t, now.Add(time.Hour), *clonedPackSize.ValidFrom)
+	assert.True(t, clonedPackSize.Active)
+}
+
+func TestPackSizeRepository_VersionMismatch(t *testing.T) {
+	repo := NewPackSizeRepository()
+
+	packSize, _ := entities.NewPackSize(100)
+	created, err := repo.Create(context.Background(), packSize)
+	require.NoError(t, err)
+
+	// Two requests read the same version
+	first, err := repo.FindByID(context.Background(), created.ID)
+	require.NoError(t, err)
+	second, err := repo.FindByID(context.Background(), created.ID)
+	require.NoError(t, err)
+
+	// The first update wins, the second one is rejected instead of overwriting it
+	first.Size = 200
+	_, err = repo.Update(context.Background(), first)
+	require.NoError(t, err)
+
+	second.Size = 300
+	_, err = repo.Update(context.Background(), second)
+	assert.ErrorIs(t, err, errors.ErrVersionMismatch)
+
+	stored, err := repo.FindByID(context.Background(), created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 200, stored.Size)
+	assert.Equal(t, int64(2), stored.Version)
+
+	// Deleting a stale version is rejected as well
+	assert.ErrorIs(t, repo.Delete(context.Background(), created.ID, 1), errors.ErrVersionMismatch)
+	require.NoError(t, repo.Delete(context.Background(), created.ID, 2))
+
+	// Restoring is a change too
+	restored, err := repo.Restore(context.Background(), created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), restored.Version)
 }
@@ -0,0 +1,75 @@
+package inmemory
+
+import (
+	"context"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// PackSizeAuditRepository is an in-memory implementation of PackSizeAuditRepository reading
+// the audit trail the in-memory pack size repository records
+type PackSizeAuditRepository struct {
+	packSizes *PackSizeRepository
+}
+
+// Ensure PackSizeAuditRepository implements the PackSizeAuditRepository interface
+var _ secondary.PackSizeAuditRepository = (*PackSizeAuditRepository)(nil)
+
+// NewPackSizeAuditRepository creates a new in-memory audit repository of the pack size repository
+func NewPackSizeAuditRepository(packSizes *PackSizeRepository) *PackSizeAuditRepository {
+	return &PackSizeAuditRepository{
+		packSizes: packSizes,
+	}
+}
+
+// FindByPackSizeID retrieves the audit entries of a pack size, oldest first
+func (r *PackSizeAuditRepository) FindByPackSizeID(ctx context.Context, packSizeID string) ([]*entities.PackSizeAuditEntry, error) {
+	r.packSizes.mutex.RLock()
+	defer r.packSizes.mutex.RUnlock()
+
+	entries := make([]*entities.PackSizeAuditEntry, 0)
+	for _, entry := range r.packSizes.audit {
+		if entry.PackSizeID == packSizeID {
+			entries = append(entries, r.clone(entry))
+		}
+	}
+
+	return entries, nil
+}
+
+// FindAllPaginated retrieves a page of the audit entries of every pack size, newest first
+func (r *PackSizeAuditRepository) FindAllPaginated(ctx context.Context, page, limit int64) ([]*entities.PackSizeAuditEntry, int64, error) {
+	r.packSizes.mutex.RLock()
+	defer r.packSizes.mutex.RUnlock()
+
+	// Get total count
+	total := int64(len(r.packSizes.audit))
+
+	// Walk the entries from the newest one
+	start := (page - 1) * limit
+	if start >= total {
+		return []*entities.PackSizeAuditEntry{}, total, nil
+	}
+	end := min(start+limit, total)
+
+	entries := make([]*entities.PackSizeAuditEntry, 0, end-start)
+	for i := start; i < end; i++ {
+		entries = append(entries, r.clone(r.packSizes.audit[total-1-i]))
+	}
+
+	return entries, total, nil
+}
+
+// clone copies an entry so callers cannot change the recorded pack sizes
+func (r *PackSizeAuditRepository) clone(entry *entities.PackSizeAuditEntry) *entities.PackSizeAuditEntry {
+	copied := *entry
+	if entry.Before != nil {
+		copied.Before = r.packSizes.clone(entry.Before)
+	}
+	if entry.After != nil {
+		copied.After = r.packSizes.clone(entry.After)
+	}
+
+	return &copied
+}
@@ -0,0 +1,99 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/shared/types"
+)
+
+func TestPackSizeAuditRepository_FindByPackSizeID(t *testing.T) {
+	packSizes := NewPackSizeRepository()
+	repo := NewPackSizeAuditRepository(packSizes)
+	ctx := types.WithRequestInfo(context.Background(), types.RequestInfo{ClaimedActor: "alice", RequestID: "req-1"})
+
+	// Create, update, delete and restore a pack size
+	packSize, _ := entities.NewPackSize(100)
+	created, err := packSizes.Create(ctx, packSize)
+	require.NoError(t, err)
+
+	toUpdate, err := packSizes.FindByID(ctx, created.ID)
+	require.NoError(t, err)
+	require.NoError(t, toUpdate.Update(200))
+	_, err = packSizes.Update(ctx, toUpdate)
+	require.NoError(t, err)
+
+	require.NoError(t, packSizes.Delete(ctx, created.ID, 0))
+	_, err = packSizes.Restore(ctx, created.ID)
+	require.NoError(t, err)
+
+	// Another pack size has its own history
+	other, _ := entities.NewPackSize(300)
+	_, err = packSizes.Create(ctx, other)
+	require.NoError(t, err)
+
+	entries, err := repo.FindByPackSizeID(context.Background(), created.ID)
+	require.NoError(t, err)
+	require.Len(t, entries, 4)
+
+	assert.Equal(t, entities.AuditActionCreated, entries[0].Action)
+	assert.Nil(t, entries[0].Before)
+	assert.Equal(t, 100, entries[0].After.Size)
+
+	assert.Equal(t, entities.AuditActionUpdated, entries[1].Action)
+	assert.Equal(t, 100, entries[1].Before.Size)
+	assert.Equal(t, 200, entries[1].After.Size)
+
+	assert.Equal(t, entities.AuditActionDeleted, entries[2].Action)
+	assert.Equal(t, 200, entries[2].Before.Size)
+	assert.Nil(t, entries[2].After)
+
+	assert.Equal(t, entities.AuditActionRestored, entries[3].Action)
+	assert.NotNil(t, entries[3].Before.DeletedAt)
+	assert.Nil(t, entries[3].After.DeletedAt)
+
+	for _, entry := range entries {
+		assert.NotEmpty(t, entry.ID)
+		assert.Equal(t, created.ID, entry.PackSizeID)
+		assert.Equal(t, "alice", entry.ClaimedActor)
+		assert.Equal(t, "req-1", entry.RequestID)
+	}
+
+	// The recorded entries cannot be changed through the returned ones
+	entries[0].After.Size = 999
+	entries, err = repo.FindByPackSizeID(context.Background(), created.ID)
+	require.NoError(t, err)
+	assert.Equal(t, 100, entries[0].After.Size)
+}
+
+func TestPackSizeAuditRepository_FindAllPaginated(t *testing.T) {
+	packSizes := NewPackSizeRepository()
+	repo := NewPackSizeAuditRepository(packSizes)
+
+	for _, size := range []int{100, 200, 300} {
+		packSize, _ := entities.NewPackSize(size)
+		_, err := packSizes.Create(context.Background(), packSize)
+		require.NoError(t, err)
+	}
+
+	// Newest first
+	entries, total, err := repo.FindAllPaginated(context.Background(), 1, 2)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), total)
+	require.Len(t, entries, 2)
+	assert.Equal(t, 300, entries[0].After.Size)
+	assert.Equal(t, 200, entries[1].After.Size)
+
+	entries, _, err = repo.FindAllPaginated(context.Background(), 2, 2)
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	assert.Equal(t, 100, entries[0].After.Size)
+
+	entries, _, err = repo.FindAllPaginated(context.Background(), 3, 2)
+	require.NoError(t, err)
+	assert.Empty(t, entries)
+}
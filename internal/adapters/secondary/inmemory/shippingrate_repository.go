@@ -0,0 +1,88 @@
+package inmemory
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// ShippingRateRepository is an in-memory implementation of ShippingRateRepository
+type ShippingRateRepository struct {
+	rates []*entities.ShippingRate
+	mutex sync.RWMutex
+}
+
+// Ensure ShippingRateRepository implements the ShippingRateRepository interface
+var _ secondary.ShippingRateRepository = (*ShippingRateRepository)(nil)
+
+// NewShippingRateRepository creates a new in-memory shipping rate repository
+func NewShippingRateRepository() *ShippingRateRepository {
+	return &ShippingRateRepository{
+		rates: make([]*entities.ShippingRate, 0),
+	}
+}
+
+// ReplaceAll replaces the whole rate table in memory
+func (r *ShippingRateRepository) ReplaceAll(ctx context.Context, rates []*entities.ShippingRate) ([]*entities.ShippingRate, error) {
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	now := time.Now()
+	stored := make([]*entities.ShippingRate, len(rates))
+	for i, rate := range rates {
+		// Generate UUID if not provided
+		if rate.ID == "" {
+			rate.ID = uuid.New().String()
+		}
+		rate.CreatedAt = now
+
+		stored[i] = r.clone(rate)
+	}
+	r.rates = stored
+
+	return r.sorted(), nil
+}
+
+// FindAll retrieves the rate table from memory
+func (r *ShippingRateRepository) FindAll(ctx context.Context) ([]*entities.ShippingRate, error) {
+	r.mutex.RLock()
+	defer r.mutex.RUnlock()
+
+	return r.sorted(), nil
+}
+
+// sorted returns copies of the rates ordered by carrier and price
+func (r *ShippingRateRepository) sorted() []*entities.ShippingRate {
+	rates := make([]*entities.ShippingRate, len(r.rates))
+	for i, rate := range r.rates {
+		rates[i] = r.clone(rate)
+	}
+
+	sort.SliceStable(rates, func(i, j int) bool {
+		if rates[i].Carrier != rates[j].Carrier {
+			return rates[i].Carrier < rates[j].Carrier
+		}
+
+		return rates[i].Price < rates[j].Price
+	})
+
+	return rates
+}
+
+// Helper method to clone a shipping rate to avoid mutation
+func (r *ShippingRateRepository) clone(rate *entities.ShippingRate) *entities.ShippingRate {
+	return &entities.ShippingRate{
+		ID:         rate.ID,
+		Carrier:    rate.Carrier,
+		MaxWeight:  rate.MaxWeight,
+		MaxParcels: rate.MaxParcels,
+		Price:      rate.Price,
+		CreatedAt:  rate.CreatedAt,
+	}
+}
@@ -0,0 +1,44 @@
+package inmemory
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-pack-calculator/internal/domain/entities"
+)
+
+func TestShippingRateRepository_ReplaceAll(t *testing.T) {
+	repo := NewShippingRateRepository()
+
+	ups, _ := entities.NewShippingRate("UPS", 20, 3, 9)
+	dhlExpensive, _ := entities.NewShippingRate("DHL", 30, 5, 15)
+	dhlCheap, _ := entities.NewShippingRate("DHL", 10, 2, 8)
+
+	// Replace the empty table
+	stored, err := repo.ReplaceAll(context.Background(), []*entities.ShippingRate{ups, dhlExpensive, dhlCheap})
+	require.NoError(t, err)
+	require.Len(t, stored, 3)
+	for _, rate := range stored {
+		assert.NotEmpty(t, rate.ID)
+		assert.False(t, rate.CreatedAt.IsZero())
+	}
+
+	// Rates are ordered by carrier and price
+	assert.Equal(t, "DHL", stored[0].Carrier)
+	assert.Equal(t, 8.0, stored[0].Price)
+	assert.Equal(t, "DHL", stored[1].Carrier)
+	assert.Equal(t, "UPS", stored[2].Carrier)
+
+	// Replacing again drops the previous rates
+	gls, _ := entities.NewShippingRate("GLS", 5, 1, 4)
+	_, err = repo.ReplaceAll(context.Background(), []*entities.ShippingRate{gls})
+	require.NoError(t, err)
+
+	rates, err := repo.FindAll(context.Background())
+	require.NoError(t, err)
+	require.Len(t, rates, 1)
+	assert.Equal(t, "GLS", rates[0].Carrier)
+}
@@ -0,0 +1,156 @@
+package inmemory
+
+import (
+	"context"
+	"time"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// TransactionManager is an in-memory implementation of TransactionManager. It holds the lock of
+// the pack size repository for the whole work and puts back the previous pack sizes and outbox
+// when the work fails.
+type TransactionManager struct {
+	packSizes *PackSizeRepository
+}
+
+// Ensure TransactionManager implements the TransactionManager interface
+var _ secondary.TransactionManager = (*TransactionManager)(nil)
+
+// NewTransactionManager creates a new in-memory transaction manager of the pack size repository
+func NewTransactionManager(packSizes *PackSizeRepository) *TransactionManager {
+	return &TransactionManager{
+		packSizes: packSizes,
+	}
+}
+
+// WithinTransaction runs the work with the repository locked, rolling back its changes when it
+// returns an error
+func (m *TransactionManager) WithinTransaction(ctx context.Context, work func(tx secondary.Transaction) error) error {
+	r := m.packSizes
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+
+	// Copy the stored pack sizes, the work may change them in place
+	packSizes := make(map[string]*entities.PackSize, len(r.packSizes))
+	for id, ps := range r.packSizes {
+		packSizes[id] = r.clone(ps)
+	}
+	audited := len(r.audit)
+	queued := len(r.outbox)
+
+	if err := work(&transaction{packSizes: &lockedPackSizeRepository{r}, outbox: &lockedOutboxRepository{r}}); err != nil {
+		r.packSizes = packSizes
+		r.audit = r.audit[:audited]
+		r.outbox = r.outbox[:queued]
+
+		return err
+	}
+
+	return nil
+}
+
+// transaction gives the repositories seen by the work of a transaction
+type transaction struct {
+	packSizes *lockedPackSizeRepository
+	outbox    *lockedOutboxRepository
+}
+
+// PackSizes returns the pack size repository of the transaction
+func (t *transaction) PackSizes() secondary.PackSizeRepository {
+	return t.packSizes
+}
+
+// Outbox returns the outbox repository of the transaction
+func (t *transaction) Outbox() secondary.OutboxRepository {
+	return t.outbox
+}
+
+// lockedPackSizeRepository is the pack size repository seen by the work of a transaction, which
+// already holds the mutex
+type lockedPackSizeRepository struct {
+	r *PackSizeRepository
+}
+
+// Ensure lockedPackSizeRepository implements the PackSizeRepository interface
+var _ secondary.PackSizeRepository = (*lockedPackSizeRepository)(nil)
+
+// Create creates a new pack size in memory
+func (l *lockedPackSizeRepository) Create(ctx context.Context, packSize *entities.PackSize) (*entities.PackSize, error) {
+	return l.r.create(ctx, packSize)
+}
+
+// FindAll retrieves all pack sizes from memory
+func (l *lockedPackSizeRepository) FindAll(ctx context.Context) ([]*entities.PackSize, error) {
+	return l.r.findAll(ctx)
+}
+
+// FindAllPaginated retrieves pack sizes with pagination from memory
+func (l *lockedPackSizeRepository) FindAllPaginated(ctx context.Context, page, limit int64) ([]*entities.PackSize, int64, error) {
+	return l.r.findAllPaginated(ctx, page, limit)
+}
+
+// FindByID retrieves a pack size by ID from memory
+func (l *lockedPackSizeRepository) FindByID(ctx context.Context, id string) (*entities.PackSize, error) {
+	return l.r.findByID(ctx, id)
+}
+
+// Update updates a pack size in memory
+func (l *lockedPackSizeRepository) Update(ctx context.Context, packSize *entities.PackSize) (*entities.PackSize, error) {
+	return l.r.update(ctx, packSize)
+}
+
+// Delete moves a pack size to the trash
+func (l *lockedPackSizeRepository) Delete(ctx context.Context, id string, version int64) error {
+	return l.r.delete(ctx, id, version)
+}
+
+// FindDeleted retrieves the pack sizes in the trash, most recently deleted first
+func (l *lockedPackSizeRepository) FindDeleted(ctx context.Context) ([]*entities.PackSize, error) {
+	return l.r.findDeleted(ctx)
+}
+
+// Restore takes a pack size out of the trash
+func (l *lockedPackSizeRepository) Restore(ctx context.Context, id string) (*entities.PackSize, error) {
+	return l.r.restore(ctx, id)
+}
+
+// PurgeDeleted permanently removes the pack sizes deleted before the given time
+func (l *lockedPackSizeRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	return l.r.purgeDeleted(ctx, before)
+}
+
+// lockedOutboxRepository is the outbox repository seen by the work of a transaction, which
+// already holds the mutex of the pack size repository
+type lockedOutboxRepository struct {
+	r *PackSizeRepository
+}
+
+// Ensure lockedOutboxRepository implements the OutboxRepository interface
+var _ secondary.OutboxRepository = (*lockedOutboxRepository)(nil)
+
+// Add adds events to the outbox, due for delivery now
+func (l *lockedOutboxRepository) Add(ctx context.Context, events ...*entities.PackSizeEvent) error {
+	return l.r.addEvents(ctx, events)
+}
+
+// ClaimPending retrieves the entries due for delivery, oldest first, hiding them for the lease
+func (l *lockedOutboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*entities.OutboxEntry, error) {
+	return l.r.claimPending(ctx, limit, lease)
+}
+
+// MarkDelivered removes a delivered entry from the outbox
+func (l *lockedOutboxRepository) MarkDelivered(ctx context.Context, id string) error {
+	return l.r.markDelivered(ctx, id)
+}
+
+// MarkFailed records a failed delivery of an entry, delivered again from the given time
+func (l *lockedOutboxRepository) MarkFailed(ctx context.Context, id string, reason string, nextAttemptAt time.Time) error {
+	return l.r.markFailed(ctx, id, reason, nextAttemptAt)
+}
+
+// MarkDead records the last failed delivery of an entry, which is never delivered again
+func (l *lockedOutboxRepository) MarkDead(ctx context.Context, id string, reason string) error {
+	return l.r.markDead(ctx, id, reason)
+}
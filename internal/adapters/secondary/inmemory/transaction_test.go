@@ -0,0 +1,120 @@
+package inmemory
+
+import (
+	"context"
+	stderr "errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+func TestTransactionManager_WithinTransaction(t *testing.T) {
+	packSizes := NewPackSizeRepository()
+	transactions := NewTransactionManager(packSizes)
+	ctx := context.Background()
+
+	existing, _ := entities.NewPackSize(250)
+	existing, err := packSizes.Create(ctx, existing)
+	require.NoError(t, err)
+
+	// A successful work keeps every change
+	err = transactions.WithinTransaction(ctx, func(tx secondary.Transaction) error {
+		repo := tx.PackSizes()
+		packSize, _ := entities.NewPackSize(500)
+		if _, err := repo.Create(ctx, packSize); err != nil {
+			return err
+		}
+		return repo.Delete(ctx, existing.ID, existing.Version)
+	})
+	require.NoError(t, err)
+
+	all, err := packSizes.FindAll(ctx)
+	require.NoError(t, err)
+	if assert.Len(t, all, 1) {
+		assert.Equal(t, 500, all[0].Size)
+	}
+	assert.Len(t, packSizes.audit, 3)
+}
+
+func TestTransactionManager_WithinTransactionRollsBack(t *testing.T) {
+	packSizes := NewPackSizeRepository()
+	transactions := NewTransactionManager(packSizes)
+	ctx := context.Background()
+
+	existing, _ := entities.NewPackSize(250)
+	existing, err := packSizes.Create(ctx, existing)
+	require.NoError(t, err)
+
+	// A failing work leaves the pack sizes and the audit trail as they were
+	errFailed := stderr.New("failed")
+	err = transactions.WithinTransaction(ctx, func(tx secondary.Transaction) error {
+		repo := tx.PackSizes()
+		packSize, _ := entities.NewPackSize(500)
+		if _, err := repo.Create(ctx, packSize); err != nil {
+			return err
+		}
+
+		toUpdate, err := repo.FindByID(ctx, existing.ID)
+		if err != nil {
+			return err
+		}
+		toUpdate.Size = 300
+		if _, err := repo.Update(ctx, toUpdate); err != nil {
+			return err
+		}
+
+		return errFailed
+	})
+	assert.ErrorIs(t, err, errFailed)
+
+	all, err := packSizes.FindAll(ctx)
+	require.NoError(t, err)
+	if assert.Len(t, all, 1) {
+		assert.Equal(t, 250, all[0].Size)
+		assert.Equal(t, existing.Version, all[0].Version)
+	}
+	assert.Len(t, packSizes.audit, 1)
+}
+
+func TestTransactionManager_WithinTransactionSerializes(t *testing.T) {
+	packSizes := NewPackSizeRepository()
+	transactions := NewTransactionManager(packSizes)
+	ctx := context.Background()
+
+	packSize, _ := entities.NewPackSize(250)
+	packSize, err := packSizes.Create(ctx, packSize)
+	require.NoError(t, err)
+
+	// Concurrent deletions checking the pack size exists first: only one finds it
+	const workers = 10
+	var wg sync.WaitGroup
+	var deleted atomic.Int32
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			err := transactions.WithinTransaction(ctx, func(tx secondary.Transaction) error {
+				if _, err := tx.PackSizes().FindByID(ctx, packSize.ID); err != nil {
+					return err
+				}
+				return tx.PackSizes().Delete(ctx, packSize.ID, 0)
+			})
+			if err == nil {
+				deleted.Add(1)
+			} else {
+				assert.ErrorIs(t, err, errors.ErrPackSizeNotFound)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(1), deleted.Load())
+}
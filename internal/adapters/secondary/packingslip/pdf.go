@@ -0,0 +1,104 @@
+package packingslip
+
+import (
+	"bytes"
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/go-pdf/fpdf"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// Layout of the pick list table in millimetres
+const (
+	pdfColumnWidth = 50
+	pdfRowHeight   = 8
+	pdfLineHeight  = 6
+)
+
+// PDFRenderer renders packing slips as PDF documents
+type PDFRenderer struct{}
+
+// Ensure PDFRenderer implements the PackingSlipRenderer interface
+var _ secondary.PackingSlipRenderer = (*PDFRenderer)(nil)
+
+// NewPDFRenderer creates a new PDF packing slip renderer
+func NewPDFRenderer() *PDFRenderer {
+	return &PDFRenderer{}
+}
+
+// Format returns the document format produced by the renderer
+func (r *PDFRenderer) Format() entities.DocumentFormat {
+	return entities.DocumentFormatPDF
+}
+
+// Render renders the packing slip as a PDF document
+func (r *PDFRenderer) Render(ctx context.Context, slip *entities.PackingSlip) (*entities.Document, error) {
+	pdf := fpdf.New("P", "mm", "A4", "")
+	pdf.SetTitle("Packing slip "+slip.CalculationID, false)
+	pdf.SetCreationDate(slip.CalculatedAt)
+	pdf.AddPage()
+
+	// Header
+	pdf.SetFont("Helvetica", "B", 18)
+	pdf.Cell(0, 10, "Packing Slip")
+	pdf.Ln(12)
+
+	pdf.SetFont("Helvetica", "", 10)
+	pdf.Cell(0, pdfLineHeight, "Calculation: "+slip.CalculationID)
+	pdf.Ln(pdfLineHeight)
+	pdf.Cell(0, pdfLineHeight, "Calculated at: "+slip.CalculatedAt.UTC().Format(time.RFC3339))
+	pdf.Ln(pdfLineHeight * 2)
+
+	// Pick list table
+	pdf.SetFont("Helvetica", "B", 12)
+	pdf.Cell(0, pdfRowHeight, "Pick List")
+	pdf.Ln(pdfRowHeight)
+
+	pdf.SetFont("Helvetica", "B", 10)
+	for _, header := range []string{"Pack size", "Quantity", "Items"} {
+		pdf.CellFormat(pdfColumnWidth, pdfRowHeight, header, "1", 0, "C", false, 0, "")
+	}
+	pdf.Ln(-1)
+
+	pdf.SetFont("Helvetica", "", 10)
+	for _, line := range slip.Lines {
+		pdf.CellFormat(pdfColumnWidth, pdfRowHeight, strconv.Itoa(line.PackSize), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(pdfColumnWidth, pdfRowHeight, strconv.Itoa(line.Quantity), "1", 0, "R", false, 0, "")
+		pdf.CellFormat(pdfColumnWidth, pdfRowHeight, strconv.Itoa(line.Items), "1", 0, "R", false, 0, "")
+		pdf.Ln(-1)
+	}
+	pdf.Ln(pdfLineHeight)
+
+	// Totals
+	totals := []struct {
+		label string
+		value int
+	}{
+		{"Items ordered", slip.ItemsOrdered},
+		{"Total items", slip.TotalItems},
+		{"Total packs", slip.TotalPacks},
+		{"Overshoot", slip.Overshoot},
+	}
+	for _, total := range totals {
+		pdf.SetFont("Helvetica", "B", 10)
+		pdf.CellFormat(pdfColumnWidth, pdfLineHeight, total.label+":", "", 0, "L", false, 0, "")
+		pdf.SetFont("Helvetica", "", 10)
+		pdf.CellFormat(pdfColumnWidth, pdfLineHeight, strconv.Itoa(total.value), "", 0, "R", false, 0, "")
+		pdf.Ln(pdfLineHeight)
+	}
+
+	var buf bytes.Buffer
+	if err := pdf.Output(&buf); err != nil {
+		return nil, err
+	}
+
+	return &entities.Document{
+		Filename:    filename(slip, entities.DocumentFormatPDF),
+		ContentType: "application/pdf",
+		Content:     buf.Bytes(),
+	}, nil
+}
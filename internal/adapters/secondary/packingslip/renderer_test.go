@@ -0,0 +1,53 @@
+package packingslip
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-pack-calculator/internal/domain/entities"
+)
+
+func testPackingSlip() *entities.PackingSlip {
+	result := entities.NewCalculationResult(12001, map[int]int{5000: 2, 2000: 1, 250: 1})
+	result.ID = "calc-id"
+	result.CreatedAt = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	return entities.NewPackingSlip(result)
+}
+
+func TestTextRenderer_Render(t *testing.T) {
+	renderer := NewTextRenderer()
+	assert.Equal(t, entities.DocumentFormatText, renderer.Format())
+
+	doc, err := renderer.Render(context.Background(), testPackingSlip())
+	require.NoError(t, err)
+	assert.Equal(t, "packing-slip-calc-id.txt", doc.Filename)
+	assert.Equal(t, "text/plain; charset=utf-8", doc.ContentType)
+
+	content := string(doc.Content)
+	assert.Contains(t, content, "Calculation: calc-id")
+	assert.Contains(t, content, "Items ordered: 12001")
+	assert.Contains(t, content, "Total items:   12250")
+	assert.Contains(t, content, "Total packs:   4")
+	assert.Contains(t, content, "Overshoot:     249")
+
+	// Pack sizes are listed largest first
+	assert.Less(t, bytes.Index(doc.Content, []byte("5000")), bytes.Index(doc.Content, []byte("2000")))
+	assert.Less(t, bytes.Index(doc.Content, []byte("2000")), bytes.Index(doc.Content, []byte(" 250")))
+}
+
+func TestPDFRenderer_Render(t *testing.T) {
+	renderer := NewPDFRenderer()
+	assert.Equal(t, entities.DocumentFormatPDF, renderer.Format())
+
+	doc, err := renderer.Render(context.Background(), testPackingSlip())
+	require.NoError(t, err)
+	assert.Equal(t, "packing-slip-calc-id.pdf", doc.Filename)
+	assert.Equal(t, "application/pdf", doc.ContentType)
+	assert.True(t, bytes.HasPrefix(doc.Content, []byte("%PDF-")))
+}
@@ -0,0 +1,66 @@
+package packingslip
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"text/tabwriter"
+	"time"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// TextRenderer renders packing slips as plain text
+type TextRenderer struct{}
+
+// Ensure TextRenderer implements the PackingSlipRenderer interface
+var _ secondary.PackingSlipRenderer = (*TextRenderer)(nil)
+
+// NewTextRenderer creates a new plain text packing slip renderer
+func NewTextRenderer() *TextRenderer {
+	return &TextRenderer{}
+}
+
+// Format returns the document format produced by the renderer
+func (r *TextRenderer) Format() entities.DocumentFormat {
+	return entities.DocumentFormatText
+}
+
+// Render renders the packing slip as plain text
+func (r *TextRenderer) Render(ctx context.Context, slip *entities.PackingSlip) (*entities.Document, error) {
+	var buf bytes.Buffer
+
+	fmt.Fprintln(&buf, "PACKING SLIP")
+	fmt.Fprintf(&buf, "Calculation: %s\n", slip.CalculationID)
+	fmt.Fprintf(&buf, "Calculated at: %s\n", slip.CalculatedAt.UTC().Format(time.RFC3339))
+	fmt.Fprintln(&buf)
+	fmt.Fprintln(&buf, "PICK LIST")
+
+	// Align the pick list columns to the right
+	w := tabwriter.NewWriter(&buf, 0, 0, 4, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(w, "Pack size\tQuantity\tItems\t")
+	for _, line := range slip.Lines {
+		fmt.Fprintf(w, "%d\t%d\t%d\t\n", line.PackSize, line.Quantity, line.Items)
+	}
+	if err := w.Flush(); err != nil {
+		return nil, err
+	}
+
+	fmt.Fprintln(&buf)
+	fmt.Fprintf(&buf, "Items ordered: %d\n", slip.ItemsOrdered)
+	fmt.Fprintf(&buf, "Total items:   %d\n", slip.TotalItems)
+	fmt.Fprintf(&buf, "Total packs:   %d\n", slip.TotalPacks)
+	fmt.Fprintf(&buf, "Overshoot:     %d\n", slip.Overshoot)
+
+	return &entities.Document{
+		Filename:    filename(slip, entities.DocumentFormatText),
+		ContentType: "text/plain; charset=utf-8",
+		Content:     buf.Bytes(),
+	}, nil
+}
+
+// filename builds the download filename of a packing slip
+func filename(slip *entities.PackingSlip, format entities.DocumentFormat) string {
+	return fmt.Sprintf("packing-slip-%s.%s", slip.CalculationID, format)
+}
@@ -0,0 +1,127 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	stderr "errors"
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// CalculationModel is the GORM model for calculation results
+type CalculationModel struct {
+	ID                string `gorm:"primaryKey"`
+	ItemsOrdered      int
+	TotalItems        int
+	Packs             map[int]int `gorm:"type:jsonb;serializer:json"`
+	TotalWeight       float64
+	ShippingEstimates []entities.ShippingEstimate `gorm:"type:jsonb;serializer:json"`
+	MaterialWeight    float64
+	Emissions         float64
+	CreatedAt         time.Time
+}
+
+// TableName specifies the table name for the model
+func (CalculationModel) TableName() string {
+	return "calculations"
+}
+
+// CalculationRepository is the PostgreSQL implementation of CalculationRepository
+type CalculationRepository struct {
+	db *gorm.DB
+}
+
+// Ensure CalculationRepository implements the CalculationRepository interface
+var _ secondary.CalculationRepository = (*CalculationRepository)(nil)
+
+// NewCalculationRepository creates a new PostgreSQL calculation repository
+func NewCalculationRepository(db *gorm.DB) *CalculationRepository {
+	return &CalculationRepository{
+		db: db,
+	}
+}
+
+// mapCalculationToEntity converts a model to an entity
+func mapCalculationToEntity(model *CalculationModel) *entities.CalculationResult {
+	return &entities.CalculationResult{
+		ID:                model.ID,
+		ItemsOrdered:      model.ItemsOrdered,
+		TotalItems:        model.TotalItems,
+		Packs:             model.Packs,
+		TotalWeight:       model.TotalWeight,
+		ShippingEstimates: model.ShippingEstimates,
+		Footprint: entities.PackagingFootprint{
+			Material:  model.MaterialWeight,
+			Emissions: model.Emissions,
+		},
+		CreatedAt: model.CreatedAt,
+	}
+}
+
+// mapCalculationToModel converts an entity to a model
+func mapCalculationToModel(entity *entities.CalculationResult) *CalculationModel {
+	return &CalculationModel{
+		ID:                entity.ID,
+		ItemsOrdered:      entity.ItemsOrdered,
+		TotalItems:        entity.TotalItems,
+		Packs:             entity.Packs,
+		TotalWeight:       entity.TotalWeight,
+		ShippingEstimates: entity.ShippingEstimates,
+		MaterialWeight:    entity.Footprint.Material,
+		Emissions:         entity.Footprint.Emissions,
+		CreatedAt:         entity.CreatedAt,
+	}
+}
+
+// Create stores a calculation result in the database
+func (r *CalculationRepository) Create(ctx context.Context, result *entities.CalculationResult) (*entities.CalculationResult, error) {
+	// Generate UUID if not provided
+	if result.ID == "" {
+		result.ID = uuid.New().String()
+	}
+
+	// Convert to model
+	model := mapCalculationToModel(result)
+
+	// Insert into database
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, err)
+	}
+
+	// Return the created entity
+	return mapCalculationToEntity(model), nil
+}
+
+// FindByID retrieves a calculation result by ID from the database
+func (r *CalculationRepository) FindByID(ctx context.Context, id string) (*entities.CalculationResult, error) {
+	var model CalculationModel
+
+	// Query the database
+	result := r.db.WithContext(ctx).First(&model, "id = ?", id)
+	if result.Error != nil {
+		if stderr.Is(result.Error, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrCalculationNotFound
+		}
+
+		return nil, fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, result.Error)
+	}
+
+	// Convert to entity
+	return mapCalculationToEntity(&model), nil
+}
+
+// PurgeBefore permanently removes the calculation results created before the given time
+func (r *CalculationRepository) PurgeBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).Where("created_at < ?", before).Delete(&CalculationModel{})
+	if result.Error != nil {
+		return 0, fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,160 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// OutboxModel is the GORM model for outbox entries. The table orders the entries by a position
+// column the database assigns.
+type OutboxModel struct {
+	ID            string `gorm:"primaryKey"`
+	Type          string
+	PackSizeID    string
+	Event         *entities.PackSizeEvent `gorm:"serializer:json;type:jsonb"`
+	Attempts      int
+	NextAttemptAt time.Time `gorm:"index"`
+	LastError     string
+	DeadAt        *time.Time // Given up, never claimed again
+	OccurredAt    time.Time
+}
+
+// TableName specifies the table name for the model
+func (OutboxModel) TableName() string {
+	return "outbox"
+}
+
+// OutboxRepository is the PostgreSQL implementation of OutboxRepository. Delivered entries are
+// removed, given up ones are kept.
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+// Ensure OutboxRepository implements the OutboxRepository interface
+var _ secondary.OutboxRepository = (*OutboxRepository)(nil)
+
+// NewOutboxRepository creates a new PostgreSQL outbox repository
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{
+		db: db,
+	}
+}
+
+// mapOutboxToEntity converts an outbox model to an entity
+func mapOutboxToEntity(model *OutboxModel) *entities.OutboxEntry {
+	return &entities.OutboxEntry{
+		Event:         model.Event,
+		Attempts:      model.Attempts,
+		NextAttemptAt: model.NextAttemptAt,
+		LastError:     model.LastError,
+	}
+}
+
+// Add inserts events in the outbox, due for delivery now
+func (r *OutboxRepository) Add(ctx context.Context, events ...*entities.PackSizeEvent) error {
+	if len(events) == 0 {
+		return nil
+	}
+
+	now := time.Now()
+	models := make([]*OutboxModel, len(events))
+	for i, event := range events {
+		if event.ID == "" {
+			event.ID = uuid.New().String()
+		}
+		models[i] = &OutboxModel{
+			ID:            event.ID,
+			Type:          string(event.Type),
+			PackSizeID:    event.PackSizeID,
+			Event:         event,
+			NextAttemptAt: now,
+			OccurredAt:    event.OccurredAt,
+		}
+	}
+
+	if err := r.db.WithContext(ctx).Create(models).Error; err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, err)
+	}
+
+	return nil
+}
+
+// ClaimPending retrieves the entries due for delivery, oldest first, hiding them for the lease.
+// Entries another relay is claiming at the same time are skipped.
+func (r *OutboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*entities.OutboxEntry, error) {
+	var models []*OutboxModel
+
+	now := time.Now()
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE", Options: "SKIP LOCKED"}).
+			Where("dead_at IS NULL AND next_attempt_at <= ?", now).
+			Order("position ASC").
+			Limit(limit).
+			Find(&models).Error
+		if err != nil || len(models) == 0 {
+			return err
+		}
+
+		ids := make([]string, len(models))
+		for i, model := range models {
+			ids[i] = model.ID
+		}
+
+		return tx.Model(&OutboxModel{}).Where("id IN ?", ids).Update("next_attempt_at", now.Add(lease)).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, err)
+	}
+
+	// Convert to entities
+	entries := make([]*entities.OutboxEntry, len(models))
+	for i, model := range models {
+		entries[i] = mapOutboxToEntity(model)
+	}
+
+	return entries, nil
+}
+
+// MarkDelivered removes a delivered entry from the outbox
+func (r *OutboxRepository) MarkDelivered(ctx context.Context, id string) error {
+	return r.change(r.db.WithContext(ctx).Where("id = ?", id).Delete(&OutboxModel{}), id)
+}
+
+// MarkFailed records a failed delivery of an entry, delivered again from the given time
+func (r *OutboxRepository) MarkFailed(ctx context.Context, id string, reason string, nextAttemptAt time.Time) error {
+	return r.change(r.db.WithContext(ctx).Model(&OutboxModel{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"attempts":        gorm.Expr("attempts + 1"),
+		"last_error":      reason,
+		"next_attempt_at": nextAttemptAt,
+	}), id)
+}
+
+// MarkDead records the last failed delivery of an entry, which is never delivered again
+func (r *OutboxRepository) MarkDead(ctx context.Context, id string, reason string) error {
+	return r.change(r.db.WithContext(ctx).Model(&OutboxModel{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"attempts":   gorm.Expr("attempts + 1"),
+		"last_error": reason,
+		"dead_at":    time.Now(),
+	}), id)
+}
+
+// change checks the result of a statement changing the entry with the given ID
+func (r *OutboxRepository) change(result *gorm.DB, id string) error {
+	if result.Error != nil {
+		return fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("%w: %s", errors.ErrOutboxEntryNotFound, id)
+	}
+
+	return nil
+}
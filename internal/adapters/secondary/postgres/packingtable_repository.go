@@ -0,0 +1,126 @@
+package postgres
+
+import (
+	"context"
+	"encoding/binary"
+	stderr "errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// PackingTableModel is the GORM model for precomputed packing tables
+type PackingTableModel struct {
+	SizeSet   string `gorm:"primaryKey"`
+	Ceiling   int
+	Index     []byte // Little-endian int32 values
+	Rows      []byte // Little-endian int32 values
+	CreatedAt time.Time
+}
+
+// TableName specifies the table name for the model
+func (PackingTableModel) TableName() string {
+	return "packing_tables"
+}
+
+// PackingTableRepository is the PostgreSQL implementation of PackingTableRepository
+type PackingTableRepository struct {
+	db *gorm.DB
+}
+
+// Ensure PackingTableRepository implements the PackingTableRepository interface
+var _ secondary.PackingTableRepository = (*PackingTableRepository)(nil)
+
+// NewPackingTableRepository creates a new PostgreSQL packing table repository
+func NewPackingTableRepository(db *gorm.DB) *PackingTableRepository {
+	return &PackingTableRepository{
+		db: db,
+	}
+}
+
+// mapPackingTableToEntity converts a model to an entity
+func mapPackingTableToEntity(model *PackingTableModel) (*entities.PackingTable, error) {
+	sizes := make([]int, 0)
+	for _, part := range strings.Split(model.SizeSet, ",") {
+		size, err := strconv.Atoi(part)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid size set %q", errors.ErrDatabaseOperation, model.SizeSet)
+		}
+		sizes = append(sizes, size)
+	}
+
+	return &entities.PackingTable{
+		Sizes:     sizes,
+		Ceiling:   model.Ceiling,
+		Index:     decodeInt32s(model.Index),
+		Rows:      decodeInt32s(model.Rows),
+		CreatedAt: model.CreatedAt,
+	}, nil
+}
+
+// mapPackingTableToModel converts an entity to a model
+func mapPackingTableToModel(entity *entities.PackingTable) *PackingTableModel {
+	return &PackingTableModel{
+		SizeSet:   entity.Key(),
+		Ceiling:   entity.Ceiling,
+		Index:     encodeInt32s(entity.Index),
+		Rows:      encodeInt32s(entity.Rows),
+		CreatedAt: entity.CreatedAt,
+	}
+}
+
+// Save stores the table, replacing the table of the same pack size set
+func (r *PackingTableRepository) Save(ctx context.Context, table *entities.PackingTable) error {
+	model := mapPackingTableToModel(table)
+
+	err := r.db.WithContext(ctx).Clauses(clause.OnConflict{UpdateAll: true}).Create(model).Error
+	if err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, err)
+	}
+
+	return nil
+}
+
+// FindByKey retrieves the table of a pack size set from the database
+func (r *PackingTableRepository) FindByKey(ctx context.Context, key string) (*entities.PackingTable, error) {
+	var model PackingTableModel
+
+	// Query the database
+	if err := r.db.WithContext(ctx).Where("size_set = ?", key).First(&model).Error; err != nil {
+		if stderr.Is(err, gorm.ErrRecordNotFound) {
+			return nil, errors.ErrPackingTableNotFound
+		}
+
+		return nil, fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, err)
+	}
+
+	return mapPackingTableToEntity(&model)
+}
+
+// encodeInt32s packs the values into little-endian bytes
+func encodeInt32s(values []int32) []byte {
+	data := make([]byte, 4*len(values))
+	for i, value := range values {
+		binary.LittleEndian.PutUint32(data[4*i:], uint32(value))
+	}
+
+	return data
+}
+
+// decodeInt32s unpacks little-endian bytes written by encodeInt32s
+func decodeInt32s(data []byte) []int32 {
+	values := make([]int32, len(data)/4)
+	for i := range values {
+		values[i] = int32(binary.LittleEndian.Uint32(data[4*i:]))
+	}
+
+	return values
+}
@@ -1,11 +1,13 @@
 package postgres
 
 import (
+	"context"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
 	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
 
 	stderr "errors"
 	"go-pack-calculator/internal/domain/entities"
@@ -15,11 +17,24 @@ import (
 
 // PackSizeModel is the GORM model for pack sizes
 type PackSizeModel struct {
-	ID        string `gorm:"primaryKey"`
-	Size      int
-	CreatedAt time.Time
-	UpdatedAt time.Time
-	DeletedAt *time.Time `gorm:"index"`
+	ID             string `gorm:"primaryKey"`
+	Version        int64  `gorm:"not null;default:1"` // Incremented by every change
+	Size           int
+	Name           string
+	SKU            string   `gorm:"column:sku"`
+	Tags           []string `gorm:"serializer:json;type:jsonb"`
+	Active         bool
+	Weight         float64
+	MaterialWeight float64
+	EmissionFactor float64
+	Length         float64
+	Width          float64
+	Height         float64
+	ValidFrom      *time.Time
+	ValidTo        *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+	DeletedAt      gorm.DeletedAt `gorm:"index"` // Soft delete, hides the row from regular queries
 }
 
 // TableName specifies the table name for the model
@@ -29,7 +44,8 @@ func (PackSizeModel) TableName() string {
 
 // PackSizeRepository is the PostgreSQL implementation of PackSizeRepository
 type PackSizeRepository struct {
-	db *gorm.DB
+	db       *gorm.DB
+	lockRows bool // Lock the rows read until the end of the transaction
 }
 
 // Ensure PackSizeRepository implements the PackSizeRepository interface
@@ -45,36 +61,88 @@ func NewPackSizeRepository(db *gorm.DB) *PackSizeRepository {
 // mapToEntity converts a model to an entity
 func mapToEntity(model *PackSizeModel) *entities.PackSize {
 	return &entities.PackSize{
-		ID:        model.ID,
-		Size:      model.Size,
+		ID:             model.ID,
+		Version:        model.Version,
+		Size:           model.Size,
+		Name:           model.Name,
+		SKU:            model.SKU,
+		Tags:           model.Tags,
+		Active:         model.Active,
+		Weight:         model.Weight,
+		MaterialWeight: model.MaterialWeight,
+		EmissionFactor: model.EmissionFactor,
+		Dimensions: entities.Dimensions{
+			Length: model.Length,
+			Width:  model.Width,
+			Height: model.Height,
+		},
+		ValidFrom: model.ValidFrom,
+		ValidTo:   model.ValidTo,
 		CreatedAt: model.CreatedAt,
 		UpdatedAt: model.UpdatedAt,
+		DeletedAt: deletedAt(model.DeletedAt),
 	}
 }
 
+// deletedAt converts the soft delete column to the optional deletion time of an entity
+func deletedAt(column gorm.DeletedAt) *time.Time {
+	if !column.Valid {
+		return nil
+	}
+
+	return &column.Time
+}
+
 // mapToModel converts an entity to a model
 func mapToModel(entity *entities.PackSize) *PackSizeModel {
 	return &PackSizeModel{
-		ID:        entity.ID,
-		Size:      entity.Size,
-		CreatedAt: entity.CreatedAt,
-		UpdatedAt: entity.UpdatedAt,
+		ID:             entity.ID,
+		Version:        entity.Version,
+		Size:           entity.Size,
+		Name:           entity.Name,
+		SKU:            entity.SKU,
+		Tags:           entity.Tags,
+		Active:         entity.Active,
+		Weight:         entity.Weight,
+		MaterialWeight: entity.MaterialWeight,
+		EmissionFactor: entity.EmissionFactor,
+		Length:         entity.Dimensions.Length,
+		Width:          entity.Dimensions.Width,
+		Height:         entity.Dimensions.Height,
+		ValidFrom:      entity.ValidFrom,
+		ValidTo:        entity.ValidTo,
+		CreatedAt:      entity.CreatedAt,
+		UpdatedAt:      entity.UpdatedAt,
 	}
 }
 
 // Create creates a new pack size in the database
-func (r *PackSizeRepository) Create(packSize *entities.PackSize) (*entities.PackSize, error) {
+func (r *PackSizeRepository) Create(ctx context.Context, packSize *entities.PackSize) (*entities.PackSize, error) {
 	// Generate UUID if not provided
 	if packSize.ID == "" {
 		packSize.ID = uuid.New().String()
 	}
 
+	// Set the first version
+	packSize.Version = 1
+
 	// Convert to model
 	model := mapToModel(packSize)
 
-	// Insert into database
-	if err := r.db.Create(model).Error; err != nil {
-		return nil, fmt.Errorf("%w: %s", errors.ErrDatabaseOperation, err.Error())
+	// Insert into database together with the audit entry
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(model).Error; err != nil {
+			if stderr.Is(err, gorm.ErrDuplicatedKey) {
+				return fmt.Errorf("%w: size %d", errors.ErrDuplicatePackSize, packSize.Size)
+			}
+
+			return fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, err)
+		}
+
+		return recordAudit(ctx, tx, entities.AuditActionCreated, nil, mapToEntity(model))
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Return the created entity
@@ -82,12 +150,12 @@ func (r *PackSizeRepository) Create(packSize *entities.PackSize) (*entities.Pack
 }
 
 // FindAll retrieves all pack sizes from the database
-func (r *PackSizeRepository) FindAll() ([]*entities.PackSize, error) {
+func (r *PackSizeRepository) FindAll(ctx context.Context) ([]*entities.PackSize, error) {
 	var models []*PackSizeModel
 
 	// Query the database
-	if err := r.db.Order("size ASC").Find(&models).Error; err != nil {
-		return nil, fmt.Errorf("%w: %s", errors.ErrDatabaseOperation, err.Error())
+	if err := r.read(ctx).Order("size ASC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, err)
 	}
 
 	// Convert to entities
@@ -100,21 +168,21 @@ func (r *PackSizeRepository) FindAll() ([]*entities.PackSize, error) {
 }
 
 // FindAllPaginated retrieves pack sizes with pagination
-func (r *PackSizeRepository) FindAllPaginated(page, limit int64) ([]*entities.PackSize, int64, error) {
+func (r *PackSizeRepository) FindAllPaginated(ctx context.Context, page, limit int64) ([]*entities.PackSize, int64, error) {
 	var models []*PackSizeModel
 	var total int64
 
 	// Get total count
-	if err := r.db.Model(&PackSizeModel{}).Count(&total).Error; err != nil {
-		return nil, 0, fmt.Errorf("%w: %s", errors.ErrDatabaseOperation, err.Error())
+	if err := r.db.WithContext(ctx).Model(&PackSizeModel{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, err)
 	}
 
 	// Calculate offset
 	offset := (page - 1) * limit
 
 	// Query with pagination
-	if err := r.db.Order("size ASC").Offset(int(offset)).Limit(int(limit)).Find(&models).Error; err != nil {
-		return nil, 0, fmt.Errorf("%w: %s", errors.ErrDatabaseOperation, err.Error())
+	if err := r.db.WithContext(ctx).Order("size ASC").Offset(int(offset)).Limit(int(limit)).Find(&models).Error; err != nil {
+		return nil, 0, fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, err)
 	}
 
 	// Convert to entities
@@ -127,17 +195,17 @@ func (r *PackSizeRepository) FindAllPaginated(page, limit int64) ([]*entities.Pa
 }
 
 // FindByID retrieves a pack size by ID from the database
-func (r *PackSizeRepository) FindByID(id string) (*entities.PackSize, error) {
+func (r *PackSizeRepository) FindByID(ctx context.Context, id string) (*entities.PackSize, error) {
 	var model PackSizeModel
 
 	// Query the database
-	result := r.db.First(&model, "id = ?", id)
+	result := r.read(ctx).First(&model, "id = ?", id)
 	if result.Error != nil {
 		if stderr.Is(result.Error, gorm.ErrRecordNotFound) {
 			return nil, errors.ErrPackSizeNotFound
 		}
 
-		return nil, fmt.Errorf("%w: %s", errors.ErrDatabaseOperation, result.Error.Error())
+		return nil, fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, result.Error)
 	}
 
 	// Convert to entity
@@ -145,38 +213,194 @@ func (r *PackSizeRepository) FindByID(id string) (*entities.PackSize, error) {
 }
 
 // Update updates a pack size in the database
-func (r *PackSizeRepository) Update(packSize *entities.PackSize) (*entities.PackSize, error) {
-	// Update timestamp
-	packSize.UpdatedAt = time.Now()
-
-	// Convert to model
+func (r *PackSizeRepository) Update(ctx context.Context, packSize *entities.PackSize) (*entities.PackSize, error) {
+	// Convert to model with the next version and timestamp
 	model := mapToModel(packSize)
+	model.Version = packSize.Version + 1
+	model.UpdatedAt = time.Now()
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Load the previous state for the audit entry, locking the row until the update is written
+		var existing PackSizeModel
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&existing, "id = ?", packSize.ID).Error; err != nil {
+			if stderr.Is(err, gorm.ErrRecordNotFound) {
+				return errors.ErrPackSizeNotFound
+			}
+
+			return fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, err)
+		}
+		if existing.Version != packSize.Version {
+			return fmt.Errorf("%w: version %d, not %d", errors.ErrVersionMismatch, existing.Version, packSize.Version)
+		}
 
-	// Update in database
-	result := r.db.Model(&PackSizeModel{ID: packSize.ID}).Updates(model)
-	if result.Error != nil {
-		return nil, fmt.Errorf("%w: %s", errors.ErrDatabaseOperation, result.Error.Error())
-	}
+		// Update in database if the version is unchanged, selecting the columns so zero values are written too
+		result := tx.Model(&PackSizeModel{ID: packSize.ID}).Where("version = ?", packSize.Version).Select("version", "size", "name", "sku", "tags", "active", "weight", "material_weight", "emission_factor", "length", "width", "height", "valid_from", "valid_to", "updated_at").Updates(model)
+		if result.Error != nil {
+			if stderr.Is(result.Error, gorm.ErrDuplicatedKey) {
+				return fmt.Errorf("%w: size %d", errors.ErrDuplicatePackSize, packSize.Size)
+			}
+
+			return fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, result.Error)
+		}
 
-	if result.RowsAffected == 0 {
-		return nil, errors.ErrPackSizeNotFound
+		if result.RowsAffected == 0 {
+			return errors.ErrVersionMismatch
+		}
+
+		return recordAudit(ctx, tx, entities.AuditActionUpdated, mapToEntity(&existing), mapToEntity(model))
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	// Return the updated entity
+	packSize.Version = model.Version
+	packSize.UpdatedAt = model.UpdatedAt
+
 	return packSize, nil
 }
 
-// Delete deletes a pack size from the database
-func (r *PackSizeRepository) Delete(id string) error {
-	// Delete from database
-	result := r.db.Delete(&PackSizeModel{}, "id = ?", id)
-	if result.Error != nil {
-		return fmt.Errorf("%w: %s", errors.ErrDatabaseOperation, result.Error.Error())
+// Delete moves a pack size to the trash by setting its deletion time
+func (r *PackSizeRepository) Delete(ctx context.Context, id string, version int64) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Load the previous state for the audit entry, locking the row until the deletion is written
+		var existing PackSizeModel
+		if err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).First(&existing, "id = ?", id).Error; err != nil {
+			if stderr.Is(err, gorm.ErrRecordNotFound) {
+				return errors.ErrPackSizeNotFound
+			}
+
+			return fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, err)
+		}
+		if version != 0 && existing.Version != version {
+			return fmt.Errorf("%w: version %d, not %d", errors.ErrVersionMismatch, existing.Version, version)
+		}
+
+		// Soft delete in database if the version is unchanged
+		result := tx.Model(&PackSizeModel{}).
+			Where("id = ? AND version = ?", id, existing.Version).
+			Updates(map[string]interface{}{"deleted_at": time.Now(), "version": gorm.Expr("version + 1")})
+		if result.Error != nil {
+			return fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return errors.ErrVersionMismatch
+		}
+
+		return recordAudit(ctx, tx, entities.AuditActionDeleted, mapToEntity(&existing), nil)
+	})
+}
+
+// FindDeleted retrieves the pack sizes in the trash, most recently deleted first
+func (r *PackSizeRepository) FindDeleted(ctx context.Context) ([]*entities.PackSize, error) {
+	var models []*PackSizeModel
+
+	// Query the soft deleted rows only
+	if err := r.db.WithContext(ctx).Unscoped().Where("deleted_at IS NOT NULL").Order("deleted_at DESC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, err)
 	}
 
-	if result.RowsAffected == 0 {
-		return errors.ErrPackSizeNotFound
+	// Convert to entities
+	packSizes := make([]*entities.PackSize, len(models))
+	for i, model := range models {
+		packSizes[i] = mapToEntity(model)
+	}
+
+	return packSizes, nil
+}
+
+// Restore takes a pack size out of the trash
+func (r *PackSizeRepository) Restore(ctx context.Context, id string) (*entities.PackSize, error) {
+	var restored PackSizeModel
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Load the previous state for the audit entry, locking the row until the restore is written
+		var existing PackSizeModel
+		if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).Where("deleted_at IS NOT NULL").First(&existing, "id = ?", id).Error; err != nil {
+			if stderr.Is(err, gorm.ErrRecordNotFound) {
+				return errors.ErrPackSizeNotFound
+			}
+
+			return fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, err)
+		}
+
+		// Clear the deletion time, the unique index rejects a size taken in the meantime
+		result := tx.Unscoped().Model(&PackSizeModel{}).
+			Where("id = ? AND deleted_at IS NOT NULL", id).
+			Updates(map[string]interface{}{"deleted_at": nil, "version": gorm.Expr("version + 1"), "updated_at": time.Now()})
+		if result.Error != nil {
+			if stderr.Is(result.Error, gorm.ErrDuplicatedKey) {
+				return fmt.Errorf("%w: restoring %s", errors.ErrDuplicatePackSize, id)
+			}
+
+			return fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, result.Error)
+		}
+
+		if result.RowsAffected == 0 {
+			return errors.ErrPackSizeNotFound
+		}
+
+		if err := tx.First(&restored, "id = ?", id).Error; err != nil {
+			return fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, err)
+		}
+
+		return recordAudit(ctx, tx, entities.AuditActionRestored, mapToEntity(&existing), mapToEntity(&restored))
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return mapToEntity(&restored), nil
+}
+
+// PurgeDeleted permanently removes the pack sizes deleted before the given time, recording an
+// audit entry of each of them
+func (r *PackSizeRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	var purged int64
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Load the purged pack sizes for the audit entries, locking the rows until they are removed
+		var models []*PackSizeModel
+		if err := tx.Unscoped().Clauses(clause.Locking{Strength: "UPDATE"}).
+			Where("deleted_at IS NOT NULL AND deleted_at < ?", before).
+			Find(&models).Error; err != nil {
+			return fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, err)
+		}
+		if len(models) == 0 {
+			return nil
+		}
+
+		ids := make([]string, len(models))
+		for i, model := range models {
+			ids[i] = model.ID
+		}
+		result := tx.Unscoped().Where("id IN ?", ids).Delete(&PackSizeModel{})
+		if result.Error != nil {
+			return fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, result.Error)
+		}
+		purged = result.RowsAffected
+
+		for _, model := range models {
+			if err := recordAudit(ctx, tx, entities.AuditActionPurged, mapToEntity(model), nil); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return purged, nil
+}
+
+// read starts a query reading pack sizes, locking the rows it returns when the repository is bound
+// to a transaction of the transaction manager
+func (r *PackSizeRepository) read(ctx context.Context) *gorm.DB {
+	db := r.db.WithContext(ctx)
+	if r.lockRows {
+		db = db.Clauses(clause.Locking{Strength: "UPDATE"})
 	}
 
-	return nil
+	return db
 }
@@ -0,0 +1,129 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/ports/secondary"
+	"go-pack-calculator/internal/shared/types"
+)
+
+// PackSizeAuditModel is the GORM model for pack size audit entries
+type PackSizeAuditModel struct {
+	ID           string `gorm:"primaryKey"`
+	PackSizeID   string `gorm:"index"`
+	Action       string
+	ClaimedActor string
+	RequestID    string
+	Before       *entities.PackSize `gorm:"serializer:json;type:jsonb"` // Nil for a creation
+	After        *entities.PackSize `gorm:"serializer:json;type:jsonb"` // Nil for a deletion
+	Timestamp    time.Time          `gorm:"index"`
+}
+
+// TableName specifies the table name for the model
+func (PackSizeAuditModel) TableName() string {
+	return "pack_size_audit"
+}
+
+// PackSizeAuditRepository is the PostgreSQL implementation of PackSizeAuditRepository
+type PackSizeAuditRepository struct {
+	db *gorm.DB
+}
+
+// Ensure PackSizeAuditRepository implements the PackSizeAuditRepository interface
+var _ secondary.PackSizeAuditRepository = (*PackSizeAuditRepository)(nil)
+
+// NewPackSizeAuditRepository creates a new PostgreSQL pack size audit repository
+func NewPackSizeAuditRepository(db *gorm.DB) *PackSizeAuditRepository {
+	return &PackSizeAuditRepository{
+		db: db,
+	}
+}
+
+// recordAudit inserts an audit entry of a change attributed to the request info of the context.
+// Pass the transaction of the change so both are written or neither is.
+func recordAudit(ctx context.Context, tx *gorm.DB, action entities.AuditAction, before, after *entities.PackSize) error {
+	info := types.RequestInfoFromContext(ctx)
+	entry := entities.NewPackSizeAuditEntry(action, before, after, info.ClaimedActor, info.RequestID)
+
+	model := &PackSizeAuditModel{
+		ID:           uuid.New().String(),
+		PackSizeID:   entry.PackSizeID,
+		Action:       string(entry.Action),
+		ClaimedActor: entry.ClaimedActor,
+		RequestID:    entry.RequestID,
+		Before:       entry.Before,
+		After:        entry.After,
+		Timestamp:    entry.Timestamp,
+	}
+	if err := tx.Create(model).Error; err != nil {
+		return fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, err)
+	}
+
+	return nil
+}
+
+// mapAuditToEntity converts an audit model to an entity
+func mapAuditToEntity(model *PackSizeAuditModel) *entities.PackSizeAuditEntry {
+	return &entities.PackSizeAuditEntry{
+		ID:           model.ID,
+		PackSizeID:   model.PackSizeID,
+		Action:       entities.AuditAction(model.Action),
+		ClaimedActor: model.ClaimedActor,
+		RequestID:    model.RequestID,
+		Before:       model.Before,
+		After:        model.After,
+		Timestamp:    model.Timestamp,
+	}
+}
+
+// FindByPackSizeID retrieves the audit entries of a pack size, oldest first
+func (r *PackSizeAuditRepository) FindByPackSizeID(ctx context.Context, packSizeID string) ([]*entities.PackSizeAuditEntry, error) {
+	var models []*PackSizeAuditModel
+
+	// Query the database
+	if err := r.db.WithContext(ctx).Where("pack_size_id = ?", packSizeID).Order("timestamp ASC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, err)
+	}
+
+	// Convert to entities
+	entries := make([]*entities.PackSizeAuditEntry, len(models))
+	for i, model := range models {
+		entries[i] = mapAuditToEntity(model)
+	}
+
+	return entries, nil
+}
+
+// FindAllPaginated retrieves a page of the audit entries of every pack size, newest first
+func (r *PackSizeAuditRepository) FindAllPaginated(ctx context.Context, page, limit int64) ([]*entities.PackSizeAuditEntry, int64, error) {
+	var models []*PackSizeAuditModel
+	var total int64
+
+	// Get total count
+	if err := r.db.WithContext(ctx).Model(&PackSizeAuditModel{}).Count(&total).Error; err != nil {
+		return nil, 0, fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, err)
+	}
+
+	// Calculate offset
+	offset := (page - 1) * limit
+
+	// Query with pagination
+	if err := r.db.WithContext(ctx).Order("timestamp DESC").Offset(int(offset)).Limit(int(limit)).Find(&models).Error; err != nil {
+		return nil, 0, fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, err)
+	}
+
+	// Convert to entities
+	entries := make([]*entities.PackSizeAuditEntry, len(models))
+	for i, model := range models {
+		entries[i] = mapAuditToEntity(model)
+	}
+
+	return entries, total, nil
+}
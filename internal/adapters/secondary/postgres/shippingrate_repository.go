@@ -0,0 +1,114 @@
+package postgres
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// ShippingRateModel is the GORM model for shipping rates
+type ShippingRateModel struct {
+	ID         string `gorm:"primaryKey"`
+	Carrier    string
+	MaxWeight  float64
+	MaxParcels int
+	Price      float64
+	CreatedAt  time.Time
+}
+
+// TableName specifies the table name for the model
+func (ShippingRateModel) TableName() string {
+	return "shipping_rates"
+}
+
+// ShippingRateRepository is the PostgreSQL implementation of ShippingRateRepository
+type ShippingRateRepository struct {
+	db *gorm.DB
+}
+
+// Ensure ShippingRateRepository implements the ShippingRateRepository interface
+var _ secondary.ShippingRateRepository = (*ShippingRateRepository)(nil)
+
+// NewShippingRateRepository creates a new PostgreSQL shipping rate repository
+func NewShippingRateRepository(db *gorm.DB) *ShippingRateRepository {
+	return &ShippingRateRepository{
+		db: db,
+	}
+}
+
+// mapShippingRateToEntity converts a model to an entity
+func mapShippingRateToEntity(model *ShippingRateModel) *entities.ShippingRate {
+	return &entities.ShippingRate{
+		ID:         model.ID,
+		Carrier:    model.Carrier,
+		MaxWeight:  model.MaxWeight,
+		MaxParcels: model.MaxParcels,
+		Price:      model.Price,
+		CreatedAt:  model.CreatedAt,
+	}
+}
+
+// mapShippingRateToModel converts an entity to a model
+func mapShippingRateToModel(entity *entities.ShippingRate) *ShippingRateModel {
+	return &ShippingRateModel{
+		ID:         entity.ID,
+		Carrier:    entity.Carrier,
+		MaxWeight:  entity.MaxWeight,
+		MaxParcels: entity.MaxParcels,
+		Price:      entity.Price,
+		CreatedAt:  entity.CreatedAt,
+	}
+}
+
+// ReplaceAll replaces the whole rate table in a single transaction
+func (r *ShippingRateRepository) ReplaceAll(ctx context.Context, rates []*entities.ShippingRate) ([]*entities.ShippingRate, error) {
+	models := make([]*ShippingRateModel, len(rates))
+	for i, rate := range rates {
+		// Generate UUID if not provided
+		if rate.ID == "" {
+			rate.ID = uuid.New().String()
+		}
+		models[i] = mapShippingRateToModel(rate)
+	}
+
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("1 = 1").Delete(&ShippingRateModel{}).Error; err != nil {
+			return err
+		}
+		if len(models) == 0 {
+			return nil
+		}
+
+		return tx.Create(&models).Error
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, err)
+	}
+
+	return r.FindAll(ctx)
+}
+
+// FindAll retrieves the rate table from the database
+func (r *ShippingRateRepository) FindAll(ctx context.Context) ([]*entities.ShippingRate, error) {
+	var models []*ShippingRateModel
+
+	// Query the database
+	if err := r.db.WithContext(ctx).Order("carrier ASC, price ASC").Find(&models).Error; err != nil {
+		return nil, fmt.Errorf("%w: %w", errors.ErrDatabaseOperation, err)
+	}
+
+	// Convert to entities
+	rates := make([]*entities.ShippingRate, len(models))
+	for i, model := range models {
+		rates[i] = mapShippingRateToEntity(model)
+	}
+
+	return rates, nil
+}
@@ -0,0 +1,55 @@
+package postgres
+
+import (
+	"context"
+
+	"gorm.io/gorm"
+
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// TransactionManager is the PostgreSQL implementation of TransactionManager, running the work in
+// a database transaction
+type TransactionManager struct {
+	db *gorm.DB
+}
+
+// Ensure TransactionManager implements the TransactionManager interface
+var _ secondary.TransactionManager = (*TransactionManager)(nil)
+
+// NewTransactionManager creates a new PostgreSQL transaction manager
+func NewTransactionManager(db *gorm.DB) *TransactionManager {
+	return &TransactionManager{
+		db: db,
+	}
+}
+
+// WithinTransaction runs the work with repositories bound to one transaction, committed when the
+// work succeeds. The changes of the repositories use savepoints inside it.
+func (m *TransactionManager) WithinTransaction(ctx context.Context, work func(tx secondary.Transaction) error) error {
+	return m.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		return work(&transaction{tx: tx})
+	})
+}
+
+// transaction gives the repositories bound to a database transaction
+type transaction struct {
+	tx *gorm.DB
+}
+
+// PackSizes returns the pack size repository of the transaction, whose reads lock the rows they
+// return until the transaction ends
+func (t *transaction) PackSizes() secondary.PackSizeRepository {
+	return &PackSizeRepository{
+		db:       t.tx,
+		lockRows: true,
+	}
+}
+
+// Outbox returns the outbox repository of the transaction, whose events are only saved when the
+// transaction commits
+func (t *transaction) Outbox() secondary.OutboxRepository {
+	return &OutboxRepository{
+		db: t.tx,
+	}
+}
@@ -0,0 +1,46 @@
+package publisher
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// LogPublisher writes pack size events to a logger, one JSON line each
+type LogPublisher struct {
+	logger *log.Logger
+}
+
+// Ensure LogPublisher implements the EventPublisher interface
+var _ secondary.EventPublisher = (*LogPublisher)(nil)
+
+// NewLogPublisher creates a new publisher writing to the logger, the standard one when nil
+func NewLogPublisher(logger *log.Logger) *LogPublisher {
+	if logger == nil {
+		logger = log.Default()
+	}
+
+	return &LogPublisher{
+		logger: logger,
+	}
+}
+
+// Name returns the name of the publisher
+func (p *LogPublisher) Name() string {
+	return "log"
+}
+
+// Publish writes the event to the logger
+func (p *LogPublisher) Publish(ctx context.Context, event *entities.PackSizeEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding event: %w", err)
+	}
+	p.logger.Printf("Pack size event %s\n", payload)
+
+	return nil
+}
@@ -0,0 +1,182 @@
+package publisher
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-pack-calculator/internal/domain/entities"
+)
+
+func testEvent() *entities.PackSizeEvent {
+	event := entities.NewPackSizeEvent(entities.PackSizeCreated, &entities.PackSize{ID: "ps-1", Size: 250}, "alice", "req-1")
+	event.ID = "event-1"
+
+	return event
+}
+
+func TestLogPublisher_Publish(t *testing.T) {
+	var buf bytes.Buffer
+	publisher := NewLogPublisher(log.New(&buf, "", 0))
+	assert.Equal(t, "log", publisher.Name())
+
+	require.NoError(t, publisher.Publish(context.Background(), testEvent()))
+	assert.Contains(t, buf.String(), `"id":"event-1"`)
+	assert.Contains(t, buf.String(), `"type":"pack_size.created"`)
+}
+
+func TestWebhookPublisher_Publish(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		wantErr bool
+	}{
+		{name: "Accepted", status: http.StatusNoContent},
+		{name: "Rejected", status: http.StatusServiceUnavailable, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var received *http.Request
+			var body []byte
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				received = r
+				body, _ = io.ReadAll(r.Body)
+				w.WriteHeader(tt.status)
+			}))
+			defer server.Close()
+
+			publisher := NewWebhookPublisher(server.URL, "secret", server.Client())
+			err := publisher.Publish(context.Background(), testEvent())
+			assert.Equal(t, tt.wantErr, err != nil)
+
+			// The request identifies and signs the event
+			require.NotNil(t, received)
+			assert.Equal(t, http.MethodPost, received.Method)
+			assert.Equal(t, "application/json", received.Header.Get("Content-Type"))
+			assert.Equal(t, "event-1", received.Header.Get(EventIDHeader))
+			assert.Equal(t, "pack_size.created", received.Header.Get(EventTypeHeader))
+			assert.Equal(t, Sign("secret", body), received.Header.Get(SignatureHeader))
+
+			var event entities.PackSizeEvent
+			require.NoError(t, json.Unmarshal(body, &event))
+			assert.Equal(t, "ps-1", event.PackSizeID)
+		})
+	}
+}
+
+// natsMessage represents a message published to the fake NATS server
+type natsMessage struct {
+	subject string
+	headers string
+	payload string
+}
+
+// fakeNATSServer speaks enough of the NATS protocol for a publishing client: it answers PINGs
+// and sends the messages published with headers to the channel. The returned function stops it.
+func fakeNATSServer(t *testing.T, messages chan<- natsMessage) (string, func()) {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	var mu sync.Mutex
+	var conns []net.Conn
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			mu.Lock()
+			conns = append(conns, conn)
+			mu.Unlock()
+			go serveNATS(conn, messages)
+		}
+	}()
+
+	stop := func() {
+		listener.Close()
+		mu.Lock()
+		defer mu.Unlock()
+		for _, conn := range conns {
+			conn.Close()
+		}
+	}
+	t.Cleanup(stop)
+
+	return "nats://" + listener.Addr().String(), stop
+}
+
+func serveNATS(conn net.Conn, messages chan<- natsMessage) {
+	defer conn.Close()
+
+	fmt.Fprint(conn, "INFO {\"server_id\":\"fake\",\"proto\":1,\"headers\":true,\"max_payload\":1048576}\r\n")
+	reader := bufio.NewReader(conn)
+	for {
+		line, err := reader.ReadString('\n')
+		if err != nil {
+			return
+		}
+		fields := strings.Fields(line)
+		if len(fields) == 0 {
+			continue
+		}
+
+		switch fields[0] {
+		case "HPUB":
+			headerSize, _ := strconv.Atoi(fields[2])
+			totalSize, _ := strconv.Atoi(fields[3])
+			message := make([]byte, totalSize+2)
+			if _, err := io.ReadFull(reader, message); err != nil {
+				return
+			}
+			messages <- natsMessage{
+				subject: fields[1],
+				headers: string(message[:headerSize]),
+				payload: string(message[headerSize:totalSize]),
+			}
+		case "PING":
+			fmt.Fprint(conn, "PONG\r\n")
+		}
+	}
+}
+
+func TestNATSPublisher_Publish(t *testing.T) {
+	messages := make(chan natsMessage, 10)
+	serverURL, stop := fakeNATSServer(t, messages)
+
+	publisher, err := NewNATSPublisher(serverURL, "pack_sizes", "", time.Second)
+	require.NoError(t, err)
+	defer publisher.Close()
+	assert.Equal(t, "nats", publisher.Name())
+
+	// The event is published on the subject of its type, identified by its ID
+	require.NoError(t, publisher.Publish(context.Background(), testEvent()))
+	message := <-messages
+	assert.Equal(t, "pack_sizes.pack_size.created", message.subject)
+	assert.Contains(t, message.headers, NATSMsgIDHeader+": event-1")
+
+	var event entities.PackSizeEvent
+	require.NoError(t, json.Unmarshal([]byte(message.payload), &event))
+	assert.Equal(t, "event-1", event.ID)
+
+	// Without a server the event is not accepted, so the outbox delivers it again
+	stop()
+	assert.Error(t, publisher.Publish(context.Background(), testEvent()))
+}
@@ -0,0 +1,91 @@
+package publisher
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// Headers of the webhook requests
+const (
+	EventIDHeader   = "X-Event-ID"
+	EventTypeHeader = "X-Event-Type"
+	SignatureHeader = "X-Signature-256" // sha256= and the hex HMAC-SHA256 of the body with the secret
+)
+
+// WebhookPublisher posts pack size events as JSON to a URL. Any response but a 2xx status fails
+// the delivery.
+type WebhookPublisher struct {
+	url    string
+	secret string
+	client *http.Client
+}
+
+// Ensure WebhookPublisher implements the EventPublisher interface
+var _ secondary.EventPublisher = (*WebhookPublisher)(nil)
+
+// NewWebhookPublisher creates a new publisher posting to the URL with the client, signing the
+// requests when the secret is not empty
+func NewWebhookPublisher(url, secret string, client *http.Client) *WebhookPublisher {
+	return &WebhookPublisher{
+		url:    url,
+		secret: secret,
+		client: client,
+	}
+}
+
+// Name returns the name of the publisher
+func (p *WebhookPublisher) Name() string {
+	return "webhook"
+}
+
+// Publish posts the event to the URL
+func (p *WebhookPublisher) Publish(ctx context.Context, event *entities.PackSizeEvent) error {
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("encoding event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.url, bytes.NewReader(payload))
+	if err != nil {
+		return fmt.Errorf("creating request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(EventIDHeader, event.ID)
+	req.Header.Set(EventTypeHeader, string(event.Type))
+	if p.secret != "" {
+		req.Header.Set(SignatureHeader, Sign(p.secret, payload))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	// Drain the body so the connection is reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 1<<16))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook responded %s", resp.Status)
+	}
+
+	return nil
+}
+
+// Sign returns the signature header value of a webhook body
+func Sign(secret string, payload []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
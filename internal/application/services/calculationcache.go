@@ -0,0 +1,156 @@
+package services
+
+import (
+	"container/list"
+	"context"
+	"sync"
+	"time"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/ports/primary"
+)
+
+// calculationKey identifies a calculation of an order against a version of the pack size set
+type calculationKey struct {
+	version      uint64
+	itemsOrdered int
+	options      entities.CalculationOptions
+}
+
+// calculationEntry represents a cached calculation result
+type calculationEntry struct {
+	key    calculationKey
+	result *entities.CalculationResult
+}
+
+// CalculationCache memoizes pack calculations. It wraps a CalculationService and keeps the
+// most recently used results of the current pack size set, evicting the least recently used
+// result when full. A result expires once it is older than the time to live, before the stored
+// calculation it is can be purged. Invalidate must be called whenever the pack size set or the shipping rates
+// change.
+type CalculationCache struct {
+	primary.CalculationService
+	mu        sync.Mutex
+	capacity  int
+	ttl       time.Duration
+	version   uint64
+	entries   map[calculationKey]*list.Element
+	recent    *list.List // Most recently used first
+	hits      int64
+	misses    int64
+	evictions int64
+}
+
+// Ensure CalculationCache implements the CalculationService and CalculationCacheService interfaces
+var _ primary.CalculationService = (*CalculationCache)(nil)
+var _ primary.CalculationCacheService = (*CalculationCache)(nil)
+
+// NewCalculationCache creates a calculation cache holding at most the given number of results,
+// each for at most the time to live
+func NewCalculationCache(calculationService primary.CalculationService, capacity int, ttl time.Duration) *CalculationCache {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &CalculationCache{
+		CalculationService: calculationService,
+		capacity:           capacity,
+		ttl:                ttl,
+		entries:            make(map[calculationKey]*list.Element),
+		recent:             list.New(),
+	}
+}
+
+// CalculatePacksForOrder returns the cached calculation of the order, calculating it on a miss.
+// A cached result is the stored calculation it was first returned as, so it keeps its ID until it
+// expires.
+func (c *CalculationCache) CalculatePacksForOrder(
+	ctx context.Context,
+	itemsOrdered int,
+	options entities.CalculationOptions,
+) (*entities.CalculationResult, error) {
+	key, result, ok := c.get(itemsOrdered, options)
+	if ok {
+		return result, nil
+	}
+
+	result, err := c.CalculationService.CalculatePacksForOrder(ctx, itemsOrdered, options)
+	if err != nil {
+		return nil, err
+	}
+	c.put(key, result)
+
+	return result, nil
+}
+
+// Invalidate drops every cached result, it is called when the pack size set or the shipping
+// rates change
+func (c *CalculationCache) Invalidate() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.version++
+	c.entries = make(map[calculationKey]*list.Element)
+	c.recent.Init()
+}
+
+// GetCacheStats returns the hit and miss counts and the size of the cache
+func (c *CalculationCache) GetCacheStats(ctx context.Context) entities.CacheStats {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return entities.CacheStats{
+		Hits:      c.hits,
+		Misses:    c.misses,
+		Evictions: c.evictions,
+		Entries:   c.recent.Len(),
+		Capacity:  c.capacity,
+		Version:   c.version,
+	}
+}
+
+// get looks up the unexpired calculation of the order against the current pack size set
+func (c *CalculationCache) get(itemsOrdered int, options entities.CalculationOptions) (calculationKey, *entities.CalculationResult, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := calculationKey{version: c.version, itemsOrdered: itemsOrdered, options: options}
+	element, ok := c.entries[key]
+	if ok && time.Since(element.Value.(*calculationEntry).result.CreatedAt) >= c.ttl {
+		c.recent.Remove(element)
+		delete(c.entries, key)
+		ok = false
+	}
+	if !ok {
+		c.misses++
+		return key, nil, false
+	}
+
+	c.hits++
+	c.recent.MoveToFront(element)
+
+	return key, element.Value.(*calculationEntry).result, true
+}
+
+// put stores a result, unless the pack size set changed while it was being calculated
+func (c *CalculationCache) put(key calculationKey, result *entities.CalculationResult) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if key.version != c.version {
+		return
+	}
+	if element, ok := c.entries[key]; ok {
+		element.Value.(*calculationEntry).result = result
+		c.recent.MoveToFront(element)
+		return
+	}
+
+	c.entries[key] = c.recent.PushFront(&calculationEntry{key: key, result: result})
+	if c.recent.Len() > c.capacity {
+		oldest := c.recent.Back()
+		c.recent.Remove(oldest)
+		delete(c.entries, oldest.Value.(*calculationEntry).key)
+		c.evictions++
+	}
+}
@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/ports/primary"
+)
+
+// Mock calculation service that counts its calculations
+type countingCalculationService struct {
+	primary.CalculationService
+	mu    sync.Mutex
+	calls int
+	err   error
+}
+
+func (m *countingCalculationService) CalculatePacksForOrder(
+	ctx context.Context,
+	itemsOrdered int,
+	options entities.CalculationOptions,
+) (*entities.CalculationResult, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.calls++
+	if m.err != nil {
+		return nil, m.err
+	}
+	return entities.NewCalculationResult(itemsOrdered, map[int]int{itemsOrdered: 1}), nil
+}
+
+func (m *countingCalculationService) callCount() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	return m.calls
+}
+
+func TestCalculationCache_CalculatePacksForOrder(t *testing.T) {
+	ctx := context.Background()
+	material := entities.CalculationOptions{Objective: entities.ObjectiveLeastMaterial}
+
+	t.Run("Repeated calculation is served from the cache", func(t *testing.T) {
+		calculationService := &countingCalculationService{}
+		cache := NewCalculationCache(calculationService, 10, time.Hour)
+
+		first, err := cache.CalculatePacksForOrder(ctx, 500, entities.CalculationOptions{})
+		require.NoError(t, err)
+		second, err := cache.CalculatePacksForOrder(ctx, 500, entities.CalculationOptions{})
+		require.NoError(t, err)
+
+		assert.Same(t, first, second)
+		assert.Equal(t, 1, calculationService.callCount())
+
+		stats := cache.GetCacheStats(ctx)
+		assert.Equal(t, int64(1), stats.Hits)
+		assert.Equal(t, int64(1), stats.Misses)
+		assert.Equal(t, 1, stats.Entries)
+		assert.Equal(t, 0.5, stats.HitRatio())
+	})
+
+	t.Run("Options are part of the key", func(t *testing.T) {
+		calculationService := &countingCalculationService{}
+		cache := NewCalculationCache(calculationService, 10, time.Hour)
+
+		_, _ = cache.CalculatePacksForOrder(ctx, 500, entities.CalculationOptions{})
+		_, _ = cache.CalculatePacksForOrder(ctx, 500, material)
+
+		assert.Equal(t, 2, calculationService.callCount())
+	})
+
+	t.Run("Least recently used result is evicted", func(t *testing.T) {
+		calculationService := &countingCalculationService{}
+		cache := NewCalculationCache(calculationService, 2, time.Hour)
+
+		_, _ = cache.CalculatePacksForOrder(ctx, 1, entities.CalculationOptions{})
+		_, _ = cache.CalculatePacksForOrder(ctx, 2, entities.CalculationOptions{})
+		_, _ = cache.CalculatePacksForOrder(ctx, 1, entities.CalculationOptions{}) // 2 is now the oldest
+		_, _ = cache.CalculatePacksForOrder(ctx, 3, entities.CalculationOptions{})
+		_, _ = cache.CalculatePacksForOrder(ctx, 1, entities.CalculationOptions{})
+		_, _ = cache.CalculatePacksForOrder(ctx, 2, entities.CalculationOptions{})
+
+		assert.Equal(t, 4, calculationService.callCount())
+
+		stats := cache.GetCacheStats(ctx)
+		assert.Equal(t, int64(2), stats.Evictions)
+		assert.Equal(t, 2, stats.Entries)
+	})
+
+	t.Run("Invalidation drops cached results", func(t *testing.T) {
+		calculationService := &countingCalculationService{}
+		cache := NewCalculationCache(calculationService, 10, time.Hour)
+
+		_, _ = cache.CalculatePacksForOrder(ctx, 500, entities.CalculationOptions{})
+		cache.Invalidate()
+		_, _ = cache.CalculatePacksForOrder(ctx, 500, entities.CalculationOptions{})
+
+		assert.Equal(t, 2, calculationService.callCount())
+		assert.Equal(t, uint64(1), cache.GetCacheStats(ctx).Version)
+	})
+
+	t.Run("Errors are not cached", func(t *testing.T) {
+		calculationService := &countingCalculationService{err: errors.New("service error")}
+		cache := NewCalculationCache(calculationService, 10, time.Hour)
+
+		_, err := cache.CalculatePacksForOrder(ctx, 500, entities.CalculationOptions{})
+		assert.Error(t, err)
+		_, err = cache.CalculatePacksForOrder(ctx, 500, entities.CalculationOptions{})
+		assert.Error(t, err)
+
+		assert.Equal(t, 2, calculationService.callCount())
+		assert.Equal(t, 0, cache.GetCacheStats(ctx).Entries)
+	})
+}
+
+func TestCalculationCache_InvalidatedByPackSizeChanges(t *testing.T) {
+	ctx := context.Background()
+	small, _ := entities.NewPackSize(250)
+	large, _ := entities.NewPackSize(500)
+
+	repo := &mockPackSizeRepository{packSizes: []*entities.PackSize{small}, packSize: large}
+	service := NewPackCalculatorService(repo, &mockTransactionManager{repo}, newMockCalculationRepository(), &mockShippingRateRepository{})
+	cache := NewCalculationCache(service, 10, time.Hour)
+	service.OnPackSizesChanged(cache.Invalidate)
+
+	first, err := cache.CalculatePacksForOrder(ctx, 300, entities.CalculationOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[int]int{250: 2}, first.Packs)
+
+	// A new pack size changes the optimal packing
+	repo.packSizes = []*entities.PackSize{small, large}
+	_, err = service.CreatePackSize(ctx, 500, entities.PackSizeAttributes{})
+	require.NoError(t, err)
+
+	second, err := cache.CalculatePacksForOrder(ctx, 300, entities.CalculationOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[int]int{500: 1}, second.Packs)
+}
+
+func TestCalculationCache_InvalidatedByShippingRateImports(t *testing.T) {
+	ctx := context.Background()
+	packSize, _ := entities.NewPackSize(250)
+	standard, _ := entities.NewShippingRate("DHL", 20, 5, 12.5)
+	discounted, _ := entities.NewShippingRate("DHL", 20, 5, 9.9)
+
+	repo := &mockPackSizeRepository{packSizes: []*entities.PackSize{packSize}}
+	rates := &mockShippingRateRepository{rates: []*entities.ShippingRate{standard}}
+	service := NewPackCalculatorService(repo, &mockTransactionManager{repo}, newMockCalculationRepository(), rates)
+	cache := NewCalculationCache(service, 10, time.Hour)
+	service.OnShippingRatesChanged(cache.Invalidate)
+
+	first, err := cache.CalculatePacksForOrder(ctx, 300, entities.CalculationOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []entities.ShippingEstimate{{Carrier: "DHL", Price: 12.5}}, first.ShippingEstimates)
+
+	// The same order is estimated with the imported rates
+	_, err = service.ImportShippingRates(ctx, []*entities.ShippingRate{discounted})
+	require.NoError(t, err)
+
+	second, err := cache.CalculatePacksForOrder(ctx, 300, entities.CalculationOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, []entities.ShippingEstimate{{Carrier: "DHL", Price: 9.9}}, second.ShippingEstimates)
+}
+
+func TestCalculationCache_ExpiresBeforeCalculationsArePurged(t *testing.T) {
+	ctx := context.Background()
+	packSize, _ := entities.NewPackSize(250)
+
+	repo := &mockPackSizeRepository{packSizes: []*entities.PackSize{packSize}}
+	service := NewPackCalculatorService(repo, &mockTransactionManager{repo}, newMockCalculationRepository(), &mockShippingRateRepository{})
+	cache := NewCalculationCache(service, 10, 30*time.Minute)
+
+	first, err := cache.CalculatePacksForOrder(ctx, 300, entities.CalculationOptions{})
+	require.NoError(t, err)
+
+	// The stored calculation ages past the retention and is purged
+	first.CreatedAt = time.Now().Add(-2 * time.Hour)
+	purged, err := service.PurgeCalculations(ctx, time.Hour)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), purged)
+
+	// The cached result expired with it, the calculation is made and stored again
+	second, err := cache.CalculatePacksForOrder(ctx, 300, entities.CalculationOptions{})
+	require.NoError(t, err)
+	assert.NotSame(t, first, second)
+	assert.Equal(t, int64(0), cache.GetCacheStats(ctx).Hits)
+
+	stored, err := service.GetCalculationByID(ctx, second.ID)
+	require.NoError(t, err)
+	assert.Equal(t, second.Packs, stored.Packs)
+}
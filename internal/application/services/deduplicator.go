@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"sync"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/ports/primary"
+)
+
+// inflightCalculation represents a calculation shared by the callers waiting for it
+type inflightCalculation struct {
+	done    chan struct{}
+	result  *entities.CalculationResult
+	err     error
+	waiters int
+	cancel  context.CancelFunc
+}
+
+// CalculationDeduplicator shares a single calculation between concurrent identical requests.
+// It wraps a CalculationService and runs each distinct calculation once, detached from the
+// callers' contexts: a caller that gives up stops waiting, and the calculation is only canceled
+// once no caller waits for it. Invalidate must be called whenever the pack size set or the
+// shipping rates change.
+type CalculationDeduplicator struct {
+	primary.CalculationService
+	mu       sync.Mutex
+	version  uint64
+	inflight map[calculationKey]*inflightCalculation
+}
+
+// Ensure CalculationDeduplicator implements the CalculationService interface
+var _ primary.CalculationService = (*CalculationDeduplicator)(nil)
+
+// NewCalculationDeduplicator creates a new calculation deduplicator
+func NewCalculationDeduplicator(calculationService primary.CalculationService) *CalculationDeduplicator {
+	return &CalculationDeduplicator{
+		CalculationService: calculationService,
+		inflight:           make(map[calculationKey]*inflightCalculation),
+	}
+}
+
+// CalculatePacksForOrder joins the identical calculation in flight, starting it when there is none
+func (d *CalculationDeduplicator) CalculatePacksForOrder(
+	ctx context.Context,
+	itemsOrdered int,
+	options entities.CalculationOptions,
+) (*entities.CalculationResult, error) {
+	d.mu.Lock()
+	key := calculationKey{version: d.version, itemsOrdered: itemsOrdered, options: options}
+	call, ok := d.inflight[key]
+	if !ok {
+		// Keep the values of the first caller's context but not its cancellation
+		sharedCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		call = &inflightCalculation{done: make(chan struct{}), cancel: cancel}
+		d.inflight[key] = call
+
+		go d.run(sharedCtx, key, call)
+	}
+	call.waiters++
+	d.mu.Unlock()
+
+	select {
+	case <-call.done:
+		return call.result, call.err
+	case <-ctx.Done():
+		d.leave(key, call)
+		return nil, ctx.Err()
+	}
+}
+
+// Invalidate stops new requests from joining calculations started before the pack size set or the
+// shipping rates changed
+func (d *CalculationDeduplicator) Invalidate() {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	d.version++
+}
+
+// run calculates the packs of a shared calculation and wakes its callers
+func (d *CalculationDeduplicator) run(ctx context.Context, key calculationKey, call *inflightCalculation) {
+	defer call.cancel()
+
+	call.result, call.err = d.CalculationService.CalculatePacksForOrder(ctx, key.itemsOrdered, key.options)
+
+	d.forget(key, call)
+	close(call.done)
+}
+
+// leave removes a caller that stopped waiting, canceling the calculation when it was the last one
+func (d *CalculationDeduplicator) leave(key calculationKey, call *inflightCalculation) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	call.waiters--
+	if call.waiters > 0 {
+		return
+	}
+
+	call.cancel()
+	if d.inflight[key] == call {
+		delete(d.inflight, key)
+	}
+}
+
+// forget stops new requests from joining a finished calculation
+func (d *CalculationDeduplicator) forget(key calculationKey, call *inflightCalculation) {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.inflight[key] == call {
+		delete(d.inflight, key)
+	}
+}
@@ -0,0 +1,175 @@
+package services
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/ports/primary"
+)
+
+// Mock calculation service that blocks until released or canceled
+type cancelableCalculationService struct {
+	primary.CalculationService
+	calls    atomic.Int32
+	started  chan struct{}
+	release  chan struct{}
+	canceled chan struct{}
+}
+
+func newCancelableCalculationService() *cancelableCalculationService {
+	return &cancelableCalculationService{
+		started:  make(chan struct{}, 10),
+		release:  make(chan struct{}),
+		canceled: make(chan struct{}, 10),
+	}
+}
+
+func (m *cancelableCalculationService) CalculatePacksForOrder(
+	ctx context.Context,
+	itemsOrdered int,
+	options entities.CalculationOptions,
+) (*entities.CalculationResult, error) {
+	m.calls.Add(1)
+	m.started <- struct{}{}
+
+	select {
+	case <-m.release:
+		return entities.NewCalculationResult(itemsOrdered, map[int]int{itemsOrdered: 1}), nil
+	case <-ctx.Done():
+		m.canceled <- struct{}{}
+		return nil, ctx.Err()
+	}
+}
+
+// waitForWaiters waits until the given number of callers wait for the calculation of the order
+func waitForWaiters(t *testing.T, d *CalculationDeduplicator, itemsOrdered, waiters int) {
+	t.Helper()
+
+	require.Eventually(t, func() bool {
+		d.mu.Lock()
+		defer d.mu.Unlock()
+
+		call, ok := d.inflight[calculationKey{version: d.version, itemsOrdered: itemsOrdered}]
+		return ok && call.waiters == waiters
+	}, time.Second, time.Millisecond)
+}
+
+func TestCalculationDeduplicator_CalculatePacksForOrder(t *testing.T) {
+	t.Run("Concurrent identical calculations run once", func(t *testing.T) {
+		calculationService := newCancelableCalculationService()
+		deduplicator := NewCalculationDeduplicator(calculationService)
+
+		results := make([]*entities.CalculationResult, 5)
+		var wg sync.WaitGroup
+		for i := range results {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				results[i], _ = deduplicator.CalculatePacksForOrder(context.Background(), 500, entities.CalculationOptions{})
+			}(i)
+		}
+		waitForWaiters(t, deduplicator, 500, len(results))
+		close(calculationService.release)
+		wg.Wait()
+
+		assert.Equal(t, int32(1), calculationService.calls.Load())
+		for _, result := range results {
+			assert.Same(t, results[0], result)
+		}
+	})
+
+	t.Run("Different quantities run separately", func(t *testing.T) {
+		calculationService := newCancelableCalculationService()
+		close(calculationService.release)
+		deduplicator := NewCalculationDeduplicator(calculationService)
+
+		_, err := deduplicator.CalculatePacksForOrder(context.Background(), 500, entities.CalculationOptions{})
+		require.NoError(t, err)
+		_, err = deduplicator.CalculatePacksForOrder(context.Background(), 1000, entities.CalculationOptions{})
+		require.NoError(t, err)
+
+		assert.Equal(t, int32(2), calculationService.calls.Load())
+	})
+
+	t.Run("Canceled caller does not abort the shared calculation", func(t *testing.T) {
+		calculationService := newCancelableCalculationService()
+		deduplicator := NewCalculationDeduplicator(calculationService)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		firstErr := make(chan error, 1)
+		go func() {
+			_, err := deduplicator.CalculatePacksForOrder(ctx, 500, entities.CalculationOptions{})
+			firstErr <- err
+		}()
+		<-calculationService.started
+
+		secondResult := make(chan *entities.CalculationResult, 1)
+		go func() {
+			result, _ := deduplicator.CalculatePacksForOrder(context.Background(), 500, entities.CalculationOptions{})
+			secondResult <- result
+		}()
+		waitForWaiters(t, deduplicator, 500, 2)
+
+		// The first caller gives up while the second still waits
+		cancel()
+		assert.ErrorIs(t, <-firstErr, context.Canceled)
+
+		close(calculationService.release)
+		result := <-secondResult
+		require.NotNil(t, result)
+		assert.Equal(t, 500, result.ItemsOrdered)
+		assert.Empty(t, calculationService.canceled)
+	})
+
+	t.Run("Calculation is canceled when every caller gives up", func(t *testing.T) {
+		calculationService := newCancelableCalculationService()
+		deduplicator := NewCalculationDeduplicator(calculationService)
+
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan error, 1)
+		go func() {
+			_, err := deduplicator.CalculatePacksForOrder(ctx, 500, entities.CalculationOptions{})
+			done <- err
+		}()
+		<-calculationService.started
+
+		cancel()
+		assert.ErrorIs(t, <-done, context.Canceled)
+
+		select {
+		case <-calculationService.canceled:
+		case <-time.After(time.Second):
+			t.Fatal("shared calculation was not canceled")
+		}
+	})
+
+	t.Run("Invalidation starts a new calculation", func(t *testing.T) {
+		calculationService := newCancelableCalculationService()
+		deduplicator := NewCalculationDeduplicator(calculationService)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, _ = deduplicator.CalculatePacksForOrder(context.Background(), 500, entities.CalculationOptions{})
+		}()
+		<-calculationService.started
+
+		// A request made after the pack sizes changed must not reuse the stale calculation
+		deduplicator.Invalidate()
+		go func() {
+			_, _ = deduplicator.CalculatePacksForOrder(context.Background(), 500, entities.CalculationOptions{})
+		}()
+		<-calculationService.started
+
+		close(calculationService.release)
+		<-done
+		assert.Equal(t, int32(2), calculationService.calls.Load())
+	})
+}
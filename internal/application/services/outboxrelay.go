@@ -0,0 +1,33 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go-pack-calculator/internal/application/usecases"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// OutboxRelayService delivers the pack size events of the outbox to the publishers
+type OutboxRelayService struct {
+	relayUseCase *usecases.OutboxRelayUseCase
+}
+
+// NewOutboxRelayService creates a new outbox relay service claiming batches of the given size for
+// the lease
+func NewOutboxRelayService(
+	outbox secondary.OutboxRepository,
+	policy usecases.RetryPolicy,
+	batchSize int,
+	lease time.Duration,
+	publishers ...secondary.EventPublisher,
+) *OutboxRelayService {
+	return &OutboxRelayService{
+		relayUseCase: usecases.NewOutboxRelayUseCase(outbox, policy, batchSize, lease, publishers...),
+	}
+}
+
+// RelayEvents delivers the pending events of the outbox, at least once, to every publisher
+func (s *OutboxRelayService) RelayEvents(ctx context.Context) (usecases.RelayResult, error) {
+	return s.relayUseCase.RelayEvents(ctx)
+}
@@ -1,6 +1,9 @@
 package services
 
 import (
+	"context"
+	"time"
+
 	"go-pack-calculator/internal/application/usecases"
 	"go-pack-calculator/internal/domain/entities"
 	"go-pack-calculator/internal/ports/primary"
@@ -8,37 +11,106 @@ import (
 	"go-pack-calculator/internal/shared/types"
 )
 
-// PackCalculatorService implements both PackSizeService and CalculationService interfaces
+// PackCalculatorService implements the PackSizeService, CalculationService, PackSetComparisonService
+// and ShippingRateService interfaces
 type PackCalculatorService struct {
-	packSizeUseCase    *usecases.PackSizeUseCase
-	calculationUseCase *usecases.CalculationUseCase
+	packSizeUseCase      *usecases.PackSizeUseCase
+	calculationUseCase   *usecases.CalculationUseCase
+	consolidationUseCase *usecases.ConsolidationUseCase
+	boxPackingUseCase    *usecases.BoxPackingUseCase
+	packingSlipUseCase   *usecases.PackingSlipUseCase
+	comparisonUseCase    *usecases.PackSetComparisonUseCase
+	shippingRateUseCase  *usecases.ShippingRateUseCase
+	packingTableUseCase  *usecases.PackingTableUseCase
+	repository           secondary.PackSizeRepository
 }
 
-// Ensure PackCalculatorService implements both interfaces
+// Ensure PackCalculatorService implements the primary interfaces
 var _ primary.PackSizeService = (*PackCalculatorService)(nil)
 var _ primary.CalculationService = (*PackCalculatorService)(nil)
+var _ primary.PackSetComparisonService = (*PackCalculatorService)(nil)
+var _ primary.ShippingRateService = (*PackCalculatorService)(nil)
+var _ primary.PackSizeTransferService = (*PackCalculatorService)(nil)
 
 // NewPackCalculatorService creates a new pack calculator service
-func NewPackCalculatorService(repository secondary.PackSizeRepository) *PackCalculatorService {
+func NewPackCalculatorService(
+	repository secondary.PackSizeRepository,
+	transactions secondary.TransactionManager,
+	calculationRepository secondary.CalculationRepository,
+	shippingRateRepository secondary.ShippingRateRepository,
+	renderers ...secondary.PackingSlipRenderer,
+) *PackCalculatorService {
+	calculationUseCase := usecases.NewCalculationUseCase(repository, calculationRepository, shippingRateRepository)
+
 	return &PackCalculatorService{
-		packSizeUseCase:    usecases.NewPackSizeUseCase(repository),
-		calculationUseCase: usecases.NewCalculationUseCase(repository),
+		packSizeUseCase:      usecases.NewPackSizeUseCase(repository, transactions),
+		calculationUseCase:   calculationUseCase,
+		consolidationUseCase: usecases.NewConsolidationUseCase(repository, calculationUseCase),
+		boxPackingUseCase:    usecases.NewBoxPackingUseCase(repository, calculationUseCase),
+		packingSlipUseCase:   usecases.NewPackingSlipUseCase(calculationUseCase, renderers...),
+		comparisonUseCase:    usecases.NewPackSetComparisonUseCase(repository, calculationUseCase),
+		shippingRateUseCase:  usecases.NewShippingRateUseCase(shippingRateRepository),
+		repository:           repository,
 	}
 }
 
+// SetCalculationLimits sets the computation budget of a single calculation
+func (s *PackCalculatorService) SetCalculationLimits(limits usecases.CalculationLimits) {
+	s.calculationUseCase.SetLimits(limits)
+}
+
+// EnablePackingTable serves calculations of quantities up to the ceiling from a precomputed
+// packing table stored in the table repository. The table is only used once RebuildPackingTable
+// has built it for the current pack sizes.
+func (s *PackCalculatorService) EnablePackingTable(tableRepository secondary.PackingTableRepository, ceiling int) {
+	s.packingTableUseCase = usecases.NewPackingTableUseCase(s.repository, tableRepository, ceiling)
+	s.calculationUseCase.SetPackingTable(s.packingTableUseCase)
+}
+
+// RebuildPackingTable makes the packing table of the current pack sizes available
+func (s *PackCalculatorService) RebuildPackingTable(ctx context.Context) (*entities.PackingTable, error) {
+	return s.packingTableUseCase.Rebuild(ctx)
+}
+
+// OnPackSizesChanged registers a listener called after a pack size is created, updated or deleted
+func (s *PackCalculatorService) OnPackSizesChanged(listener func()) {
+	s.packSizeUseCase.OnChange(listener)
+}
+
+// OnShippingRatesChanged registers a listener called after the carrier rate table is replaced
+func (s *PackCalculatorService) OnShippingRatesChanged(listener func()) {
+	s.shippingRateUseCase.OnChange(listener)
+}
+
+// WatchPackSizeValidity also calls the OnPackSizesChanged listeners whenever a pack size starts or
+// stops being valid
+func (s *PackCalculatorService) WatchPackSizeValidity(ctx context.Context) error {
+	return s.packSizeUseCase.WatchValidity(ctx)
+}
+
 // CreatePackSize creates a new pack size
-func (s *PackCalculatorService) CreatePackSize(size int) (*entities.PackSize, error) {
-	return s.packSizeUseCase.CreatePackSize(size)
+func (s *PackCalculatorService) CreatePackSize(ctx context.Context, size int, attributes entities.PackSizeAttributes) (*entities.PackSize, error) {
+	return s.packSizeUseCase.CreatePackSize(ctx, size, attributes)
+}
+
+// CreatePackSizes creates several pack sizes, all of them or none
+func (s *PackCalculatorService) CreatePackSizes(ctx context.Context, definitions []entities.PackSizeDefinition) ([]*entities.PackSize, error) {
+	return s.packSizeUseCase.CreatePackSizes(ctx, definitions)
+}
+
+// ReplacePackSizes makes the pack sizes exactly the defined ones at once
+func (s *PackCalculatorService) ReplacePackSizes(ctx context.Context, definitions []entities.PackSizeDefinition) (*entities.PackSizeChanges, error) {
+	return s.packSizeUseCase.ReplacePackSizes(ctx, definitions)
 }
 
 // GetAllPackSizes retrieves all pack sizes
-func (s *PackCalculatorService) GetAllPackSizes() ([]*entities.PackSize, error) {
-	return s.packSizeUseCase.GetAllPackSizes()
+func (s *PackCalculatorService) GetAllPackSizes(ctx context.Context) ([]*entities.PackSize, error) {
+	return s.packSizeUseCase.GetAllPackSizes(ctx)
 }
 
 // GetAllPackSizesWithPagination retrieves all pack sizes with pagination
-func (s *PackCalculatorService) GetAllPackSizesWithPagination(page, limit int64) (*types.Pagination, error) {
-	packSizes, total, err := s.packSizeUseCase.GetAllPackSizesWithPagination(page, limit)
+func (s *PackCalculatorService) GetAllPackSizesWithPagination(ctx context.Context, page, limit int64) (*types.Pagination, error) {
+	packSizes, total, err := s.packSizeUseCase.GetAllPackSizesWithPagination(ctx, page, limit)
 	if err != nil {
 		return nil, err
 	}
@@ -57,21 +129,120 @@ func (s *PackCalculatorService) GetAllPackSizesWithPagination(page, limit int64)
 }
 
 // GetPackSizeByID retrieves a pack size by ID
-func (s *PackCalculatorService) GetPackSizeByID(id string) (*entities.PackSize, error) {
-	return s.packSizeUseCase.GetPackSizeByID(id)
+func (s *PackCalculatorService) GetPackSizeByID(ctx context.Context, id string) (*entities.PackSize, error) {
+	return s.packSizeUseCase.GetPackSizeByID(ctx, id)
+}
+
+// UpdatePackSize updates a pack size if its version is the expected one, any version when zero
+func (s *PackCalculatorService) UpdatePackSize(
+	ctx context.Context,
+	id string,
+	version int64,
+	size int,
+	attributes entities.PackSizeAttributes,
+) (*entities.PackSize, error) {
+	return s.packSizeUseCase.UpdatePackSize(ctx, id, version, size, attributes)
+}
+
+// DeletePackSize moves a pack size to the trash if its version is the expected one, any version
+// when zero
+func (s *PackCalculatorService) DeletePackSize(ctx context.Context, id string, version int64) error {
+	return s.packSizeUseCase.DeletePackSize(ctx, id, version)
+}
+
+// DeletePackSizes moves several pack sizes to the trash, all of them or none
+func (s *PackCalculatorService) DeletePackSizes(ctx context.Context, ids []string) error {
+	return s.packSizeUseCase.DeletePackSizes(ctx, ids)
+}
+
+// ExportPackSizes retrieves the pack sizes to export, smallest first
+func (s *PackCalculatorService) ExportPackSizes(ctx context.Context) ([]*entities.PackSize, error) {
+	return s.packSizeUseCase.ExportPackSizes(ctx)
+}
+
+// ImportPackSizes validates imported pack sizes and saves them with the strategy unless it is a dry run
+func (s *PackCalculatorService) ImportPackSizes(ctx context.Context, rows []entities.PackSizeImportRow, strategy entities.ImportStrategy, dryRun bool) (*entities.PackSizeImportReport, error) {
+	return s.packSizeUseCase.ImportPackSizes(ctx, rows, strategy, dryRun)
+}
+
+// GetDeletedPackSizes retrieves the pack sizes in the trash
+func (s *PackCalculatorService) GetDeletedPackSizes(ctx context.Context) ([]*entities.PackSize, error) {
+	return s.packSizeUseCase.GetDeletedPackSizes(ctx)
+}
+
+// RestorePackSize takes a pack size out of the trash
+func (s *PackCalculatorService) RestorePackSize(ctx context.Context, id string) (*entities.PackSize, error) {
+	return s.packSizeUseCase.RestorePackSize(ctx, id)
 }
 
-// UpdatePackSize updates a pack size
-func (s *PackCalculatorService) UpdatePackSize(id string, size int) (*entities.PackSize, error) {
-	return s.packSizeUseCase.UpdatePackSize(id, size)
+// PurgeCalculations permanently removes the stored calculation results older than the retention
+func (s *PackCalculatorService) PurgeCalculations(ctx context.Context, retention time.Duration) (int64, error) {
+	return s.calculationUseCase.PurgeCalculations(ctx, retention)
 }
 
-// DeletePackSize deletes a pack size
-func (s *PackCalculatorService) DeletePackSize(id string) error {
-	return s.packSizeUseCase.DeletePackSize(id)
+// PurgeDeletedPackSizes permanently removes the pack sizes deleted longer ago than the retention
+func (s *PackCalculatorService) PurgeDeletedPackSizes(ctx context.Context, retention time.Duration) (int64, error) {
+	return s.packSizeUseCase.PurgeDeletedPackSizes(ctx, retention)
 }
 
 // CalculatePacksForOrder calculates the optimal pack combination for an order
-func (s *PackCalculatorService) CalculatePacksForOrder(itemsOrdered int) (*entities.CalculationResult, error) {
-	return s.calculationUseCase.CalculatePacksForOrder(itemsOrdered)
+func (s *PackCalculatorService) CalculatePacksForOrder(
+	ctx context.Context,
+	itemsOrdered int,
+	options entities.CalculationOptions,
+) (*entities.CalculationResult, error) {
+	return s.calculationUseCase.CalculatePacksForOrder(ctx, itemsOrdered, options)
+}
+
+// ConsolidateOrders calculates the combined and separate packings for several orders
+func (s *PackCalculatorService) ConsolidateOrders(ctx context.Context, orders []entities.Order) (*entities.ConsolidationResult, error) {
+	return s.consolidationUseCase.ConsolidateOrders(ctx, orders)
+}
+
+// PackOrderIntoBoxes calculates the optimal packs for an order and assigns them to shipping boxes
+func (s *PackCalculatorService) PackOrderIntoBoxes(ctx context.Context, itemsOrdered int, boxes []entities.Box) (*entities.BoxPackingResult, error) {
+	return s.boxPackingUseCase.PackOrderIntoBoxes(ctx, itemsOrdered, boxes)
+}
+
+// CalculatePackingTable calculates the packings of every step-th quantity of a range
+func (s *PackCalculatorService) CalculatePackingTable(ctx context.Context, from, to, step int) (*entities.CalculationTable, error) {
+	return s.calculationUseCase.CalculatePackingTable(ctx, from, to, step)
+}
+
+// VerifyPacking checks a proposed packing of an order against the business rules
+func (s *PackCalculatorService) VerifyPacking(
+	ctx context.Context,
+	itemsOrdered int,
+	proposed map[int]float64,
+) (*entities.PackingVerification, error) {
+	return s.calculationUseCase.VerifyPacking(ctx, itemsOrdered, proposed)
+}
+
+// ComparePackSets compares the packings of a quantity distribution with two pack size sets
+func (s *PackCalculatorService) ComparePackSets(
+	ctx context.Context,
+	current, candidate []int,
+	demand []entities.QuantityDemand,
+) (*entities.PackSetComparison, error) {
+	return s.comparisonUseCase.ComparePackSets(ctx, current, candidate, demand)
+}
+
+// GetCalculationByID retrieves a stored calculation result by ID
+func (s *PackCalculatorService) GetCalculationByID(ctx context.Context, id string) (*entities.CalculationResult, error) {
+	return s.calculationUseCase.GetCalculationByID(ctx, id)
+}
+
+// GeneratePackingSlip generates the pick list and packing slip of a stored calculation
+func (s *PackCalculatorService) GeneratePackingSlip(ctx context.Context, calculationID string, format entities.DocumentFormat) (*entities.Document, error) {
+	return s.packingSlipUseCase.GeneratePackingSlip(ctx, calculationID, format)
+}
+
+// ImportShippingRates replaces the carrier rate table
+func (s *PackCalculatorService) ImportShippingRates(ctx context.Context, rates []*entities.ShippingRate) ([]*entities.ShippingRate, error) {
+	return s.shippingRateUseCase.ImportShippingRates(ctx, rates)
+}
+
+// GetAllShippingRates retrieves the carrier rate table
+func (s *PackCalculatorService) GetAllShippingRates(ctx context.Context) ([]*entities.ShippingRate, error) {
+	return s.shippingRateUseCase.GetAllShippingRates(ctx)
 }
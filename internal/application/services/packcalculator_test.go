@@ -1,14 +1,18 @@
 package services
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 
 	"go-pack-calculator/internal/domain/entities"
+	domainerrors "go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/ports/secondary"
 )
 
 // Mock repository for testing
@@ -20,39 +24,166 @@ type mockPackSizeRepository struct {
 	totalCount     int64
 }
 
-func (m *mockPackSizeRepository) Create(packSize *entities.PackSize) (*entities.PackSize, error) {
+// Mock transaction manager running the work on the repository directly
+type mockTransactionManager struct {
+	repository secondary.PackSizeRepository
+}
+
+func (m *mockTransactionManager) WithinTransaction(ctx context.Context, work func(tx secondary.Transaction) error) error {
+	return work(m)
+}
+
+func (m *mockTransactionManager) PackSizes() secondary.PackSizeRepository {
+	return m.repository
+}
+
+func (m *mockTransactionManager) Outbox() secondary.OutboxRepository {
+	return mockOutboxRepository{}
+}
+
+// Mock outbox discarding the events
+type mockOutboxRepository struct{}
+
+func (m mockOutboxRepository) Add(ctx context.Context, events ...*entities.PackSizeEvent) error {
+	return nil
+}
+
+func (m mockOutboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*entities.OutboxEntry, error) {
+	return nil, nil
+}
+
+func (m mockOutboxRepository) MarkDelivered(ctx context.Context, id string) error {
+	return nil
+}
+
+func (m mockOutboxRepository) MarkFailed(ctx context.Context, id string, reason string, nextAttemptAt time.Time) error {
+	return nil
+}
+
+func (m mockOutboxRepository) MarkDead(ctx context.Context, id string, reason string) error {
+	return nil
+}
+
+func (m *mockPackSizeRepository) Create(ctx context.Context, packSize *entities.PackSize) (*entities.PackSize, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
 	return m.packSize, nil
 }
 
-func (m *mockPackSizeRepository) FindAll() ([]*entities.PackSize, error) {
+func (m *mockPackSizeRepository) FindAll(ctx context.Context) ([]*entities.PackSize, error) {
 	return m.packSizes, m.err
 }
 
-func (m *mockPackSizeRepository) FindAllPaginated(page, limit int64) ([]*entities.PackSize, int64, error) {
+func (m *mockPackSizeRepository) FindAllPaginated(ctx context.Context, page, limit int64) ([]*entities.PackSize, int64, error) {
 	if m.err != nil {
 		return nil, 0, m.err
 	}
 	return m.paginatedItems, m.totalCount, nil
 }
 
-func (m *mockPackSizeRepository) FindByID(id string) (*entities.PackSize, error) {
+func (m *mockPackSizeRepository) FindByID(ctx context.Context, id string) (*entities.PackSize, error) {
 	return m.packSize, m.err
 }
 
-func (m *mockPackSizeRepository) Update(packSize *entities.PackSize) (*entities.PackSize, error) {
+func (m *mockPackSizeRepository) Update(ctx context.Context, packSize *entities.PackSize) (*entities.PackSize, error) {
 	if m.err != nil {
 		return nil, m.err
 	}
 	return m.packSize, nil
 }
 
-func (m *mockPackSizeRepository) Delete(id string) error {
+func (m *mockPackSizeRepository) Delete(ctx context.Context, id string, version int64) error {
 	return m.err
 }
 
+func (m *mockPackSizeRepository) FindDeleted(ctx context.Context) ([]*entities.PackSize, error) {
+	return m.packSizes, m.err
+}
+
+func (m *mockPackSizeRepository) Restore(ctx context.Context, id string) (*entities.PackSize, error) {
+	return m.packSize, m.err
+}
+
+func (m *mockPackSizeRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	return 0, m.err
+}
+
+// Mock calculation repository for testing
+type mockCalculationRepository struct {
+	results map[string]*entities.CalculationResult
+	err     error
+}
+
+func newMockCalculationRepository() *mockCalculationRepository {
+	return &mockCalculationRepository{results: make(map[string]*entities.CalculationResult)}
+}
+
+func (m *mockCalculationRepository) Create(ctx context.Context, result *entities.CalculationResult) (*entities.CalculationResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if result.ID == "" {
+		result.ID = "calc-id"
+	}
+	m.results[result.ID] = result
+	return result, nil
+}
+
+func (m *mockCalculationRepository) FindByID(ctx context.Context, id string) (*entities.CalculationResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	result, ok := m.results[id]
+	if !ok {
+		return nil, domainerrors.ErrCalculationNotFound
+	}
+	return result, nil
+}
+
+func (m *mockCalculationRepository) PurgeBefore(ctx context.Context, before time.Time) (int64, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	var purged int64
+	for id, result := range m.results {
+		if result.CreatedAt.Before(before) {
+			delete(m.results, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// Mock shipping rate repository for testing
+type mockShippingRateRepository struct {
+	rates []*entities.ShippingRate
+	err   error
+}
+
+func (m *mockShippingRateRepository) ReplaceAll(ctx context.Context, rates []*entities.ShippingRate) ([]*entities.ShippingRate, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.rates = rates
+	return rates, nil
+}
+
+func (m *mockShippingRateRepository) FindAll(ctx context.Context) ([]*entities.ShippingRate, error) {
+	return m.rates, m.err
+}
+
+// Mock packing slip renderer for testing
+type mockPackingSlipRenderer struct{}
+
+func (m *mockPackingSlipRenderer) Format() entities.DocumentFormat {
+	return entities.DocumentFormatText
+}
+
+func (m *mockPackingSlipRenderer) Render(ctx context.Context, slip *entities.PackingSlip) (*entities.Document, error) {
+	return &entities.Document{Filename: "slip.txt", ContentType: "text/plain", Content: []byte(slip.CalculationID)}, nil
+}
+
 func TestPackCalculatorService_CreatePackSize(t *testing.T) {
 	// Create test pack size
 	testPackSize, _ := entities.NewPackSize(100)
@@ -93,10 +224,10 @@ func TestPackCalculatorService_CreatePackSize(t *testing.T) {
 			}
 
 			// Create service
-			service := NewPackCalculatorService(mockRepo)
+			service := NewPackCalculatorService(mockRepo, &mockTransactionManager{mockRepo}, newMockCalculationRepository(), &mockShippingRateRepository{})
 
 			// Call the method
-			result, err := service.CreatePackSize(tt.size)
+			result, err := service.CreatePackSize(context.Background(), tt.size, entities.PackSizeAttributes{})
 
 			// Check error
 			if (err != nil) != tt.wantErr {
@@ -155,10 +286,10 @@ func TestPackCalculatorService_GetAllPackSizes(t *testing.T) {
 			}
 
 			// Create service
-			service := NewPackCalculatorService(mockRepo)
+			service := NewPackCalculatorService(mockRepo, &mockTransactionManager{mockRepo}, newMockCalculationRepository(), &mockShippingRateRepository{})
 
 			// Call the method
-			result, err := service.GetAllPackSizes()
+			result, err := service.GetAllPackSizes(context.Background())
 
 			// Check error
 			if (err != nil) != tt.wantErr {
@@ -246,10 +377,10 @@ func TestPackCalculatorService_GetAllPackSizesWithPagination(t *testing.T) {
 			}
 
 			// Create service
-			service := NewPackCalculatorService(mockRepo)
+			service := NewPackCalculatorService(mockRepo, &mockTransactionManager{mockRepo}, newMockCalculationRepository(), &mockShippingRateRepository{})
 
 			// Call the method
-			result, err := service.GetAllPackSizesWithPagination(tt.page, tt.limit)
+			result, err := service.GetAllPackSizesWithPagination(context.Background(), tt.page, tt.limit)
 
 			// Check error
 			if (err != nil) != tt.wantErr {
@@ -309,10 +440,10 @@ func TestPackCalculatorService_GetPackSizeByID(t *testing.T) {
 			}
 
 			// Create service
-			service := NewPackCalculatorService(mockRepo)
+			service := NewPackCalculatorService(mockRepo, &mockTransactionManager{mockRepo}, newMockCalculationRepository(), &mockShippingRateRepository{})
 
 			// Call the method
-			result, err := service.GetPackSizeByID(tt.id)
+			result, err := service.GetPackSizeByID(context.Background(), tt.id)
 
 			// Check error
 			if (err != nil) != tt.wantErr {
@@ -377,10 +508,10 @@ func TestPackCalculatorService_UpdatePackSize(t *testing.T) {
 			}
 
 			// Create service
-			service := NewPackCalculatorService(mockRepo)
+			service := NewPackCalculatorService(mockRepo, &mockTransactionManager{mockRepo}, newMockCalculationRepository(), &mockShippingRateRepository{})
 
 			// Call the method
-			result, err := service.UpdatePackSize(tt.id, tt.size)
+			result, err := service.UpdatePackSize(context.Background(), tt.id, 0, tt.size, entities.PackSizeAttributes{})
 
 			// Check error
 			if (err != nil) != tt.wantErr {
@@ -424,14 +555,15 @@ func TestPackCalculatorService_DeletePackSize(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			// Create mock repository
 			mockRepo := &mockPackSizeRepository{
-				err: tt.mockErr,
+				packSize: &entities.PackSize{ID: tt.id, Size: 250},
+				err:      tt.mockErr,
 			}
 
 			// Create service
-			service := NewPackCalculatorService(mockRepo)
+			service := NewPackCalculatorService(mockRepo, &mockTransactionManager{mockRepo}, newMockCalculationRepository(), &mockShippingRateRepository{})
 
 			// Call the method
-			err := service.DeletePackSize(tt.id)
+			err := service.DeletePackSize(context.Background(), tt.id, 0)
 
 			// Check error
 			if (err != nil) != tt.wantErr {
@@ -483,10 +615,10 @@ func TestPackCalculatorService_CalculatePacksForOrder(t *testing.T) {
 			}
 
 			// Create service
-			service := NewPackCalculatorService(mockRepo)
+			service := NewPackCalculatorService(mockRepo, &mockTransactionManager{mockRepo}, newMockCalculationRepository(), &mockShippingRateRepository{})
 
 			// Call the method
-			result, err := service.CalculatePacksForOrder(tt.itemsOrdered)
+			result, err := service.CalculatePacksForOrder(context.Background(), tt.itemsOrdered, entities.CalculationOptions{})
 
 			// Check error
 			if (err != nil) != tt.wantErr {
@@ -508,3 +640,212 @@ func TestPackCalculatorService_CalculatePacksForOrder(t *testing.T) {
 		})
 	}
 }
+
+// Mock packing table repository for testing
+type mockPackingTableRepository struct {
+	table *entities.PackingTable
+}
+
+func (m *mockPackingTableRepository) Save(ctx context.Context, table *entities.PackingTable) error {
+	m.table = table
+	return nil
+}
+
+func (m *mockPackingTableRepository) FindByKey(ctx context.Context, key string) (*entities.PackingTable, error) {
+	if m.table == nil || m.table.Key() != key {
+		return nil, domainerrors.ErrPackingTableNotFound
+	}
+	return m.table, nil
+}
+
+func TestPackCalculatorService_RebuildPackingTable(t *testing.T) {
+	ps1, _ := entities.NewPackSize(250)
+	ps2, _ := entities.NewPackSize(500)
+	tableRepo := &mockPackingTableRepository{}
+
+	service := NewPackCalculatorService(
+		&mockPackSizeRepository{packSizes: []*entities.PackSize{ps1, ps2}},
+		&mockTransactionManager{},
+		newMockCalculationRepository(),
+		&mockShippingRateRepository{},
+	)
+	service.EnablePackingTable(tableRepo, 1000)
+
+	table, err := service.RebuildPackingTable(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, "500,250", table.Key())
+	assert.Same(t, table, tableRepo.table)
+
+	result, err := service.CalculatePacksForOrder(context.Background(), 751, entities.CalculationOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, map[int]int{500: 2}, result.Packs)
+}
+
+func TestPackCalculatorService_ConsolidateOrders(t *testing.T) {
+	// Create test pack sizes
+	ps1, _ := entities.NewPackSize(250)
+	ps2, _ := entities.NewPackSize(500)
+	testPackSizes := []*entities.PackSize{ps1, ps2}
+
+	tests := []struct {
+		name    string
+		orders  []entities.Order
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:    "Success",
+			orders:  []entities.Order{{ID: "a", ItemsOrdered: 1}, {ID: "b", ItemsOrdered: 1}},
+			mockErr: nil,
+			wantErr: false,
+		},
+		{
+			name:    "Repository error",
+			orders:  []entities.Order{{ID: "a", ItemsOrdered: 1}},
+			mockErr: errors.New("repository error"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create mock repository
+			mockRepo := &mockPackSizeRepository{
+				packSizes: testPackSizes,
+				err:       tt.mockErr,
+			}
+
+			// Create service
+			service := NewPackCalculatorService(mockRepo, &mockTransactionManager{mockRepo}, newMockCalculationRepository(), &mockShippingRateRepository{})
+
+			// Call the method
+			result, err := service.ConsolidateOrders(context.Background(), tt.orders)
+
+			// Check error
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ConsolidateOrders() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			// If expecting an error, don't check the result
+			if tt.wantErr {
+				return
+			}
+
+			require.NotNil(t, result)
+			assert.Equal(t, map[int]int{250: 1}, result.Combined.Packs)
+			assert.Len(t, result.Allocations, len(tt.orders))
+		})
+	}
+}
+
+func TestPackCalculatorService_PackOrderIntoBoxes(t *testing.T) {
+	// Create test pack size with dimensions
+	ps, _ := entities.NewPackSize(250)
+	ps.Dimensions = entities.Dimensions{Length: 10, Width: 10, Height: 10}
+	mockRepo := &mockPackSizeRepository{packSizes: []*entities.PackSize{ps}}
+
+	// Create service
+	service := NewPackCalculatorService(mockRepo, &mockTransactionManager{mockRepo}, newMockCalculationRepository(), &mockShippingRateRepository{})
+
+	// Call the method
+	result, err := service.PackOrderIntoBoxes(context.Background(), 300, []entities.Box{
+		{Name: "M", Dimensions: entities.Dimensions{Length: 20, Width: 10, Height: 10}, MaxWeight: 10},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, map[int]int{250: 2}, result.Calculation.Packs)
+	require.Len(t, result.Boxes, 1)
+	assert.Equal(t, map[int]int{250: 2}, result.Boxes[0].Packs)
+	assert.Len(t, result.Boxes[0].Placements, 2)
+}
+
+func TestPackCalculatorService_GeneratePackingSlip(t *testing.T) {
+	// Create test pack sizes
+	ps1, _ := entities.NewPackSize(250)
+	ps2, _ := entities.NewPackSize(500)
+	mockRepo := &mockPackSizeRepository{packSizes: []*entities.PackSize{ps1, ps2}}
+
+	// Create service
+	service := NewPackCalculatorService(mockRepo, &mockTransactionManager{mockRepo}, newMockCalculationRepository(), &mockShippingRateRepository{}, &mockPackingSlipRenderer{})
+
+	// Calculate and store a result
+	result, err := service.CalculatePacksForOrder(context.Background(), 251, entities.CalculationOptions{})
+	require.NoError(t, err)
+	require.NotEmpty(t, result.ID)
+
+	// Retrieve the stored result
+	stored, err := service.GetCalculationByID(context.Background(), result.ID)
+	require.NoError(t, err)
+	assert.Equal(t, result.Packs, stored.Packs)
+
+	// Generate the packing slip
+	document, err := service.GeneratePackingSlip(context.Background(), result.ID, entities.DocumentFormatText)
+	require.NoError(t, err)
+	assert.Equal(t, result.ID, string(document.Content))
+
+	// Unsupported format
+	_, err = service.GeneratePackingSlip(context.Background(), result.ID, entities.DocumentFormatPDF)
+	assert.ErrorIs(t, err, domainerrors.ErrUnsupportedFormat)
+
+	// Unknown calculation
+	_, err = service.GeneratePackingSlip(context.Background(), "unknown-id", entities.DocumentFormatText)
+	assert.ErrorIs(t, err, domainerrors.ErrCalculationNotFound)
+}
+
+func TestPackCalculatorService_ImportShippingRates(t *testing.T) {
+	validRate, _ := entities.NewShippingRate("DHL", 20, 5, 12.5)
+	invalidRate := &entities.ShippingRate{Carrier: "DHL", MaxWeight: 20, MaxParcels: 0, Price: 12.5}
+
+	tests := []struct {
+		name    string
+		rates   []*entities.ShippingRate
+		mockErr error
+		wantErr bool
+	}{
+		{
+			name:    "Success",
+			rates:   []*entities.ShippingRate{validRate},
+			mockErr: nil,
+			wantErr: false,
+		},
+		{
+			name:    "Invalid rate",
+			rates:   []*entities.ShippingRate{validRate, invalidRate},
+			mockErr: nil,
+			wantErr: true,
+		},
+		{
+			name:    "Repository error",
+			rates:   []*entities.ShippingRate{validRate},
+			mockErr: errors.New("repository error"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create service
+			rateRepo := &mockShippingRateRepository{err: tt.mockErr}
+			service := NewPackCalculatorService(&mockPackSizeRepository{}, &mockTransactionManager{}, newMockCalculationRepository(), rateRepo)
+
+			// Call the method
+			_, err := service.ImportShippingRates(context.Background(), tt.rates)
+
+			// Check error
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ImportShippingRates() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			// If expecting an error, don't check the result
+			if tt.wantErr {
+				return
+			}
+
+			rates, err := service.GetAllShippingRates(context.Background())
+			require.NoError(t, err)
+			assert.Len(t, rates, len(tt.rates))
+		})
+	}
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"context"
+
+	"go-pack-calculator/internal/application/usecases"
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/ports/primary"
+	"go-pack-calculator/internal/ports/secondary"
+	"go-pack-calculator/internal/shared/types"
+)
+
+// PackSizeAuditService implements the PackSizeAuditService interface
+type PackSizeAuditService struct {
+	auditUseCase *usecases.PackSizeAuditUseCase
+}
+
+// Ensure PackSizeAuditService implements the primary interface
+var _ primary.PackSizeAuditService = (*PackSizeAuditService)(nil)
+
+// NewPackSizeAuditService creates a new pack size audit service
+func NewPackSizeAuditService(auditRepository secondary.PackSizeAuditRepository, packSizeRepository secondary.PackSizeRepository) *PackSizeAuditService {
+	return &PackSizeAuditService{
+		auditUseCase: usecases.NewPackSizeAuditUseCase(auditRepository, packSizeRepository),
+	}
+}
+
+// GetPackSizeHistory retrieves the audit entries of a pack size, oldest first
+func (s *PackSizeAuditService) GetPackSizeHistory(ctx context.Context, id string) ([]*entities.PackSizeAuditEntry, error) {
+	return s.auditUseCase.GetPackSizeHistory(ctx, id)
+}
+
+// GetAuditFeed retrieves the audit entries of every pack size with pagination, newest first
+func (s *PackSizeAuditService) GetAuditFeed(ctx context.Context, page, limit int64) (*types.Pagination, error) {
+	entries, total, err := s.auditUseCase.GetAuditFeed(ctx, page, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if this is the last page
+	isLastPage := (page * limit) >= total
+
+	// Convert to interface slice
+	data := make([]interface{}, len(entries))
+	for i, entry := range entries {
+		data[i] = entry
+	}
+
+	// Create pagination response
+	return types.NewPagination(page, limit, total, isLastPage, data), nil
+}
@@ -0,0 +1,86 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-pack-calculator/internal/domain/entities"
+)
+
+// Mock audit repository for testing
+type mockPackSizeAuditRepository struct {
+	entries []*entities.PackSizeAuditEntry
+	total   int64
+	err     error
+}
+
+func (m *mockPackSizeAuditRepository) FindByPackSizeID(ctx context.Context, packSizeID string) ([]*entities.PackSizeAuditEntry, error) {
+	return m.entries, m.err
+}
+
+func (m *mockPackSizeAuditRepository) FindAllPaginated(ctx context.Context, page, limit int64) ([]*entities.PackSizeAuditEntry, int64, error) {
+	return m.entries, m.total, m.err
+}
+
+func TestPackSizeAuditService_GetAuditFeed(t *testing.T) {
+	packSize, _ := entities.NewPackSize(100)
+	entry := entities.NewPackSizeAuditEntry(entities.AuditActionCreated, nil, packSize, "alice", "req-1")
+
+	tests := []struct {
+		name           string
+		page           int64
+		limit          int64
+		entries        []*entities.PackSizeAuditEntry
+		total          int64
+		mockErr        error
+		wantErr        bool
+		wantIsLastPage bool
+	}{
+		{
+			name:           "Last page",
+			page:           2,
+			limit:          1,
+			entries:        []*entities.PackSizeAuditEntry{entry},
+			total:          2,
+			wantIsLastPage: true,
+		},
+		{
+			name:           "Not last page",
+			page:           1,
+			limit:          1,
+			entries:        []*entities.PackSizeAuditEntry{entry},
+			total:          2,
+			wantIsLastPage: false,
+		},
+		{
+			name:    "Repository error",
+			page:    1,
+			limit:   10,
+			mockErr: errors.New("repository error"),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditRepo := &mockPackSizeAuditRepository{entries: tt.entries, total: tt.total, err: tt.mockErr}
+			service := NewPackSizeAuditService(auditRepo, &mockPackSizeRepository{})
+
+			result, err := service.GetAuditFeed(context.Background(), tt.page, tt.limit)
+
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.page, result.Page)
+			assert.Equal(t, tt.total, result.Total)
+			assert.Equal(t, tt.wantIsLastPage, result.IsLastPage)
+			assert.Equal(t, []interface{}{entry}, result.Items)
+		})
+	}
+}
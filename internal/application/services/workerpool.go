@@ -0,0 +1,155 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/ports/primary"
+)
+
+// CalculationWorkerPool bounds the number of pack calculations running at once. It wraps a
+// CalculationService and a PackSetComparisonService sharing the same workers and rejects
+// calculations that cannot start within the queue timeout.
+type CalculationWorkerPool struct {
+	primary.CalculationService
+	primary.PackSetComparisonService
+	workers      chan struct{}
+	queueTimeout time.Duration
+	retryAfter   time.Duration
+}
+
+// Ensure CalculationWorkerPool implements the CalculationService interface
+var _ primary.CalculationService = (*CalculationWorkerPool)(nil)
+
+// Ensure CalculationWorkerPool implements the PackSetComparisonService interface
+var _ primary.PackSetComparisonService = (*CalculationWorkerPool)(nil)
+
+// NewCalculationWorkerPool creates a worker pool running at most the given number of calculations
+func NewCalculationWorkerPool(
+	calculationService primary.CalculationService,
+	comparisonService primary.PackSetComparisonService,
+	workers int,
+	queueTimeout time.Duration,
+	retryAfter time.Duration,
+) *CalculationWorkerPool {
+	if workers < 1 {
+		workers = 1
+	}
+
+	return &CalculationWorkerPool{
+		CalculationService:       calculationService,
+		PackSetComparisonService: comparisonService,
+		workers:                  make(chan struct{}, workers),
+		queueTimeout:             queueTimeout,
+		retryAfter:               retryAfter,
+	}
+}
+
+// CalculatePacksForOrder calculates the optimal pack combination for an order once a worker is free
+func (p *CalculationWorkerPool) CalculatePacksForOrder(
+	ctx context.Context,
+	itemsOrdered int,
+	options entities.CalculationOptions,
+) (*entities.CalculationResult, error) {
+	if err := p.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer p.release()
+
+	return p.CalculationService.CalculatePacksForOrder(ctx, itemsOrdered, options)
+}
+
+// ConsolidateOrders calculates the combined and separate packings for several orders once a worker is free
+func (p *CalculationWorkerPool) ConsolidateOrders(ctx context.Context, orders []entities.Order) (*entities.ConsolidationResult, error) {
+	if err := p.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer p.release()
+
+	return p.CalculationService.ConsolidateOrders(ctx, orders)
+}
+
+// PackOrderIntoBoxes calculates the optimal packs for an order and assigns them to boxes once a worker is free
+func (p *CalculationWorkerPool) PackOrderIntoBoxes(
+	ctx context.Context,
+	itemsOrdered int,
+	boxes []entities.Box,
+) (*entities.BoxPackingResult, error) {
+	if err := p.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer p.release()
+
+	return p.CalculationService.PackOrderIntoBoxes(ctx, itemsOrdered, boxes)
+}
+
+// CalculatePackingTable calculates the packings of a range of quantities once a worker is free
+func (p *CalculationWorkerPool) CalculatePackingTable(ctx context.Context, from, to, step int) (*entities.CalculationTable, error) {
+	if err := p.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer p.release()
+
+	return p.CalculationService.CalculatePackingTable(ctx, from, to, step)
+}
+
+// VerifyPacking checks a proposed packing against the optimal packing once a worker is free
+func (p *CalculationWorkerPool) VerifyPacking(
+	ctx context.Context,
+	itemsOrdered int,
+	proposed map[int]float64,
+) (*entities.PackingVerification, error) {
+	if err := p.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer p.release()
+
+	return p.CalculationService.VerifyPacking(ctx, itemsOrdered, proposed)
+}
+
+// ComparePackSets compares the packings of a demand with two pack size sets once a worker is free
+func (p *CalculationWorkerPool) ComparePackSets(
+	ctx context.Context,
+	current []int,
+	candidate []int,
+	demand []entities.QuantityDemand,
+) (*entities.PackSetComparison, error) {
+	if err := p.acquire(ctx); err != nil {
+		return nil, err
+	}
+	defer p.release()
+
+	return p.PackSetComparisonService.ComparePackSets(ctx, current, candidate, demand)
+}
+
+// acquire waits up to the queue timeout for a free worker
+func (p *CalculationWorkerPool) acquire(ctx context.Context) error {
+	// Take a free worker without starting a timer
+	select {
+	case p.workers <- struct{}{}:
+		return nil
+	default:
+	}
+
+	timer := time.NewTimer(p.queueTimeout)
+	defer timer.Stop()
+
+	select {
+	case p.workers <- struct{}{}:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return &errors.RetryAfterError{
+			RetryAfter: p.retryAfter,
+			Err:        errors.ErrCalculatorBusy,
+		}
+	}
+}
+
+// release frees the worker taken by acquire
+func (p *CalculationWorkerPool) release() {
+	<-p.workers
+}
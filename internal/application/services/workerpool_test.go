@@ -0,0 +1,154 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-pack-calculator/internal/domain/entities"
+	domainerrors "go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/ports/primary"
+)
+
+// Mock calculation service that blocks until released
+type blockingCalculationService struct {
+	primary.CalculationService
+	primary.PackSetComparisonService
+	started chan struct{}
+	release chan struct{}
+}
+
+func newBlockingCalculationService() *blockingCalculationService {
+	return &blockingCalculationService{
+		started: make(chan struct{}, 10),
+		release: make(chan struct{}),
+	}
+}
+
+func (m *blockingCalculationService) CalculatePacksForOrder(
+	ctx context.Context,
+	itemsOrdered int,
+	options entities.CalculationOptions,
+) (*entities.CalculationResult, error) {
+	m.started <- struct{}{}
+	<-m.release
+	return entities.NewCalculationResult(itemsOrdered, map[int]int{itemsOrdered: 1}), nil
+}
+
+func TestCalculationWorkerPool_CalculatePacksForOrder(t *testing.T) {
+	t.Run("Saturated pool asks to retry", func(t *testing.T) {
+		calculationService := newBlockingCalculationService()
+		pool := NewCalculationWorkerPool(calculationService, calculationService, 1, 10*time.Millisecond, 3*time.Second)
+
+		// Occupy the only worker
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, _ = pool.CalculatePacksForOrder(context.Background(), 1, entities.CalculationOptions{})
+		}()
+		<-calculationService.started
+
+		_, err := pool.CalculatePacksForOrder(context.Background(), 2, entities.CalculationOptions{})
+
+		var retryErr *domainerrors.RetryAfterError
+		require.True(t, errors.As(err, &retryErr))
+		assert.Equal(t, 3*time.Second, retryErr.RetryAfter)
+		assert.True(t, errors.Is(err, domainerrors.ErrCalculatorBusy))
+
+		close(calculationService.release)
+		<-done
+	})
+
+	t.Run("Queued calculation runs when a worker is freed", func(t *testing.T) {
+		calculationService := newBlockingCalculationService()
+		pool := NewCalculationWorkerPool(calculationService, calculationService, 1, time.Second, time.Second)
+
+		// Occupy the only worker, then free it while the second calculation waits
+		go func() {
+			_, _ = pool.CalculatePacksForOrder(context.Background(), 1, entities.CalculationOptions{})
+		}()
+		<-calculationService.started
+		go func() {
+			time.Sleep(20 * time.Millisecond)
+			close(calculationService.release)
+		}()
+
+		result, err := pool.CalculatePacksForOrder(context.Background(), 2, entities.CalculationOptions{})
+		require.NoError(t, err)
+		assert.Equal(t, 2, result.ItemsOrdered)
+	})
+
+	t.Run("Canceled caller stops waiting", func(t *testing.T) {
+		calculationService := newBlockingCalculationService()
+		pool := NewCalculationWorkerPool(calculationService, calculationService, 1, time.Second, time.Second)
+
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			_, _ = pool.CalculatePacksForOrder(context.Background(), 1, entities.CalculationOptions{})
+		}()
+		<-calculationService.started
+
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+
+		_, err := pool.CalculatePacksForOrder(ctx, 2, entities.CalculationOptions{})
+		assert.ErrorIs(t, err, context.Canceled)
+
+		close(calculationService.release)
+		<-done
+	})
+}
+
+func TestCalculationWorkerPool_SharedWorkers(t *testing.T) {
+	calculationService := newBlockingCalculationService()
+	pool := NewCalculationWorkerPool(calculationService, calculationService, 1, 10*time.Millisecond, time.Second)
+
+	// Occupy the only worker with a calculation
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		_, _ = pool.CalculatePacksForOrder(context.Background(), 1, entities.CalculationOptions{})
+	}()
+	<-calculationService.started
+
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{
+			name: "ConsolidateOrders",
+			call: func() error {
+				_, err := pool.ConsolidateOrders(context.Background(), []entities.Order{{ItemsOrdered: 1}})
+				return err
+			},
+		},
+		{
+			name: "PackOrderIntoBoxes",
+			call: func() error {
+				_, err := pool.PackOrderIntoBoxes(context.Background(), 1, []entities.Box{{Name: "small", MaxWeight: 1}})
+				return err
+			},
+		},
+		{
+			name: "ComparePackSets",
+			call: func() error {
+				_, err := pool.ComparePackSets(context.Background(), nil, []int{250}, []entities.QuantityDemand{{ItemsOrdered: 1, Frequency: 1}})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, tt.call(), domainerrors.ErrCalculatorBusy)
+		})
+	}
+
+	close(calculationService.release)
+	<-done
+}
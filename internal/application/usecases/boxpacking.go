@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/domain/services"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// BoxPackingUseCase represents the application use cases for assigning packs to shipping boxes
+type BoxPackingUseCase struct {
+	repository         secondary.PackSizeRepository
+	calculationUseCase *CalculationUseCase
+	boxPackingService  *services.BoxPackingService
+}
+
+// NewBoxPackingUseCase creates a new box packing use case
+func NewBoxPackingUseCase(repository secondary.PackSizeRepository, calculationUseCase *CalculationUseCase) *BoxPackingUseCase {
+	return &BoxPackingUseCase{
+		repository:         repository,
+		calculationUseCase: calculationUseCase,
+		boxPackingService:  services.NewBoxPackingService(),
+	}
+}
+
+// PackOrderIntoBoxes calculates the optimal packs for an order and assigns them to the fewest boxes
+func (uc *BoxPackingUseCase) PackOrderIntoBoxes(ctx context.Context, itemsOrdered int, boxes []entities.Box) (*entities.BoxPackingResult, error) {
+	// Validate the box catalog before storing a calculation
+	if len(boxes) == 0 {
+		return nil, errors.ErrNoBoxes
+	}
+	for i := range boxes {
+		if err := boxes[i].Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	// Calculate optimal packs
+	calculation, err := uc.calculationUseCase.CalculatePacksForOrder(ctx, itemsOrdered, entities.CalculationOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the dimensions and weights of the packs
+	packSizes, err := findActivePackSizes(ctx, uc.repository, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	_, packsBySize := packSizesBySize(packSizes)
+
+	assignments, err := uc.boxPackingService.Pack(calculation.Packs, packsBySize, boxes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &entities.BoxPackingResult{
+		Calculation: calculation,
+		Boxes:       assignments,
+	}, nil
+}
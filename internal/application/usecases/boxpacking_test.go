@@ -0,0 +1,104 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go-pack-calculator/internal/domain/entities"
+	domainerrors "go-pack-calculator/internal/domain/errors"
+)
+
+func TestBoxPackingUseCase_PackOrderIntoBoxes(t *testing.T) {
+	// Create pack sizes with dimensions
+	small := createTestPackSize(t, 250)
+	small.Weight = 1
+	small.Dimensions = entities.Dimensions{Length: 10, Width: 10, Height: 10}
+	large := createTestPackSize(t, 500)
+	large.Weight = 2
+	large.Dimensions = entities.Dimensions{Length: 20, Width: 10, Height: 10}
+	unmeasured := createTestPackSize(t, 250)
+
+	box := entities.Box{Name: "M", Dimensions: entities.Dimensions{Length: 20, Width: 20, Height: 10}, MaxWeight: 10}
+
+	tests := []struct {
+		name      string
+		packSizes []*entities.PackSize
+		boxes     []entities.Box
+		wantPacks []map[int]int
+		wantErr   error
+		wantSaved bool
+	}{
+		{
+			name:      "Packs assigned to one box",
+			packSizes: []*entities.PackSize{small, large},
+			boxes:     []entities.Box{box},
+			wantPacks: []map[int]int{{500: 1, 250: 1}},
+			wantSaved: true,
+		},
+		{
+			name:      "No boxes",
+			packSizes: []*entities.PackSize{small, large},
+			boxes:     nil,
+			wantErr:   domainerrors.ErrNoBoxes,
+		},
+		{
+			name:      "Invalid box is rejected before calculating",
+			packSizes: []*entities.PackSize{small, large},
+			boxes:     []entities.Box{{Name: "X"}},
+			wantErr:   domainerrors.ErrInvalidBox,
+		},
+		{
+			name:      "Pack size without dimensions",
+			packSizes: []*entities.PackSize{unmeasured},
+			boxes:     []entities.Box{box},
+			wantErr:   domainerrors.ErrMissingDimensions,
+			wantSaved: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create use case with mock repositories
+			repository := &mockPackSizeRepository{packSizes: tt.packSizes}
+			calculationRepository := newMockCalculationRepository()
+			useCase := NewBoxPackingUseCase(
+				repository,
+				NewCalculationUseCase(repository, calculationRepository, &mockShippingRateRepository{}),
+			)
+
+			// Call the method
+			result, err := useCase.PackOrderIntoBoxes(context.Background(), 501, tt.boxes)
+
+			// Check error
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("PackOrderIntoBoxes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if saved := len(calculationRepository.results) > 0; saved != tt.wantSaved {
+				t.Errorf("PackOrderIntoBoxes() stored calculation = %v, want %v", saved, tt.wantSaved)
+			}
+
+			// If expecting an error, don't check the result
+			if tt.wantErr != nil {
+				return
+			}
+
+			if result.Calculation.ID == "" {
+				t.Errorf("PackOrderIntoBoxes() calculation has no ID")
+			}
+
+			if len(result.Boxes) != len(tt.wantPacks) {
+				t.Fatalf("PackOrderIntoBoxes() returned %d boxes, want %d", len(result.Boxes), len(tt.wantPacks))
+			}
+
+			for i, assignment := range result.Boxes {
+				if !reflect.DeepEqual(assignment.Packs, tt.wantPacks[i]) {
+					t.Errorf("PackOrderIntoBoxes() box %d packs = %v, want %v", i, assignment.Packs, tt.wantPacks[i])
+				}
+			}
+		})
+	}
+}
@@ -1,6 +1,11 @@
 package usecases
 
 import (
+	"context"
+	stderr "errors"
+	"fmt"
+	"time"
+
 	"go-pack-calculator/internal/domain/entities"
 	"go-pack-calculator/internal/domain/errors"
 	"go-pack-calculator/internal/domain/services"
@@ -9,27 +14,81 @@ import (
 
 // CalculationUseCase represents the application use cases for pack calculation
 type CalculationUseCase struct {
-	repository        secondary.PackSizeRepository
-	calculatorService *services.PackCalculatorService
+	repository             secondary.PackSizeRepository
+	calculationRepository  secondary.CalculationRepository
+	shippingRateRepository secondary.ShippingRateRepository
+	calculatorService      *services.PackCalculatorService
+	shippingCostService    *services.ShippingCostService
+	limits                 CalculationLimits
+	packingTable           *PackingTableUseCase
+}
+
+// CalculationLimits bounds the estimated cost of a single calculation, a zero value disables a limit
+type CalculationLimits struct {
+	MaxMemoryBytes int64
+	MaxDuration    time.Duration
+}
+
+// Allows reports whether a calculation with the given estimate stays within the limits
+func (l CalculationLimits) Allows(estimate entities.CalculationEstimate) bool {
+	if l.MaxMemoryBytes > 0 && estimate.MemoryBytes > l.MaxMemoryBytes {
+		return false
+	}
+	if l.MaxDuration > 0 && estimate.Duration > l.MaxDuration {
+		return false
+	}
+
+	return true
 }
 
 // NewCalculationUseCase creates a new calculation use case
-func NewCalculationUseCase(repository secondary.PackSizeRepository) *CalculationUseCase {
+func NewCalculationUseCase(
+	repository secondary.PackSizeRepository,
+	calculationRepository secondary.CalculationRepository,
+	shippingRateRepository secondary.ShippingRateRepository,
+) *CalculationUseCase {
 	return &CalculationUseCase{
-		repository:        repository,
-		calculatorService: services.NewPackCalculatorService(),
+		repository:             repository,
+		calculationRepository:  calculationRepository,
+		shippingRateRepository: shippingRateRepository,
+		calculatorService:      services.NewPackCalculatorService(),
+		shippingCostService:    services.NewShippingCostService(),
 	}
 }
 
+// SetLimits sets the computation budget of a single calculation
+func (uc *CalculationUseCase) SetLimits(limits CalculationLimits) {
+	uc.limits = limits
+}
+
+// SetPackingTable serves the packings of the fewest packs from the precomputed table when it covers the order
+func (uc *CalculationUseCase) SetPackingTable(packingTable *PackingTableUseCase) {
+	uc.packingTable = packingTable
+}
+
 // CalculatePacksForOrder calculates the optimal pack combination for an order
-func (uc *CalculationUseCase) CalculatePacksForOrder(itemsOrdered int) (*entities.CalculationResult, error) {
+func (uc *CalculationUseCase) CalculatePacksForOrder(
+	ctx context.Context,
+	itemsOrdered int,
+	options entities.CalculationOptions,
+) (*entities.CalculationResult, error) {
 	// Validate input
 	if itemsOrdered <= 0 {
 		return nil, errors.ErrInvalidItemsOrdered
 	}
+	if !options.Objective.IsValid() {
+		return nil, &errors.ValidationError{
+			Field: "objective",
+			Err:   errors.ErrInvalidObjective,
+		}
+	}
 
-	// Get all pack sizes
-	packSizes, err := uc.repository.FindAll()
+	// Get the pack sizes active and valid at the requested time
+	asOf := options.AsOf
+	if asOf.IsZero() {
+		asOf = time.Now()
+	}
+	packSizes, err := findActivePackSizes(ctx, uc.repository, asOf)
 	if err != nil {
 		return nil, err
 	}
@@ -40,19 +99,293 @@ func (uc *CalculationUseCase) CalculatePacksForOrder(itemsOrdered int) (*entitie
 	}
 
 	// Extract pack size values
-	sizes := make([]int, len(packSizes))
-	for i, ps := range packSizes {
-		sizes[i] = ps.Size
-	}
+	sizes, packsBySize := packSizesBySize(packSizes)
 
 	// Calculate optimal packs
-	packs, err := uc.calculatorService.CalculateOptimalPacks(itemsOrdered, sizes)
+	packs, err := uc.calculatePacks(ctx, itemsOrdered, sizes, packsBySize, options)
 	if err != nil {
 		return nil, err
 	}
 
 	// Create calculation result
+	result := newCalculationResult(itemsOrdered, packs, packsBySize)
+
+	// Estimate shipping costs from the carrier rate tables
+	rates, err := uc.shippingRateRepository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	result.ShippingEstimates = uc.shippingCostService.Estimate(result.TotalWeight, result.TotalPacks(), rates)
+
+	// Store the result so it can be referenced later
+	return uc.calculationRepository.Create(ctx, result)
+}
+
+// VerifyPacking checks a proposed packing of an order against the business rules, returning the
+// optimal packing for comparison. The results are not stored.
+func (uc *CalculationUseCase) VerifyPacking(
+	ctx context.Context,
+	itemsOrdered int,
+	proposed map[int]float64,
+) (*entities.PackingVerification, error) {
+	// Validate input
+	if itemsOrdered <= 0 {
+		return nil, errors.ErrInvalidItemsOrdered
+	}
+
+	// Get the active pack sizes
+	packSizes, err := findActivePackSizes(ctx, uc.repository, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(packSizes) == 0 {
+		return nil, errors.ErrNoPackSizesAvailable
+	}
+	sizes, packsBySize := packSizesBySize(packSizes)
+
+	// Calculate the optimal packs the proposal is compared with
+	optimal, err := uc.calculatePacks(ctx, itemsOrdered, sizes, packsBySize, entities.CalculationOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	verification := uc.calculatorService.VerifyPacking(itemsOrdered, proposed, sizes, optimal)
+	verification.Optimal = newCalculationResult(itemsOrdered, optimal, packsBySize)
+	if verification.Proposed != nil {
+		verification.Proposed = newCalculationResult(itemsOrdered, verification.Proposed.Packs, packsBySize)
+	}
+
+	return verification, nil
+}
+
+// maxTableRows bounds the number of quantities of a calculation table
+const maxTableRows = 10000
+
+// CalculatePackingTable calculates the packings of every step-th quantity from one quantity to
+// another in a single pass, using the packing table when it covers the range. The results are
+// not stored.
+func (uc *CalculationUseCase) CalculatePackingTable(ctx context.Context, from, to, step int) (*entities.CalculationTable, error) {
+	// Validate input
+	switch {
+	case from <= 0 || to < from:
+		return nil, &errors.ValidationError{
+			Field: "to",
+			Err:   fmt.Errorf("%w: expected 0 < from <= to", errors.ErrInvalidRange),
+		}
+	case step <= 0:
+		return nil, &errors.ValidationError{
+			Field: "step",
+			Err:   fmt.Errorf("%w: step must be greater than zero", errors.ErrInvalidRange),
+		}
+	case (to-from)/step+1 > maxTableRows:
+		return nil, &errors.ValidationError{
+			Field: "step",
+			Err:   fmt.Errorf("%w: at most %d quantities per table", errors.ErrInvalidRange, maxTableRows),
+		}
+	}
+
+	// Get the active pack sizes
+	packSizes, err := findActivePackSizes(ctx, uc.repository, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(packSizes) == 0 {
+		return nil, errors.ErrNoPackSizesAvailable
+	}
+	sizes, packsBySize := packSizesBySize(packSizes)
+
+	table, err := uc.packingTableFor(ctx, sizes, to)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &entities.CalculationTable{
+		PackSizes: table.Sizes,
+		Results:   make([]*entities.CalculationResult, 0, (to-from)/step+1),
+	}
+	for itemsOrdered := from; itemsOrdered <= to; itemsOrdered += step {
+		packs, _ := table.Lookup(itemsOrdered)
+		result.Results = append(result.Results, newCalculationResult(itemsOrdered, packs, packsBySize))
+	}
+
+	return result, nil
+}
+
+// packingTableFor returns a packing table of the pack sizes covering every quantity up to the
+// ceiling, reusing the precomputed table when it covers them and building one otherwise
+func (uc *CalculationUseCase) packingTableFor(ctx context.Context, sizes []int, ceiling int) (*entities.PackingTable, error) {
+	if uc.packingTable != nil {
+		if table := uc.packingTable.Table(sizes); table != nil && table.Ceiling >= ceiling {
+			return table, nil
+		}
+	}
+
+	estimate := uc.calculatorService.EstimatePackingTable(ceiling, sizes)
+	if !uc.limits.Allows(estimate) {
+		return nil, &errors.ValidationError{
+			Field: "items_ordered",
+			Err: fmt.Errorf("%w: estimated %d MB and %s",
+				errors.ErrCalculationTooLarge, estimate.MemoryBytes>>20, estimate.Duration.Round(time.Millisecond)),
+		}
+	}
+
+	return uc.calculatorService.BuildPackingTable(ctx, ceiling, sizes)
+}
+
+// newCalculationResult creates a calculation result with the weight and packaging footprint of the packs
+func newCalculationResult(itemsOrdered int, packs map[int]int, packsBySize map[int]*entities.PackSize) *entities.CalculationResult {
 	result := entities.NewCalculationResult(itemsOrdered, packs)
+	for size, quantity := range packs {
+		ps := packsBySize[size]
+		result.TotalWeight += ps.Weight * float64(quantity)
+		result.Footprint.Material += ps.MaterialWeight * float64(quantity)
+		result.Footprint.Emissions += ps.Emissions() * float64(quantity)
+	}
+
+	return result
+}
+
+// calculatePacks finds the packing that ships the fewest items and is best for the options, looking
+// it up in the packing table when possible. When the estimated cost exceeds the limits, the order
+// is reduced to the items not covered by the largest packs every optimal packing contains
+// (large-order mode) or rejected.
+func (uc *CalculationUseCase) calculatePacks(
+	ctx context.Context,
+	itemsOrdered int,
+	sizes []int,
+	packsBySize map[int]*entities.PackSize,
+	options entities.CalculationOptions,
+) (map[int]int, error) {
+	// The table holds the packings of the default solver
+	if uc.packingTable != nil && options.Objective != entities.ObjectiveLeastMaterial && !options.OptimizeShipping {
+		if packs, ok := uc.packingTable.Lookup(itemsOrdered, sizes); ok {
+			return packs, nil
+		}
+	}
+
+	estimate := uc.estimate(itemsOrdered, sizes, options)
+	if uc.limits.Allows(estimate) {
+		return uc.solve(ctx, itemsOrdered, sizes, packsBySize, options)
+	}
+
+	// Packings with the least material need not use the largest packs, so they cannot be reduced
+	if options.Objective != entities.ObjectiveLeastMaterial {
+		remaining, largestPacks := uc.calculatorService.ReduceLargeOrder(itemsOrdered, sizes)
+		if largestPacks > 0 && uc.limits.Allows(uc.estimate(remaining, sizes, options)) {
+			packs, err := uc.solve(ctx, remaining, sizes, packsBySize, options)
+			if err != nil {
+				return nil, err
+			}
+
+			largest := 0
+			for _, size := range sizes {
+				if size > largest {
+					largest = size
+				}
+			}
+			packs[largest] += largestPacks
+
+			return packs, nil
+		}
+	}
+
+	return nil, &errors.ValidationError{
+		Field: "items_ordered",
+		Err: fmt.Errorf("%w: estimated %d MB and %s",
+			errors.ErrCalculationTooLarge, estimate.MemoryBytes>>20, estimate.Duration.Round(time.Millisecond)),
+	}
+}
+
+// estimate estimates the cost of the solver used for the options
+func (uc *CalculationUseCase) estimate(itemsOrdered int, sizes []int, options entities.CalculationOptions) entities.CalculationEstimate {
+	if options.Objective == entities.ObjectiveLeastMaterial || options.OptimizeShipping {
+		return uc.calculatorService.EstimateOptimalPacksByCost(itemsOrdered, sizes)
+	}
+
+	return uc.calculatorService.EstimateOptimalPacks(itemsOrdered, sizes)
+}
+
+// solve runs the solver used for the options
+func (uc *CalculationUseCase) solve(
+	ctx context.Context,
+	itemsOrdered int,
+	sizes []int,
+	packsBySize map[int]*entities.PackSize,
+	options entities.CalculationOptions,
+) (map[int]int, error) {
+	// Ties are broken by pack count unless the cheapest to ship, which is the lightest, is preferred
+	secondary := func(size int) float64 {
+		if options.OptimizeShipping {
+			return packsBySize[size].Weight
+		}
+
+		return 1
+	}
+
+	switch {
+	case options.Objective == entities.ObjectiveLeastMaterial:
+		return uc.calculatorService.CalculateOptimalPacksByCost(ctx, itemsOrdered, sizes, func(size int) (float64, float64) {
+			return packsBySize[size].MaterialWeight, secondary(size)
+		})
+	case options.OptimizeShipping:
+		return uc.calculatorService.CalculateOptimalPacksByCost(ctx, itemsOrdered, sizes, func(size int) (float64, float64) {
+			return 1, secondary(size)
+		})
+	default:
+		return uc.calculatorService.CalculateOptimalPacks(ctx, itemsOrdered, sizes)
+	}
+}
+
+// findActivePackSizes retrieves the pack sizes calculations may use at the given time, leaving out
+// inactive ones and those outside their validity period
+func findActivePackSizes(ctx context.Context, repository secondary.PackSizeRepository, at time.Time) ([]*entities.PackSize, error) {
+	packSizes, err := repository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	active := make([]*entities.PackSize, 0, len(packSizes))
+	for _, ps := range packSizes {
+		if ps.Active && ps.IsValidAt(at) {
+			active = append(active, ps)
+		}
+	}
+
+	return active, nil
+}
+
+// packSizesBySize extracts the pack size values, keeping the lightest pack of a size
+func packSizesBySize(packSizes []*entities.PackSize) ([]int, map[int]*entities.PackSize) {
+	sizes := make([]int, len(packSizes))
+	packsBySize := make(map[int]*entities.PackSize, len(packSizes))
+	for i, ps := range packSizes {
+		sizes[i] = ps.Size
+		if current, ok := packsBySize[ps.Size]; !ok || ps.Weight < current.Weight {
+			packsBySize[ps.Size] = ps
+		}
+	}
+
+	return sizes, packsBySize
+}
+
+// PurgeCalculations permanently removes the stored calculation results older than the retention
+func (uc *CalculationUseCase) PurgeCalculations(ctx context.Context, retention time.Duration) (int64, error) {
+	return uc.calculationRepository.PurgeBefore(ctx, time.Now().Add(-retention))
+}
+
+// GetCalculationByID retrieves a stored calculation result by ID
+func (uc *CalculationUseCase) GetCalculationByID(ctx context.Context, id string) (*entities.CalculationResult, error) {
+	result, err := uc.calculationRepository.FindByID(ctx, id)
+	if err != nil {
+		if stderr.Is(err, errors.ErrCalculationNotFound) {
+			return nil, &errors.NotFoundError{
+				ID:  id,
+				Err: errors.ErrCalculationNotFound,
+			}
+		}
+
+		return nil, err
+	}
 
 	return result, nil
 }
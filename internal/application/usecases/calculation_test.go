@@ -1,9 +1,12 @@
 package usecases
 
 import (
+	"context"
 	"errors"
+	"math"
 	"reflect"
 	"testing"
+	"time"
 
 	"go-pack-calculator/internal/domain/entities"
 	domainerrors "go-pack-calculator/internal/domain/errors"
@@ -15,30 +18,106 @@ type mockPackSizeRepository struct {
 	err       error
 }
 
-func (m *mockPackSizeRepository) Create(packSize *entities.PackSize) (*entities.PackSize, error) {
+func (m *mockPackSizeRepository) Create(ctx context.Context, packSize *entities.PackSize) (*entities.PackSize, error) {
 	return packSize, nil // Not used in this test
 }
 
-func (m *mockPackSizeRepository) FindAll() ([]*entities.PackSize, error) {
+func (m *mockPackSizeRepository) FindAll(ctx context.Context) ([]*entities.PackSize, error) {
 	return m.packSizes, m.err
 }
 
-func (m *mockPackSizeRepository) FindAllPaginated(page, limit int64) ([]*entities.PackSize, int64, error) {
+func (m *mockPackSizeRepository) FindAllPaginated(ctx context.Context, page, limit int64) ([]*entities.PackSize, int64, error) {
 	return nil, 0, nil // Not used in this test
 }
 
-func (m *mockPackSizeRepository) FindByID(id string) (*entities.PackSize, error) {
+func (m *mockPackSizeRepository) FindByID(ctx context.Context, id string) (*entities.PackSize, error) {
 	return nil, nil // Not used in this test
 }
 
-func (m *mockPackSizeRepository) Update(packSize *entities.PackSize) (*entities.PackSize, error) {
+func (m *mockPackSizeRepository) Update(ctx context.Context, packSize *entities.PackSize) (*entities.PackSize, error) {
 	return packSize, nil // Not used in this test
 }
 
-func (m *mockPackSizeRepository) Delete(id string) error {
+func (m *mockPackSizeRepository) Delete(ctx context.Context, id string, version int64) error {
 	return nil // Not used in this test
 }
 
+func (m *mockPackSizeRepository) FindDeleted(ctx context.Context) ([]*entities.PackSize, error) {
+	return nil, nil // Not used in this test
+}
+
+func (m *mockPackSizeRepository) Restore(ctx context.Context, id string) (*entities.PackSize, error) {
+	return nil, nil // Not used in this test
+}
+
+func (m *mockPackSizeRepository) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	return 0, nil // Not used in this test
+}
+
+// Mock calculation repository for testing
+type mockCalculationRepository struct {
+	results map[string]*entities.CalculationResult
+	err     error
+}
+
+func newMockCalculationRepository() *mockCalculationRepository {
+	return &mockCalculationRepository{results: make(map[string]*entities.CalculationResult)}
+}
+
+func (m *mockCalculationRepository) Create(ctx context.Context, result *entities.CalculationResult) (*entities.CalculationResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if result.ID == "" {
+		result.ID = "calc-id"
+	}
+	m.results[result.ID] = result
+	return result, nil
+}
+
+func (m *mockCalculationRepository) FindByID(ctx context.Context, id string) (*entities.CalculationResult, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	result, ok := m.results[id]
+	if !ok {
+		return nil, domainerrors.ErrCalculationNotFound
+	}
+	return result, nil
+}
+
+func (m *mockCalculationRepository) PurgeBefore(ctx context.Context, before time.Time) (int64, error) {
+	if m.err != nil {
+		return 0, m.err
+	}
+	var purged int64
+	for id, result := range m.results {
+		if result.CreatedAt.Before(before) {
+			delete(m.results, id)
+			purged++
+		}
+	}
+	return purged, nil
+}
+
+// Mock shipping rate repository for testing
+type mockShippingRateRepository struct {
+	rates []*entities.ShippingRate
+	err   error
+}
+
+func (m *mockShippingRateRepository) ReplaceAll(ctx context.Context, rates []*entities.ShippingRate) ([]*entities.ShippingRate, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.rates = rates
+	return rates, nil
+}
+
+func (m *mockShippingRateRepository) FindAll(ctx context.Context) ([]*entities.ShippingRate, error) {
+	return m.rates, m.err
+}
+
 // createTestPackSize is a helper function to create pack sizes for tests
 func createTestPackSize(t *testing.T, size int) *entities.PackSize {
 	ps, err := entities.NewPackSize(size)
@@ -117,10 +196,10 @@ func TestCalculationUseCase_CalculatePacksForOrder(t *testing.T) {
 			}
 
 			// Create use case with mock repository
-			useCase := NewCalculationUseCase(mockRepo)
+			useCase := NewCalculationUseCase(mockRepo, newMockCalculationRepository(), &mockShippingRateRepository{})
 
 			// Call the method
-			result, err := useCase.CalculatePacksForOrder(tt.itemsOrdered)
+			result, err := useCase.CalculatePacksForOrder(context.Background(), tt.itemsOrdered, entities.CalculationOptions{})
 
 			// Check error
 			if (err != nil && tt.wantErr == nil) || (err == nil && tt.wantErr != nil) {
@@ -150,3 +229,567 @@ func TestCalculationUseCase_CalculatePacksForOrder(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculationUseCase_GetCalculationByID(t *testing.T) {
+	calculationRepo := newMockCalculationRepository()
+	useCase := NewCalculationUseCase(&mockPackSizeRepository{
+		packSizes: []*entities.PackSize{createTestPackSize(t, 250)},
+	}, calculationRepo, &mockShippingRateRepository{})
+
+	// Calculated results are stored
+	result, err := useCase.CalculatePacksForOrder(context.Background(), 1, entities.CalculationOptions{})
+	if err != nil {
+		t.Fatalf("CalculatePacksForOrder() unexpected error = %v", err)
+	}
+	if result.ID == "" {
+		t.Fatalf("CalculatePacksForOrder() did not store the result")
+	}
+
+	stored, err := useCase.GetCalculationByID(context.Background(), result.ID)
+	if err != nil {
+		t.Fatalf("GetCalculationByID() unexpected error = %v", err)
+	}
+	if !reflect.DeepEqual(stored.Packs, result.Packs) {
+		t.Errorf("GetCalculationByID() = %v, want %v", stored.Packs, result.Packs)
+	}
+
+	// Unknown IDs are reported as not found
+	_, err = useCase.GetCalculationByID(context.Background(), "unknown-id")
+	var notFoundErr *domainerrors.NotFoundError
+	if !errors.As(err, &notFoundErr) || !errors.Is(err, domainerrors.ErrCalculationNotFound) {
+		t.Errorf("GetCalculationByID() error = %v, want NotFoundError", err)
+	}
+
+	// Repository errors are passed through
+	calculationRepo.err = errors.New("database error")
+	if _, err := useCase.GetCalculationByID(context.Background(), result.ID); err == nil || err.Error() != "database error" {
+		t.Errorf("GetCalculationByID() error = %v, want database error", err)
+	}
+}
+
+func TestCalculationUseCase_PurgeCalculations(t *testing.T) {
+	calculationRepo := newMockCalculationRepository()
+	calculationRepo.results["old"] = &entities.CalculationResult{ID: "old", CreatedAt: time.Now().Add(-48 * time.Hour)}
+	calculationRepo.results["recent"] = &entities.CalculationResult{ID: "recent", CreatedAt: time.Now()}
+	useCase := NewCalculationUseCase(&mockPackSizeRepository{}, calculationRepo, &mockShippingRateRepository{})
+
+	purged, err := useCase.PurgeCalculations(context.Background(), 24*time.Hour)
+	if err != nil {
+		t.Fatalf("PurgeCalculations() unexpected error = %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("PurgeCalculations() purged = %v, want 1", purged)
+	}
+	if _, ok := calculationRepo.results["recent"]; !ok {
+		t.Error("PurgeCalculations() removed a calculation within the retention")
+	}
+}
+
+func TestCalculationUseCase_CalculatePacksForOrderWithShipping(t *testing.T) {
+	// 400 is covered by two packs as 200+200 or 300+100, the latter being lighter
+	light100 := createTestPackSize(t, 100)
+	light100.Weight = 1
+	heavy200 := createTestPackSize(t, 200)
+	heavy200.Weight = 5
+	light300 := createTestPackSize(t, 300)
+	light300.Weight = 2
+
+	cheap, _ := entities.NewShippingRate("DHL", 5, 2, 8)
+	expensive, _ := entities.NewShippingRate("DHL", 20, 5, 15)
+	other, _ := entities.NewShippingRate("UPS", 20, 5, 12)
+
+	useCase := NewCalculationUseCase(
+		&mockPackSizeRepository{packSizes: []*entities.PackSize{light100, heavy200, light300}},
+		newMockCalculationRepository(),
+		&mockShippingRateRepository{rates: []*entities.ShippingRate{cheap, expensive, other}},
+	)
+
+	result, err := useCase.CalculatePacksForOrder(context.Background(), 400, entities.CalculationOptions{OptimizeShipping: true})
+	if err != nil {
+		t.Fatalf("CalculatePacksForOrder() unexpected error = %v", err)
+	}
+
+	wantPacks := map[int]int{300: 1, 100: 1}
+	if !reflect.DeepEqual(result.Packs, wantPacks) {
+		t.Errorf("CalculatePacksForOrder() = %v, want %v", result.Packs, wantPacks)
+	}
+
+	if result.TotalWeight != 3 {
+		t.Errorf("CalculatePacksForOrder() total weight = %v, want %v", result.TotalWeight, 3)
+	}
+
+	wantEstimates := []entities.ShippingEstimate{
+		{Carrier: "DHL", Price: 8},
+		{Carrier: "UPS", Price: 12},
+	}
+	if !reflect.DeepEqual(result.ShippingEstimates, wantEstimates) {
+		t.Errorf("CalculatePacksForOrder() shipping estimates = %v, want %v", result.ShippingEstimates, wantEstimates)
+	}
+}
+
+func TestCalculationUseCase_CalculatePacksForOrderWithObjective(t *testing.T) {
+	// 500 is covered by one 500 pack or by two 250 packs using less material
+	small := createTestPackSize(t, 250)
+	small.MaterialWeight = 0.1
+	small.EmissionFactor = 2
+	large := createTestPackSize(t, 500)
+	large.MaterialWeight = 0.3
+	large.EmissionFactor = 2
+
+	tests := []struct {
+		name          string
+		objective     entities.CalculationObjective
+		wantPacks     map[int]int
+		wantFootprint entities.PackagingFootprint
+		wantErr       error
+	}{
+		{
+			name:          "Default objective uses fewest packs",
+			objective:     "",
+			wantPacks:     map[int]int{500: 1},
+			wantFootprint: entities.PackagingFootprint{Material: 0.3, Emissions: 0.6},
+		},
+		{
+			name:          "Least material",
+			objective:     entities.ObjectiveLeastMaterial,
+			wantPacks:     map[int]int{250: 2},
+			wantFootprint: entities.PackagingFootprint{Material: 0.2, Emissions: 0.4},
+		},
+		{
+			name:      "Unknown objective",
+			objective: "cheapest",
+			wantErr:   domainerrors.ErrInvalidObjective,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := NewCalculationUseCase(
+				&mockPackSizeRepository{packSizes: []*entities.PackSize{small, large}},
+				newMockCalculationRepository(),
+				&mockShippingRateRepository{},
+			)
+
+			result, err := useCase.CalculatePacksForOrder(context.Background(), 500, entities.CalculationOptions{Objective: tt.objective})
+
+			// Check error
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CalculatePacksForOrder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			// If expecting an error, don't check the result
+			if tt.wantErr != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(result.Packs, tt.wantPacks) {
+				t.Errorf("CalculatePacksForOrder() = %v, want %v", result.Packs, tt.wantPacks)
+			}
+
+			if math.Abs(result.Footprint.Material-tt.wantFootprint.Material) > 1e-9 ||
+				math.Abs(result.Footprint.Emissions-tt.wantFootprint.Emissions) > 1e-9 {
+				t.Errorf("CalculatePacksForOrder() footprint = %+v, want %+v", result.Footprint, tt.wantFootprint)
+			}
+		})
+	}
+}
+
+func TestCalculationUseCase_CalculatePacksForOrderWithLimits(t *testing.T) {
+	packSizes := []*entities.PackSize{
+		createTestPackSize(t, 23),
+		createTestPackSize(t, 31),
+		createTestPackSize(t, 53),
+	}
+
+	// Solving 500000 directly needs far more than 1 MB, the reduced order does not
+	limits := CalculationLimits{MaxMemoryBytes: 1 << 20}
+
+	tests := []struct {
+		name         string
+		itemsOrdered int
+		options      entities.CalculationOptions
+		limits       CalculationLimits
+		wantItems    int
+		wantPacks    int
+		wantErr      error
+	}{
+		{
+			name:         "Within limits",
+			itemsOrdered: 500000,
+			options:      entities.CalculationOptions{},
+			limits:       CalculationLimits{},
+			wantItems:    500000,
+			wantPacks:    9438,
+		},
+		{
+			name:         "Large order mode",
+			itemsOrdered: 500000,
+			options:      entities.CalculationOptions{},
+			limits:       limits,
+			wantItems:    500000,
+			wantPacks:    9438,
+		},
+		{
+			name:         "Large order mode optimizing shipping",
+			itemsOrdered: 500000,
+			options:      entities.CalculationOptions{OptimizeShipping: true},
+			limits:       limits,
+			wantItems:    500000,
+			wantPacks:    9438,
+		},
+		{
+			name:         "Least material cannot be reduced",
+			itemsOrdered: 500000,
+			options:      entities.CalculationOptions{Objective: entities.ObjectiveLeastMaterial},
+			limits:       limits,
+			wantErr:      domainerrors.ErrCalculationTooLarge,
+		},
+		{
+			name:         "Reduced order still too large",
+			itemsOrdered: 500000,
+			options:      entities.CalculationOptions{},
+			limits:       CalculationLimits{MaxDuration: time.Nanosecond},
+			wantErr:      domainerrors.ErrCalculationTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := NewCalculationUseCase(
+				&mockPackSizeRepository{packSizes: packSizes},
+				newMockCalculationRepository(),
+				&mockShippingRateRepository{},
+			)
+			useCase.SetLimits(tt.limits)
+
+			result, err := useCase.CalculatePacksForOrder(context.Background(), tt.itemsOrdered, tt.options)
+
+			// Check error
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CalculatePacksForOrder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			// If expecting an error, don't check the result
+			if tt.wantErr != nil {
+				return
+			}
+
+			if result.TotalItems != tt.wantItems || result.TotalPacks() != tt.wantPacks {
+				t.Errorf("CalculatePacksForOrder() = %d items in %d packs, want %d in %d",
+					result.TotalItems, result.TotalPacks(), tt.wantItems, tt.wantPacks)
+			}
+		})
+	}
+}
+
+func TestCalculationUseCase_CalculatePackingTable(t *testing.T) {
+	packSizes := []*entities.PackSize{
+		createTestPackSize(t, 250),
+		createTestPackSize(t, 500),
+		createTestPackSize(t, 1000),
+	}
+
+	tests := []struct {
+		name     string
+		from     int
+		to       int
+		step     int
+		ceiling  int // Ceiling of the precomputed packing table, none when zero
+		limits   CalculationLimits
+		wantRows int
+		wantErr  error
+	}{
+		{
+			name:     "Every quantity",
+			from:     1,
+			to:       1500,
+			step:     1,
+			wantRows: 1500,
+		},
+		{
+			name:     "Stepped quantities",
+			from:     100,
+			to:       2600,
+			step:     250,
+			wantRows: 11,
+		},
+		{
+			name:     "Covered by the packing table",
+			from:     1,
+			to:       2000,
+			step:     7,
+			ceiling:  5000,
+			wantRows: 286,
+		},
+		{
+			name:     "Beyond the packing table",
+			from:     4000,
+			to:       6000,
+			step:     100,
+			ceiling:  5000,
+			wantRows: 21,
+		},
+		{
+			name:    "Empty range",
+			from:    10,
+			to:      9,
+			step:    1,
+			wantErr: domainerrors.ErrInvalidRange,
+		},
+		{
+			name:    "Invalid step",
+			from:    1,
+			to:      10,
+			step:    0,
+			wantErr: domainerrors.ErrInvalidRange,
+		},
+		{
+			name:    "Too many quantities",
+			from:    1,
+			to:      maxTableRows + 1,
+			step:    1,
+			wantErr: domainerrors.ErrInvalidRange,
+		},
+		{
+			// A single order of the size needs much less, the table spans every total below it
+			name:    "Table over the budget",
+			from:    1000000,
+			to:      1000000,
+			step:    1,
+			limits:  CalculationLimits{MaxMemoryBytes: 4 << 20},
+			wantErr: domainerrors.ErrCalculationTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockPackSizeRepository{packSizes: packSizes}
+			useCase := NewCalculationUseCase(repo, newMockCalculationRepository(), &mockShippingRateRepository{})
+			useCase.SetLimits(tt.limits)
+			if tt.ceiling > 0 {
+				packingTable := NewPackingTableUseCase(repo, newMockPackingTableRepository(), tt.ceiling)
+				if _, err := packingTable.Rebuild(context.Background()); err != nil {
+					t.Fatalf("Rebuild() error = %v", err)
+				}
+				useCase.SetPackingTable(packingTable)
+			}
+
+			table, err := useCase.CalculatePackingTable(context.Background(), tt.from, tt.to, tt.step)
+
+			// Check error
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CalculatePackingTable() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			// If expecting an error, don't check the result
+			if tt.wantErr != nil {
+				return
+			}
+
+			if len(table.Results) != tt.wantRows {
+				t.Fatalf("CalculatePackingTable() returned %d rows, want %d", len(table.Results), tt.wantRows)
+			}
+			if !reflect.DeepEqual(table.PackSizes, []int{1000, 500, 250}) {
+				t.Errorf("CalculatePackingTable() pack sizes = %v, want [1000 500 250]", table.PackSizes)
+			}
+
+			// Every row matches the calculation of its quantity on its own
+			for i, result := range table.Results {
+				itemsOrdered := tt.from + i*tt.step
+				want, err := useCase.CalculatePacksForOrder(context.Background(), itemsOrdered, entities.CalculationOptions{})
+				if err != nil {
+					t.Fatalf("CalculatePacksForOrder() error = %v", err)
+				}
+				if result.ItemsOrdered != itemsOrdered || !reflect.DeepEqual(result.Packs, want.Packs) {
+					t.Fatalf("CalculatePackingTable() row %d = %d: %v, want %d: %v",
+						i, result.ItemsOrdered, result.Packs, itemsOrdered, want.Packs)
+				}
+			}
+		})
+	}
+}
+
+func TestCalculationUseCase_VerifyPacking(t *testing.T) {
+	packSizes := []*entities.PackSize{
+		createTestPackSize(t, 250),
+		createTestPackSize(t, 500),
+		createTestPackSize(t, 1000),
+	}
+
+	tests := []struct {
+		name         string
+		packSizes    []*entities.PackSize
+		itemsOrdered int
+		proposed     map[int]float64
+		wantValid    bool
+		wantErr      error
+	}{
+		{
+			name:         "Optimal packing",
+			packSizes:    packSizes,
+			itemsOrdered: 1251,
+			proposed:     map[int]float64{1000: 1, 500: 1},
+			wantValid:    true,
+		},
+		{
+			name:         "Too many packs",
+			packSizes:    packSizes,
+			itemsOrdered: 1251,
+			proposed:     map[int]float64{500: 3},
+			wantValid:    false,
+		},
+		{
+			name:         "Invalid items ordered",
+			packSizes:    packSizes,
+			itemsOrdered: 0,
+			proposed:     map[int]float64{500: 1},
+			wantErr:      domainerrors.ErrInvalidItemsOrdered,
+		},
+		{
+			name:         "No pack sizes",
+			itemsOrdered: 1251,
+			proposed:     map[int]float64{500: 1},
+			wantErr:      domainerrors.ErrNoPackSizesAvailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calculationRepo := newMockCalculationRepository()
+			useCase := NewCalculationUseCase(&mockPackSizeRepository{packSizes: tt.packSizes}, calculationRepo, &mockShippingRateRepository{})
+
+			verification, err := useCase.VerifyPacking(context.Background(), tt.itemsOrdered, tt.proposed)
+
+			// Check error
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("VerifyPacking() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			// If expecting an error, don't check the result
+			if tt.wantErr != nil {
+				return
+			}
+
+			if verification.Valid() != tt.wantValid {
+				t.Errorf("VerifyPacking() valid = %v, want %v: %+v", verification.Valid(), tt.wantValid, verification.Violations)
+			}
+			if want := map[int]int{1000: 1, 500: 1}; !reflect.DeepEqual(verification.Optimal.Packs, want) {
+				t.Errorf("VerifyPacking() optimal = %v, want %v", verification.Optimal.Packs, want)
+			}
+			if len(calculationRepo.results) != 0 {
+				t.Errorf("VerifyPacking() stored %d calculations, want none", len(calculationRepo.results))
+			}
+		})
+	}
+}
+
+func TestCalculationUseCase_CalculatePacksForOrderUsesActivePackSizes(t *testing.T) {
+	inactive := false
+	disabled := createTestPackSize(t, 500)
+	if err := disabled.SetAttributes(entities.PackSizeAttributes{Active: &inactive}); err != nil {
+		t.Fatalf("SetAttributes() error = %v", err)
+	}
+
+	tests := []struct {
+		name      string
+		packSizes []*entities.PackSize
+		wantPacks map[int]int
+		wantErr   error
+	}{
+		{
+			name:      "Inactive pack size is skipped",
+			packSizes: []*entities.PackSize{createTestPackSize(t, 250), disabled},
+			wantPacks: map[int]int{250: 2},
+		},
+		{
+			name:      "No active pack sizes",
+			packSizes: []*entities.PackSize{disabled},
+			wantErr:   domainerrors.ErrNoPackSizesAvailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := NewCalculationUseCase(
+				&mockPackSizeRepository{packSizes: tt.packSizes},
+				newMockCalculationRepository(),
+				&mockShippingRateRepository{},
+			)
+
+			result, err := useCase.CalculatePacksForOrder(context.Background(), 251, entities.CalculationOptions{})
+
+			// Check error
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("CalculatePacksForOrder() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			// If expecting an error, don't check the result
+			if tt.wantErr != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(result.Packs, tt.wantPacks) {
+				t.Errorf("CalculatePacksForOrder() = %v, want %v", result.Packs, tt.wantPacks)
+			}
+		})
+	}
+}
+
+func TestCalculationUseCase_CalculatePacksForOrderAsOf(t *testing.T) {
+	// The 750 pack replaces the 500 pack on the 1st
+	switchover := time.Now().AddDate(0, 0, 7)
+	retired := createTestPackSize(t, 500)
+	if err := retired.SetAttributes(entities.PackSizeAttributes{ValidTo: &switchover}); err != nil {
+		t.Fatalf("SetAttributes() error = %v", err)
+	}
+	scheduled := createTestPackSize(t, 750)
+	if err := scheduled.SetAttributes(entities.PackSizeAttributes{ValidFrom: &switchover}); err != nil {
+		t.Fatalf("SetAttributes() error = %v", err)
+	}
+	packSizes := []*entities.PackSize{createTestPackSize(t, 250), retired, scheduled}
+
+	tests := []struct {
+		name      string
+		asOf      time.Time
+		wantPacks map[int]int
+	}{
+		{
+			name:      "Current pack sizes by default",
+			wantPacks: map[int]int{500: 1, 250: 1},
+		},
+		{
+			name:      "Before the switchover",
+			asOf:      switchover.Add(-time.Second),
+			wantPacks: map[int]int{500: 1, 250: 1},
+		},
+		{
+			name:      "From the switchover",
+			asOf:      switchover,
+			wantPacks: map[int]int{750: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase := NewCalculationUseCase(
+				&mockPackSizeRepository{packSizes: packSizes},
+				newMockCalculationRepository(),
+				&mockShippingRateRepository{},
+			)
+
+			result, err := useCase.CalculatePacksForOrder(context.Background(), 700, entities.CalculationOptions{AsOf: tt.asOf})
+			if err != nil {
+				t.Fatalf("CalculatePacksForOrder() error = %v", err)
+			}
+
+			if !reflect.DeepEqual(result.Packs, tt.wantPacks) {
+				t.Errorf("CalculatePacksForOrder() = %v, want %v", result.Packs, tt.wantPacks)
+			}
+		})
+	}
+}
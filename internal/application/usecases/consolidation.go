@@ -0,0 +1,61 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/domain/services"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// ConsolidationUseCase represents the application use cases for order consolidation
+type ConsolidationUseCase struct {
+	repository          secondary.PackSizeRepository
+	calculationUseCase  *CalculationUseCase
+	consolidatorService *services.OrderConsolidationService
+}
+
+// NewConsolidationUseCase creates a new consolidation use case
+func NewConsolidationUseCase(repository secondary.PackSizeRepository, calculationUseCase *CalculationUseCase) *ConsolidationUseCase {
+	return &ConsolidationUseCase{
+		repository:          repository,
+		calculationUseCase:  calculationUseCase,
+		consolidatorService: services.NewOrderConsolidationService(),
+	}
+}
+
+// ConsolidateOrders calculates the combined and separate packings for several orders. Every
+// packing is subject to the calculation limits of single orders.
+func (uc *ConsolidationUseCase) ConsolidateOrders(ctx context.Context, orders []entities.Order) (*entities.ConsolidationResult, error) {
+	// Validate input
+	if len(orders) == 0 {
+		return nil, errors.ErrNoOrders
+	}
+	if len(orders) > maxTableRows {
+		return nil, &errors.ValidationError{
+			Field: "orders",
+			Err:   fmt.Errorf("%w: at most %d orders per consolidation", errors.ErrInvalidRange, maxTableRows),
+		}
+	}
+
+	// Get the active pack sizes
+	packSizes, err := findActivePackSizes(ctx, uc.repository, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	// Check if there are pack sizes available
+	if len(packSizes) == 0 {
+		return nil, errors.ErrNoPackSizesAvailable
+	}
+
+	// Extract pack size values
+	sizes, packsBySize := packSizesBySize(packSizes)
+
+	return uc.consolidatorService.Consolidate(ctx, orders, func(ctx context.Context, itemsOrdered int) (map[int]int, error) {
+		return uc.calculationUseCase.calculatePacks(ctx, itemsOrdered, sizes, packsBySize, entities.CalculationOptions{})
+	})
+}
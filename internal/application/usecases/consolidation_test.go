@@ -0,0 +1,156 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"math"
+	"reflect"
+	"testing"
+	"time"
+
+	"go-pack-calculator/internal/domain/entities"
+	domainerrors "go-pack-calculator/internal/domain/errors"
+)
+
+func TestConsolidationUseCase_ConsolidateOrders(t *testing.T) {
+	tests := []struct {
+		name         string
+		orders       []entities.Order
+		packSizes    []*entities.PackSize
+		repoErr      error
+		wantCombined map[int]int
+		wantErr      error
+	}{
+		{
+			name:   "Successful consolidation",
+			orders: []entities.Order{{ID: "a", ItemsOrdered: 251}, {ID: "b", ItemsOrdered: 251}},
+			packSizes: []*entities.PackSize{
+				createTestPackSize(t, 250),
+				createTestPackSize(t, 500),
+			},
+			wantCombined: map[int]int{500: 1, 250: 1},
+		},
+		{
+			name:    "No orders",
+			orders:  nil,
+			wantErr: domainerrors.ErrNoOrders,
+		},
+		{
+			name:    "Repository error",
+			orders:  []entities.Order{{ID: "a", ItemsOrdered: 1}},
+			repoErr: errors.New("database error"),
+			wantErr: errors.New("database error"),
+		},
+		{
+			name:      "No pack sizes available",
+			orders:    []entities.Order{{ID: "a", ItemsOrdered: 1}},
+			packSizes: []*entities.PackSize{},
+			wantErr:   domainerrors.ErrNoPackSizesAvailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Create use case with mock repository
+			repository := &mockPackSizeRepository{
+				packSizes: tt.packSizes,
+				err:       tt.repoErr,
+			}
+			useCase := NewConsolidationUseCase(
+				repository,
+				NewCalculationUseCase(repository, newMockCalculationRepository(), &mockShippingRateRepository{}),
+			)
+
+			// Call the method
+			result, err := useCase.ConsolidateOrders(context.Background(), tt.orders)
+
+			// Check error
+			if (err != nil) != (tt.wantErr != nil) || (err != nil && err.Error() != tt.wantErr.Error()) {
+				t.Errorf("ConsolidateOrders() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			// If expecting an error, don't check the result
+			if tt.wantErr != nil {
+				return
+			}
+
+			// Check result
+			if !reflect.DeepEqual(result.Combined.Packs, tt.wantCombined) {
+				t.Errorf("ConsolidateOrders() = %v, want %v", result.Combined.Packs, tt.wantCombined)
+			}
+		})
+	}
+}
+
+func TestConsolidationUseCase_ConsolidateOrdersWithLimits(t *testing.T) {
+	packSizes := []*entities.PackSize{
+		createTestPackSize(t, 23),
+		createTestPackSize(t, 31),
+		createTestPackSize(t, 53),
+	}
+
+	tests := []struct {
+		name      string
+		orders    []entities.Order
+		limits    CalculationLimits
+		wantItems int
+		wantPacks int
+		wantErr   error
+	}{
+		{
+			name:      "Combined order in large order mode",
+			orders:    []entities.Order{{ID: "a", ItemsOrdered: 250000}, {ID: "b", ItemsOrdered: 250000}},
+			limits:    CalculationLimits{MaxMemoryBytes: 1 << 20},
+			wantItems: 500000,
+			wantPacks: 9438,
+		},
+		{
+			name:    "Combined order too large",
+			orders:  []entities.Order{{ID: "a", ItemsOrdered: 250000}, {ID: "b", ItemsOrdered: 250000}},
+			limits:  CalculationLimits{MaxDuration: time.Nanosecond},
+			wantErr: domainerrors.ErrCalculationTooLarge,
+		},
+		{
+			name:    "Orders total more than an int",
+			orders:  []entities.Order{{ID: "a", ItemsOrdered: math.MaxInt}, {ID: "b", ItemsOrdered: 1}},
+			wantErr: domainerrors.ErrCalculationTooLarge,
+		},
+		{
+			name:    "Too many orders",
+			orders:  make([]entities.Order, maxTableRows+1),
+			wantErr: domainerrors.ErrInvalidRange,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repository := &mockPackSizeRepository{packSizes: packSizes}
+			calculationUseCase := NewCalculationUseCase(repository, newMockCalculationRepository(), &mockShippingRateRepository{})
+			calculationUseCase.SetLimits(tt.limits)
+			useCase := NewConsolidationUseCase(repository, calculationUseCase)
+
+			result, err := useCase.ConsolidateOrders(context.Background(), tt.orders)
+
+			// Check error
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ConsolidateOrders() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			// If expecting an error, don't check the result
+			if tt.wantErr != nil {
+				var validationErr *domainerrors.ValidationError
+				if !errors.As(err, &validationErr) {
+					t.Errorf("ConsolidateOrders() error = %v, want a validation error", err)
+				}
+				return
+			}
+
+			if result.Combined.TotalItems != tt.wantItems || result.Combined.TotalPacks() != tt.wantPacks {
+				t.Errorf("ConsolidateOrders() combined = %d items in %d packs, want %d in %d",
+					result.Combined.TotalItems, result.Combined.TotalPacks(), tt.wantItems, tt.wantPacks)
+			}
+		})
+	}
+}
@@ -0,0 +1,111 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// RetryPolicy bounds the deliveries of an outbox entry. The delay before the next delivery doubles
+// with every failure, from Backoff up to MaxBackoff, and the entry is given up after MaxAttempts
+// failures.
+type RetryPolicy struct {
+	MaxAttempts int
+	Backoff     time.Duration
+	MaxBackoff  time.Duration
+}
+
+// RelayResult counts what a relay pass did with the entries it claimed
+type RelayResult struct {
+	Delivered int // Accepted by every publisher
+	Retried   int // Failed, delivered again later
+	Dead      int // Failed for the last time, given up
+}
+
+// OutboxRelayUseCase represents the application use case delivering the events of the outbox to
+// the publishers
+type OutboxRelayUseCase struct {
+	outbox     secondary.OutboxRepository
+	publishers []secondary.EventPublisher
+	policy     RetryPolicy
+	batchSize  int
+	lease      time.Duration
+}
+
+// NewOutboxRelayUseCase creates a new outbox relay use case claiming batches of the given size for
+// the lease
+func NewOutboxRelayUseCase(
+	outbox secondary.OutboxRepository,
+	policy RetryPolicy,
+	batchSize int,
+	lease time.Duration,
+	publishers ...secondary.EventPublisher,
+) *OutboxRelayUseCase {
+	return &OutboxRelayUseCase{
+		outbox:     outbox,
+		publishers: publishers,
+		policy:     policy,
+		batchSize:  batchSize,
+		lease:      lease,
+	}
+}
+
+// RelayEvents delivers the pending events of the outbox to every publisher, batch by batch until
+// none is left. An event is only marked delivered once all publishers have accepted it, so a
+// failure delivers it again to every publisher and consumers see it at least once.
+func (uc *OutboxRelayUseCase) RelayEvents(ctx context.Context) (RelayResult, error) {
+	var result RelayResult
+	for {
+		entries, err := uc.outbox.ClaimPending(ctx, uc.batchSize, uc.lease)
+		if err != nil {
+			return result, err
+		}
+
+		for _, entry := range entries {
+			if err := uc.relay(ctx, entry, &result); err != nil {
+				return result, err
+			}
+		}
+
+		if len(entries) < uc.batchSize {
+			return result, nil
+		}
+	}
+}
+
+// relay delivers the event of a claimed entry and records the outcome in the outbox and result
+func (uc *OutboxRelayUseCase) relay(ctx context.Context, entry *entities.OutboxEntry, result *RelayResult) error {
+	if err := uc.publish(ctx, entry.Event); err != nil {
+		attempts := entry.Attempts + 1
+		if attempts >= uc.policy.MaxAttempts {
+			result.Dead++
+			return uc.outbox.MarkDead(ctx, entry.Event.ID, err.Error())
+		}
+
+		result.Retried++
+		delay := entities.RetryDelay(attempts, uc.policy.Backoff, uc.policy.MaxBackoff)
+		return uc.outbox.MarkFailed(ctx, entry.Event.ID, err.Error(), time.Now().Add(delay))
+	}
+
+	result.Delivered++
+	return uc.outbox.MarkDelivered(ctx, entry.Event.ID)
+}
+
+// publish hands an event to every publisher, returning the failures of all of them
+func (uc *OutboxRelayUseCase) publish(ctx context.Context, event *entities.PackSizeEvent) error {
+	var failures []string
+	for _, publisher := range uc.publishers {
+		if err := publisher.Publish(ctx, event); err != nil {
+			failures = append(failures, fmt.Sprintf("%s: %v", publisher.Name(), err))
+		}
+	}
+	if len(failures) > 0 {
+		return fmt.Errorf("publishing %s event %s failed: %s", event.Type, event.ID, strings.Join(failures, "; "))
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"go-pack-calculator/internal/domain/entities"
+)
+
+// Mock outbox recording the added events and the outcome of the deliveries
+type mockOutboxRepository struct {
+	events    []*entities.PackSizeEvent
+	addErr    error
+	pending   []*entities.OutboxEntry
+	claimErr  error
+	claims    int
+	delivered []string
+	retried   map[string]time.Time
+	dead      []string
+	reasons   map[string]string
+}
+
+func (m *mockOutboxRepository) Add(ctx context.Context, events ...*entities.PackSizeEvent) error {
+	if m.addErr != nil {
+		return m.addErr
+	}
+	m.events = append(m.events, events...)
+	return nil
+}
+
+func (m *mockOutboxRepository) ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*entities.OutboxEntry, error) {
+	m.claims++
+	if m.claimErr != nil {
+		return nil, m.claimErr
+	}
+	n := min(limit, len(m.pending))
+	claimed := m.pending[:n]
+	m.pending = m.pending[n:]
+	return claimed, nil
+}
+
+func (m *mockOutboxRepository) MarkDelivered(ctx context.Context, id string) error {
+	m.delivered = append(m.delivered, id)
+	return nil
+}
+
+func (m *mockOutboxRepository) MarkFailed(ctx context.Context, id string, reason string, nextAttemptAt time.Time) error {
+	if m.retried == nil {
+		m.retried = make(map[string]time.Time)
+		m.reasons = make(map[string]string)
+	}
+	m.retried[id] = nextAttemptAt
+	m.reasons[id] = reason
+	return nil
+}
+
+func (m *mockOutboxRepository) MarkDead(ctx context.Context, id string, reason string) error {
+	if m.reasons == nil {
+		m.reasons = make(map[string]string)
+	}
+	m.dead = append(m.dead, id)
+	m.reasons[id] = reason
+	return nil
+}
+
+// Mock publisher failing the events of the given IDs
+type mockEventPublisher struct {
+	name      string
+	failIDs   map[string]bool
+	published []string
+}
+
+func (m *mockEventPublisher) Name() string {
+	return m.name
+}
+
+func (m *mockEventPublisher) Publish(ctx context.Context, event *entities.PackSizeEvent) error {
+	if m.failIDs[event.ID] {
+		return errors.New("unavailable")
+	}
+	m.published = append(m.published, event.ID)
+	return nil
+}
+
+// outboxEntry returns a pending outbox entry of the given ID after the given failed deliveries
+func outboxEntry(id string, attempts int) *entities.OutboxEntry {
+	return &entities.OutboxEntry{
+		Event:    &entities.PackSizeEvent{ID: id, Type: entities.PackSizeCreated},
+		Attempts: attempts,
+	}
+}
+
+func TestOutboxRelayUseCase_RelayEvents(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3, Backoff: time.Minute, MaxBackoff: time.Hour}
+	outbox := &mockOutboxRepository{pending: []*entities.OutboxEntry{
+		outboxEntry("delivered", 0),
+		outboxEntry("retried", 1),
+		outboxEntry("dead", 2),
+	}}
+	log := &mockEventPublisher{name: "log"}
+	webhook := &mockEventPublisher{name: "webhook", failIDs: map[string]bool{"retried": true, "dead": true}}
+	useCase := NewOutboxRelayUseCase(outbox, policy, 2, time.Minute, log, webhook)
+
+	before := time.Now()
+	result, err := useCase.RelayEvents(context.Background())
+	if err != nil {
+		t.Fatalf("RelayEvents() unexpected error = %v", err)
+	}
+
+	if want := (RelayResult{Delivered: 1, Retried: 1, Dead: 1}); result != want {
+		t.Errorf("RelayEvents() = %+v, want %+v", result, want)
+	}
+	if outbox.claims != 2 {
+		t.Errorf("RelayEvents() claimed %d batches, want 2", outbox.claims)
+	}
+	if !reflect.DeepEqual(outbox.delivered, []string{"delivered"}) || !reflect.DeepEqual(outbox.dead, []string{"dead"}) {
+		t.Errorf("RelayEvents() delivered %v and gave up %v, want [delivered] and [dead]", outbox.delivered, outbox.dead)
+	}
+
+	// The second failure waits twice the backoff
+	retryAt, ok := outbox.retried["retried"]
+	if !ok || retryAt.Before(before.Add(2*time.Minute)) || retryAt.After(time.Now().Add(2*time.Minute)) {
+		t.Errorf("RelayEvents() retries at %v, want in 2 minutes", retryAt)
+	}
+	if reason := outbox.reasons["retried"]; !strings.Contains(reason, "webhook: unavailable") {
+		t.Errorf("RelayEvents() failure reason = %q, want the webhook error", reason)
+	}
+
+	// Every publisher gets every event, whether the others accept it or not
+	if !reflect.DeepEqual(log.published, []string{"delivered", "retried", "dead"}) {
+		t.Errorf("RelayEvents() published %v to the log, want every event", log.published)
+	}
+}
+
+func TestOutboxRelayUseCase_RelayEventsClaimError(t *testing.T) {
+	outbox := &mockOutboxRepository{claimErr: errors.New("database unavailable")}
+	useCase := NewOutboxRelayUseCase(outbox, RetryPolicy{MaxAttempts: 3}, 10, time.Minute)
+
+	if _, err := useCase.RelayEvents(context.Background()); err == nil {
+		t.Error("RelayEvents() expected an error")
+	}
+}
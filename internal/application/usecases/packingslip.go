@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"context"
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// PackingSlipUseCase represents the application use cases for packing slips
+type PackingSlipUseCase struct {
+	calculationUseCase *CalculationUseCase
+	renderers          map[entities.DocumentFormat]secondary.PackingSlipRenderer
+}
+
+// NewPackingSlipUseCase creates a new packing slip use case
+func NewPackingSlipUseCase(
+	calculationUseCase *CalculationUseCase,
+	renderers ...secondary.PackingSlipRenderer,
+) *PackingSlipUseCase {
+	byFormat := make(map[entities.DocumentFormat]secondary.PackingSlipRenderer, len(renderers))
+	for _, renderer := range renderers {
+		byFormat[renderer.Format()] = renderer
+	}
+
+	return &PackingSlipUseCase{
+		calculationUseCase: calculationUseCase,
+		renderers:          byFormat,
+	}
+}
+
+// GeneratePackingSlip generates the packing slip of a stored calculation in the given format
+func (uc *PackingSlipUseCase) GeneratePackingSlip(ctx context.Context, calculationID string, format entities.DocumentFormat) (*entities.Document, error) {
+	// Validate format before loading the calculation
+	renderer, ok := uc.renderers[format]
+	if !ok {
+		return nil, &errors.ValidationError{
+			Field: "format",
+			Err:   errors.ErrUnsupportedFormat,
+		}
+	}
+
+	result, err := uc.calculationUseCase.GetCalculationByID(ctx, calculationID)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderer.Render(ctx, entities.NewPackingSlip(result))
+}
@@ -0,0 +1,86 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go-pack-calculator/internal/domain/entities"
+	domainerrors "go-pack-calculator/internal/domain/errors"
+)
+
+// Mock packing slip renderer for testing
+type mockPackingSlipRenderer struct {
+	format entities.DocumentFormat
+	slip   *entities.PackingSlip
+}
+
+func (m *mockPackingSlipRenderer) Format() entities.DocumentFormat {
+	return m.format
+}
+
+func (m *mockPackingSlipRenderer) Render(ctx context.Context, slip *entities.PackingSlip) (*entities.Document, error) {
+	m.slip = slip
+	return &entities.Document{Filename: "slip." + string(m.format), Content: []byte(slip.CalculationID)}, nil
+}
+
+func TestPackingSlipUseCase_GeneratePackingSlip(t *testing.T) {
+	calculationRepo := newMockCalculationRepository()
+	stored, _ := calculationRepo.Create(context.Background(), entities.NewCalculationResult(12001, map[int]int{5000: 2, 2000: 1, 250: 1}))
+
+	textRenderer := &mockPackingSlipRenderer{format: entities.DocumentFormatText}
+	useCase := NewPackingSlipUseCase(
+		NewCalculationUseCase(&mockPackSizeRepository{}, calculationRepo, &mockShippingRateRepository{}),
+		textRenderer,
+	)
+
+	tests := []struct {
+		name          string
+		calculationID string
+		format        entities.DocumentFormat
+		wantErr       error
+	}{
+		{
+			name:          "Successful generation",
+			calculationID: stored.ID,
+			format:        entities.DocumentFormatText,
+			wantErr:       nil,
+		},
+		{
+			name:          "Unsupported format",
+			calculationID: stored.ID,
+			format:        entities.DocumentFormatPDF,
+			wantErr:       domainerrors.ErrUnsupportedFormat,
+		},
+		{
+			name:          "Calculation not found",
+			calculationID: "unknown-id",
+			format:        entities.DocumentFormatText,
+			wantErr:       domainerrors.ErrCalculationNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			document, err := useCase.GeneratePackingSlip(context.Background(), tt.calculationID, tt.format)
+
+			// Check error
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("GeneratePackingSlip() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			// If expecting an error, don't check the result
+			if tt.wantErr != nil {
+				return
+			}
+
+			if string(document.Content) != stored.ID {
+				t.Errorf("GeneratePackingSlip() content = %s, want %s", document.Content, stored.ID)
+			}
+			if textRenderer.slip.Lines[0].PackSize != 5000 {
+				t.Errorf("GeneratePackingSlip() first line = %v, want pack size 5000", textRenderer.slip.Lines[0])
+			}
+		})
+	}
+}
@@ -0,0 +1,103 @@
+package usecases
+
+import (
+	"context"
+	stderr "errors"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/domain/services"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// PackingTableUseCase represents the application use cases for the precomputed packing table
+type PackingTableUseCase struct {
+	repository        secondary.PackSizeRepository
+	tableRepository   secondary.PackingTableRepository
+	calculatorService *services.PackCalculatorService
+	ceiling           int
+	table             atomic.Pointer[entities.PackingTable]
+	mu                sync.Mutex // Serialises rebuilds so the last one sees the latest pack sizes
+}
+
+// NewPackingTableUseCase creates a new packing table use case for quantities up to the ceiling
+func NewPackingTableUseCase(
+	repository secondary.PackSizeRepository,
+	tableRepository secondary.PackingTableRepository,
+	ceiling int,
+) *PackingTableUseCase {
+	return &PackingTableUseCase{
+		repository:        repository,
+		tableRepository:   tableRepository,
+		calculatorService: services.NewPackCalculatorService(),
+		ceiling:           ceiling,
+	}
+}
+
+// Rebuild makes the table of the current pack sizes available, loading it from the table
+// repository when it was built before and building and storing it otherwise
+func (uc *PackingTableUseCase) Rebuild(ctx context.Context) (*entities.PackingTable, error) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	packSizes, err := findActivePackSizes(ctx, uc.repository, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	if len(packSizes) == 0 {
+		uc.table.Store(nil)
+		return nil, errors.ErrNoPackSizesAvailable
+	}
+
+	sizes := make([]int, len(packSizes))
+	for i, ps := range packSizes {
+		sizes[i] = ps.Size
+	}
+
+	// Keep the current table when the pack sizes did not change
+	if current := uc.table.Load(); current != nil && current.Ceiling == uc.ceiling && current.Matches(sizes) {
+		return current, nil
+	}
+
+	table, err := uc.tableRepository.FindByKey(ctx, entities.PackSizeSetKey(sizes))
+	if err != nil && !stderr.Is(err, errors.ErrPackingTableNotFound) {
+		return nil, err
+	}
+	if table == nil || table.Ceiling != uc.ceiling {
+		table, err = uc.calculatorService.BuildPackingTable(ctx, uc.ceiling, sizes)
+		if err != nil {
+			return nil, err
+		}
+		if err := uc.tableRepository.Save(ctx, table); err != nil {
+			return nil, err
+		}
+	}
+
+	uc.table.Store(table)
+
+	return table, nil
+}
+
+// Table returns the current table when it was built for the given pack sizes, nil otherwise
+func (uc *PackingTableUseCase) Table(sizes []int) *entities.PackingTable {
+	table := uc.table.Load()
+	if table == nil || !table.Matches(sizes) {
+		return nil
+	}
+
+	return table
+}
+
+// Lookup returns the optimal packing of the quantity when the table covers it and was built
+// for the given pack sizes
+func (uc *PackingTableUseCase) Lookup(itemsOrdered int, sizes []int) (map[int]int, bool) {
+	table := uc.Table(sizes)
+	if table == nil {
+		return nil, false
+	}
+
+	return table.Lookup(itemsOrdered)
+}
@@ -0,0 +1,214 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"go-pack-calculator/internal/domain/entities"
+	domainerrors "go-pack-calculator/internal/domain/errors"
+)
+
+// Mock packing table repository for testing
+type mockPackingTableRepository struct {
+	tables map[string]*entities.PackingTable
+	saves  int
+	err    error
+}
+
+func newMockPackingTableRepository() *mockPackingTableRepository {
+	return &mockPackingTableRepository{tables: make(map[string]*entities.PackingTable)}
+}
+
+func (m *mockPackingTableRepository) Save(ctx context.Context, table *entities.PackingTable) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.saves++
+	m.tables[table.Key()] = table
+	return nil
+}
+
+func (m *mockPackingTableRepository) FindByKey(ctx context.Context, key string) (*entities.PackingTable, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	table, ok := m.tables[key]
+	if !ok {
+		return nil, domainerrors.ErrPackingTableNotFound
+	}
+	return table, nil
+}
+
+func TestPackingTableUseCase_Rebuild(t *testing.T) {
+	ctx := context.Background()
+	packSizes := []*entities.PackSize{createTestPackSize(t, 250), createTestPackSize(t, 500)}
+
+	t.Run("Builds and stores the table", func(t *testing.T) {
+		tableRepo := newMockPackingTableRepository()
+		uc := NewPackingTableUseCase(&mockPackSizeRepository{packSizes: packSizes}, tableRepo, 1000)
+
+		table, err := uc.Rebuild(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, []int{500, 250}, table.Sizes)
+		assert.Equal(t, 1000, table.Ceiling)
+		assert.Equal(t, 1, tableRepo.saves)
+
+		packs, ok := uc.Lookup(501, []int{250, 500})
+		require.True(t, ok)
+		assert.Equal(t, map[int]int{500: 1, 250: 1}, packs)
+
+		// Unchanged pack sizes keep the table
+		_, err = uc.Rebuild(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1, tableRepo.saves)
+	})
+
+	t.Run("Loads a stored table", func(t *testing.T) {
+		stored := &entities.PackingTable{Sizes: []int{500, 250}, Ceiling: 1000}
+		tableRepo := newMockPackingTableRepository()
+		tableRepo.tables[stored.Key()] = stored
+		uc := NewPackingTableUseCase(&mockPackSizeRepository{packSizes: packSizes}, tableRepo, 1000)
+
+		table, err := uc.Rebuild(ctx)
+		require.NoError(t, err)
+		assert.Same(t, stored, table)
+		assert.Equal(t, 0, tableRepo.saves)
+	})
+
+	t.Run("Rebuilds a stored table with another ceiling", func(t *testing.T) {
+		tableRepo := newMockPackingTableRepository()
+		tableRepo.tables["500,250"] = &entities.PackingTable{Sizes: []int{500, 250}, Ceiling: 10}
+		uc := NewPackingTableUseCase(&mockPackSizeRepository{packSizes: packSizes}, tableRepo, 1000)
+
+		table, err := uc.Rebuild(ctx)
+		require.NoError(t, err)
+		assert.Equal(t, 1000, table.Ceiling)
+		assert.Equal(t, 1, tableRepo.saves)
+	})
+
+	t.Run("No pack sizes", func(t *testing.T) {
+		uc := NewPackingTableUseCase(&mockPackSizeRepository{}, newMockPackingTableRepository(), 1000)
+
+		_, err := uc.Rebuild(ctx)
+		assert.ErrorIs(t, err, domainerrors.ErrNoPackSizesAvailable)
+
+		_, ok := uc.Lookup(1, []int{250})
+		assert.False(t, ok)
+	})
+
+	t.Run("Repository error", func(t *testing.T) {
+		tableRepo := newMockPackingTableRepository()
+		tableRepo.err = errors.New("database error")
+		uc := NewPackingTableUseCase(&mockPackSizeRepository{packSizes: packSizes}, tableRepo, 1000)
+
+		_, err := uc.Rebuild(ctx)
+		assert.Error(t, err)
+	})
+}
+
+func TestPackingTableUseCase_Lookup(t *testing.T) {
+	packSizes := []*entities.PackSize{createTestPackSize(t, 250), createTestPackSize(t, 500)}
+	uc := NewPackingTableUseCase(&mockPackSizeRepository{packSizes: packSizes}, newMockPackingTableRepository(), 1000)
+	_, err := uc.Rebuild(context.Background())
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		itemsOrdered int
+		sizes        []int
+		wantOK       bool
+	}{
+		{
+			name:         "Covered quantity",
+			itemsOrdered: 1000,
+			sizes:        []int{500, 250},
+			wantOK:       true,
+		},
+		{
+			name:         "Beyond the ceiling",
+			itemsOrdered: 1001,
+			sizes:        []int{500, 250},
+			wantOK:       false,
+		},
+		{
+			name:         "Other pack sizes",
+			itemsOrdered: 1000,
+			sizes:        []int{500, 250, 1000},
+			wantOK:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ok := uc.Lookup(tt.itemsOrdered, tt.sizes)
+			assert.Equal(t, tt.wantOK, ok)
+		})
+	}
+}
+
+func TestCalculationUseCase_CalculatePacksForOrderWithPackingTable(t *testing.T) {
+	packSizes := []*entities.PackSize{
+		createTestPackSize(t, 23),
+		createTestPackSize(t, 31),
+		createTestPackSize(t, 53),
+	}
+	repo := &mockPackSizeRepository{packSizes: packSizes}
+
+	packingTable := NewPackingTableUseCase(repo, newMockPackingTableRepository(), 20000)
+	_, err := packingTable.Rebuild(context.Background())
+	require.NoError(t, err)
+
+	// Limits no calculation fits in, so only the table can answer
+	uc := NewCalculationUseCase(repo, newMockCalculationRepository(), &mockShippingRateRepository{})
+	uc.SetLimits(CalculationLimits{MaxMemoryBytes: 1})
+	uc.SetPackingTable(packingTable)
+
+	tests := []struct {
+		name         string
+		itemsOrdered int
+		options      entities.CalculationOptions
+		wantErr      error
+	}{
+		{
+			name:         "Served from the table",
+			itemsOrdered: 12001,
+			options:      entities.CalculationOptions{},
+		},
+		{
+			name:         "Beyond the ceiling falls back to the solver",
+			itemsOrdered: 20001,
+			options:      entities.CalculationOptions{},
+			wantErr:      domainerrors.ErrCalculationTooLarge,
+		},
+		{
+			name:         "Other objectives fall back to the solver",
+			itemsOrdered: 12001,
+			options:      entities.CalculationOptions{Objective: entities.ObjectiveLeastMaterial},
+			wantErr:      domainerrors.ErrCalculationTooLarge,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := uc.CalculatePacksForOrder(context.Background(), tt.itemsOrdered, tt.options)
+
+			// Check error
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("CalculatePacksForOrder() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			// If expecting an error, don't check the result
+			if tt.wantErr != nil {
+				return
+			}
+
+			want, err := uc.calculatorService.CalculateOptimalPacks(context.Background(), tt.itemsOrdered, []int{23, 31, 53})
+			require.NoError(t, err)
+			assert.Equal(t, want, result.Packs)
+		})
+	}
+}
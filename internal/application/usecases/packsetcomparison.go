@@ -0,0 +1,127 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// PackSetComparisonUseCase represents the application use cases for comparing pack size sets
+type PackSetComparisonUseCase struct {
+	repository         secondary.PackSizeRepository
+	calculationUseCase *CalculationUseCase
+}
+
+// NewPackSetComparisonUseCase creates a new pack set comparison use case
+func NewPackSetComparisonUseCase(
+	repository secondary.PackSizeRepository,
+	calculationUseCase *CalculationUseCase,
+) *PackSetComparisonUseCase {
+	return &PackSetComparisonUseCase{
+		repository:         repository,
+		calculationUseCase: calculationUseCase,
+	}
+}
+
+// ComparePackSets packs every quantity of the demand with the current and the candidate pack
+// sizes and aggregates the results of both sets. The stored pack sizes are used as the current
+// set when none is given. The results are not stored.
+func (uc *PackSetComparisonUseCase) ComparePackSets(
+	ctx context.Context,
+	current []int,
+	candidate []int,
+	demand []entities.QuantityDemand,
+) (*entities.PackSetComparison, error) {
+	// Validate input
+	if err := validatePackSet("candidate_sizes", candidate); err != nil {
+		return nil, err
+	}
+	if current != nil {
+		if err := validatePackSet("current_sizes", current); err != nil {
+			return nil, err
+		}
+	}
+	if len(demand) == 0 {
+		return nil, errors.ErrNoQuantities
+	}
+	if len(demand) > maxTableRows {
+		return nil, &errors.ValidationError{
+			Field: "quantities",
+			Err:   fmt.Errorf("%w: at most %d quantities per comparison", errors.ErrInvalidRange, maxTableRows),
+		}
+	}
+
+	ceiling := 0
+	for _, quantity := range demand {
+		if quantity.ItemsOrdered <= 0 {
+			return nil, &errors.ValidationError{Field: "items_ordered", Err: errors.ErrInvalidItemsOrdered}
+		}
+		if quantity.Frequency <= 0 {
+			return nil, &errors.ValidationError{Field: "frequency", Err: errors.ErrInvalidFrequency}
+		}
+		ceiling = max(ceiling, quantity.ItemsOrdered)
+	}
+
+	// Compare with the active stored pack sizes by default
+	if current == nil {
+		packSizes, err := findActivePackSizes(ctx, uc.repository, time.Now())
+		if err != nil {
+			return nil, err
+		}
+		if len(packSizes) == 0 {
+			return nil, errors.ErrNoPackSizesAvailable
+		}
+		current, _ = packSizesBySize(packSizes)
+	}
+
+	// Solve every quantity of each set in a single pass
+	currentTable, err := uc.calculationUseCase.packingTableFor(ctx, current, ceiling)
+	if err != nil {
+		return nil, err
+	}
+	candidateTable, err := uc.calculationUseCase.packingTableFor(ctx, candidate, ceiling)
+	if err != nil {
+		return nil, err
+	}
+
+	comparison := &entities.PackSetComparison{
+		Quantities: make([]entities.QuantityComparison, len(demand)),
+	}
+	currentResults := make([]*entities.CalculationResult, len(demand))
+	candidateResults := make([]*entities.CalculationResult, len(demand))
+	for i, quantity := range demand {
+		currentPacks, _ := currentTable.Lookup(quantity.ItemsOrdered)
+		candidatePacks, _ := candidateTable.Lookup(quantity.ItemsOrdered)
+		currentResults[i] = entities.NewCalculationResult(quantity.ItemsOrdered, currentPacks)
+		candidateResults[i] = entities.NewCalculationResult(quantity.ItemsOrdered, candidatePacks)
+
+		comparison.Quantities[i] = entities.QuantityComparison{
+			ItemsOrdered: quantity.ItemsOrdered,
+			Frequency:    quantity.Frequency,
+			Current:      currentResults[i],
+			Candidate:    candidateResults[i],
+		}
+	}
+	comparison.Current = entities.NewPackSetMetrics(currentTable.Sizes, demand, currentResults)
+	comparison.Candidate = entities.NewPackSetMetrics(candidateTable.Sizes, demand, candidateResults)
+
+	return comparison, nil
+}
+
+// validatePackSet checks a pack size set given in a request
+func validatePackSet(field string, sizes []int) error {
+	if len(sizes) == 0 {
+		return &errors.ValidationError{Field: field, Err: errors.ErrNoPackSizesAvailable}
+	}
+	for _, size := range sizes {
+		if size <= 0 {
+			return &errors.ValidationError{Field: field, Err: errors.ErrInvalidPackSize}
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,127 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go-pack-calculator/internal/domain/entities"
+	domainerrors "go-pack-calculator/internal/domain/errors"
+)
+
+func TestPackSetComparisonUseCase_ComparePackSets(t *testing.T) {
+	packSizes := []*entities.PackSize{
+		createTestPackSize(t, 250),
+		createTestPackSize(t, 500),
+		createTestPackSize(t, 1000),
+	}
+	demand := []entities.QuantityDemand{
+		{ItemsOrdered: 1, Frequency: 2},
+		{ItemsOrdered: 251, Frequency: 1},
+		{ItemsOrdered: 12001, Frequency: 1},
+	}
+
+	tests := []struct {
+		name          string
+		packSizes     []*entities.PackSize
+		current       []int
+		candidate     []int
+		demand        []entities.QuantityDemand
+		wantCurrent   []int
+		wantCandidate []int
+		wantErr       error
+	}{
+		{
+			name:          "Stored pack sizes as current set",
+			packSizes:     packSizes,
+			candidate:     []int{100, 300, 1000},
+			demand:        demand,
+			wantCurrent:   []int{1000, 500, 250},
+			wantCandidate: []int{1000, 300, 100},
+		},
+		{
+			name:          "Given current set",
+			current:       []int{23, 31, 53},
+			candidate:     []int{250, 500, 1000},
+			demand:        demand,
+			wantCurrent:   []int{53, 31, 23},
+			wantCandidate: []int{1000, 500, 250},
+		},
+		{
+			name:      "No stored pack sizes",
+			candidate: []int{100},
+			demand:    demand,
+			wantErr:   domainerrors.ErrNoPackSizesAvailable,
+		},
+		{
+			name:      "Invalid candidate set",
+			packSizes: packSizes,
+			candidate: []int{100, 0},
+			demand:    demand,
+			wantErr:   domainerrors.ErrInvalidPackSize,
+		},
+		{
+			name:      "No quantities",
+			packSizes: packSizes,
+			candidate: []int{100},
+			wantErr:   domainerrors.ErrNoQuantities,
+		},
+		{
+			name:      "Invalid quantity",
+			packSizes: packSizes,
+			candidate: []int{100},
+			demand:    []entities.QuantityDemand{{ItemsOrdered: 0, Frequency: 1}},
+			wantErr:   domainerrors.ErrInvalidItemsOrdered,
+		},
+		{
+			name:      "Invalid frequency",
+			packSizes: packSizes,
+			candidate: []int{100},
+			demand:    []entities.QuantityDemand{{ItemsOrdered: 10, Frequency: 0}},
+			wantErr:   domainerrors.ErrInvalidFrequency,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := &mockPackSizeRepository{packSizes: tt.packSizes}
+			calculationUseCase := NewCalculationUseCase(repo, newMockCalculationRepository(), &mockShippingRateRepository{})
+			useCase := NewPackSetComparisonUseCase(repo, calculationUseCase)
+
+			comparison, err := useCase.ComparePackSets(context.Background(), tt.current, tt.candidate, tt.demand)
+
+			// Check error
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("ComparePackSets() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			// If expecting an error, don't check the result
+			if tt.wantErr != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(comparison.Current.PackSizes, tt.wantCurrent) ||
+				!reflect.DeepEqual(comparison.Candidate.PackSizes, tt.wantCandidate) {
+				t.Errorf("ComparePackSets() compared %v with %v, want %v with %v",
+					comparison.Current.PackSizes, comparison.Candidate.PackSizes, tt.wantCurrent, tt.wantCandidate)
+			}
+
+			// Every quantity is packed as the solver packs it with each set
+			service := calculationUseCase.calculatorService
+			for i, quantity := range comparison.Quantities {
+				if quantity.ItemsOrdered != tt.demand[i].ItemsOrdered || quantity.Frequency != tt.demand[i].Frequency {
+					t.Fatalf("ComparePackSets() quantity %d = %+v, want %+v", i, quantity, tt.demand[i])
+				}
+
+				wantCurrent, _ := service.CalculateOptimalPacks(context.Background(), quantity.ItemsOrdered, tt.wantCurrent)
+				wantCandidate, _ := service.CalculateOptimalPacks(context.Background(), quantity.ItemsOrdered, tt.wantCandidate)
+				if !reflect.DeepEqual(quantity.Current.Packs, wantCurrent) || !reflect.DeepEqual(quantity.Candidate.Packs, wantCandidate) {
+					t.Errorf("ComparePackSets() packed %d as %v and %v, want %v and %v",
+						quantity.ItemsOrdered, quantity.Current.Packs, quantity.Candidate.Packs, wantCurrent, wantCandidate)
+				}
+			}
+		})
+	}
+}
@@ -1,51 +1,170 @@
 package usecases
 
 import (
+	"context"
+	stderr "errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
 	"go-pack-calculator/internal/domain/entities"
 	"go-pack-calculator/internal/domain/errors"
 	"go-pack-calculator/internal/ports/secondary"
+	"go-pack-calculator/internal/shared/types"
 )
 
 // PackSizeUseCase represents the application use cases for pack sizes
 type PackSizeUseCase struct {
-	repository secondary.PackSizeRepository
+	repository    secondary.PackSizeRepository
+	transactions  secondary.TransactionManager
+	mu            sync.RWMutex
+	listeners     []func()
+	watchValidity bool
+	validityTimer *time.Timer
 }
 
-// NewPackSizeUseCase creates a new pack size use case
-func NewPackSizeUseCase(repository secondary.PackSizeRepository) *PackSizeUseCase {
+// NewPackSizeUseCase creates a new pack size use case, reading from the repository and making
+// changes in transactions of the transaction manager
+func NewPackSizeUseCase(repository secondary.PackSizeRepository, transactions secondary.TransactionManager) *PackSizeUseCase {
 	return &PackSizeUseCase{
-		repository: repository,
+		repository:   repository,
+		transactions: transactions,
+	}
+}
+
+// OnChange registers a listener called after a pack size is created, updated or deleted, and with
+// WatchValidity when one starts or stops being valid
+func (uc *PackSizeUseCase) OnChange(listener func()) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	uc.listeners = append(uc.listeners, listener)
+}
+
+// notifyChange calls the listeners registered with OnChange
+func (uc *PackSizeUseCase) notifyChange(ctx context.Context) {
+	uc.mu.RLock()
+	listeners := uc.listeners
+	watchValidity := uc.watchValidity
+	uc.mu.RUnlock()
+
+	for _, listener := range listeners {
+		listener()
+	}
+
+	// The change may have moved the next validity boundary. It is already saved, so a failure only
+	// leaves the previous schedule in place.
+	if watchValidity {
+		_ = uc.scheduleValidityChange(context.WithoutCancel(ctx))
 	}
 }
 
+// WatchValidity also notifies the OnChange listeners whenever a pack size starts or stops being
+// valid, as the pack sizes calculations use then change without being written
+func (uc *PackSizeUseCase) WatchValidity(ctx context.Context) error {
+	uc.mu.Lock()
+	uc.watchValidity = true
+	uc.mu.Unlock()
+
+	return uc.scheduleValidityChange(ctx)
+}
+
+// scheduleValidityChange sets the timer notifying the listeners at the next validity boundary
+func (uc *PackSizeUseCase) scheduleValidityChange(ctx context.Context) error {
+	packSizes, err := uc.repository.FindAll(ctx)
+	if err != nil {
+		return err
+	}
+	next, ok := nextValidityChange(packSizes, time.Now())
+
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	if uc.validityTimer != nil {
+		uc.validityTimer.Stop()
+		uc.validityTimer = nil
+	}
+	if ok {
+		uc.validityTimer = time.AfterFunc(time.Until(next), func() {
+			uc.notifyChange(context.Background())
+		})
+	}
+
+	return nil
+}
+
+// nextValidityChange returns the earliest start or end of a validity period after the given time
+func nextValidityChange(packSizes []*entities.PackSize, after time.Time) (time.Time, bool) {
+	var next time.Time
+	found := false
+	for _, ps := range packSizes {
+		for _, boundary := range []*time.Time{ps.ValidFrom, ps.ValidTo} {
+			if boundary != nil && boundary.After(after) && (!found || boundary.Before(next)) {
+				next = *boundary
+				found = true
+			}
+		}
+	}
+
+	return next, found
+}
+
+// addEvents adds events of a change of the pack sizes to the outbox of the transaction making it,
+// attributed to the request info of the context
+func addEvents(ctx context.Context, tx secondary.Transaction, eventType entities.PackSizeEventType, packSizes ...*entities.PackSize) error {
+	if len(packSizes) == 0 {
+		return nil
+	}
+
+	info := types.RequestInfoFromContext(ctx)
+	events := make([]*entities.PackSizeEvent, len(packSizes))
+	for i, ps := range packSizes {
+		events[i] = entities.NewPackSizeEvent(eventType, ps, info.ClaimedActor, info.RequestID)
+	}
+
+	return tx.Outbox().Add(ctx, events...)
+}
+
 // CreatePackSize creates a new pack size
-func (uc *PackSizeUseCase) CreatePackSize(size int) (*entities.PackSize, error) {
+func (uc *PackSizeUseCase) CreatePackSize(ctx context.Context, size int, attributes entities.PackSizeAttributes) (*entities.PackSize, error) {
 	// Create a new pack size entity
-	packSize, err := entities.NewPackSize(size)
+	packSize, err := newPackSize(entities.PackSizeDefinition{Size: size, Attributes: attributes})
 	if err != nil {
-		return nil, &errors.ValidationError{
-			Field: "size",
-			Err:   err,
-		}
+		return nil, err
 	}
 
 	// Save to repository
-	return uc.repository.Create(packSize)
+	var created *entities.PackSize
+	err = uc.transactions.WithinTransaction(ctx, func(tx secondary.Transaction) error {
+		created, err = tx.PackSizes().Create(ctx, packSize)
+		if err != nil {
+			return err
+		}
+
+		return addEvents(ctx, tx, entities.PackSizeCreated, created)
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.notifyChange(ctx)
+
+	return created, nil
 }
 
 // GetAllPackSizes retrieves all pack sizes
-func (uc *PackSizeUseCase) GetAllPackSizes() ([]*entities.PackSize, error) {
-	return uc.repository.FindAll()
+func (uc *PackSizeUseCase) GetAllPackSizes(ctx context.Context) ([]*entities.PackSize, error) {
+	return uc.repository.FindAll(ctx)
 }
 
 // GetAllPackSizesWithPagination retrieves all pack sizes with pagination
-func (uc *PackSizeUseCase) GetAllPackSizesWithPagination(page, limit int64) ([]*entities.PackSize, int64, error) {
-	return uc.repository.FindAllPaginated(page, limit)
+func (uc *PackSizeUseCase) GetAllPackSizesWithPagination(ctx context.Context, page, limit int64) ([]*entities.PackSize, int64, error) {
+	return uc.repository.FindAllPaginated(ctx, page, limit)
 }
 
 // GetPackSizeByID retrieves a pack size by ID
-func (uc *PackSizeUseCase) GetPackSizeByID(id string) (*entities.PackSize, error) {
-	packSize, err := uc.repository.FindByID(id)
+func (uc *PackSizeUseCase) GetPackSizeByID(ctx context.Context, id string) (*entities.PackSize, error) {
+	packSize, err := uc.repository.FindByID(ctx, id)
 	if err != nil {
 		return nil, &errors.NotFoundError{
 			ID:  id,
@@ -56,8 +175,8 @@ func (uc *PackSizeUseCase) GetPackSizeByID(id string) (*entities.PackSize, error
 	return packSize, nil
 }
 
-// UpdatePackSize updates a pack size
-func (uc *PackSizeUseCase) UpdatePackSize(id string, size int) (*entities.PackSize, error) {
+// UpdatePackSize updates a pack size if its version is the expected one, any version when zero
+func (uc *PackSizeUseCase) UpdatePackSize(ctx context.Context, id string, version int64, size int, attributes entities.PackSizeAttributes) (*entities.PackSize, error) {
 	// Validate size
 	if size <= 0 {
 		return nil, &errors.ValidationError{
@@ -66,38 +185,361 @@ func (uc *PackSizeUseCase) UpdatePackSize(id string, size int) (*entities.PackSi
 		}
 	}
 
-	// Get existing pack size
-	packSize, err := uc.repository.FindByID(id)
+	var updated *entities.PackSize
+	err := uc.transactions.WithinTransaction(ctx, func(tx secondary.Transaction) error {
+		// Get existing pack size
+		packSize, err := tx.PackSizes().FindByID(ctx, id)
+		if err != nil {
+			return &errors.NotFoundError{
+				ID:  id,
+				Err: errors.ErrPackSizeNotFound,
+			}
+		}
+		if version != 0 && packSize.Version != version {
+			return fmt.Errorf("%w: version %d, not %d", errors.ErrVersionMismatch, packSize.Version, version)
+		}
+
+		// Update pack size
+		if err := packSize.Update(size); err != nil {
+			return &errors.ValidationError{
+				Field: "size",
+				Err:   err,
+			}
+		}
+
+		// Update optional properties
+		if err := packSize.SetAttributes(attributes); err != nil {
+			return err
+		}
+
+		// Save to repository
+		updated, err = tx.PackSizes().Update(ctx, packSize)
+		if err != nil {
+			return err
+		}
+
+		return addEvents(ctx, tx, entities.PackSizeUpdated, updated)
+	})
 	if err != nil {
-		return nil, &errors.NotFoundError{
-			ID:  id,
-			Err: errors.ErrPackSizeNotFound,
+		return nil, err
+	}
+	uc.notifyChange(ctx)
+
+	return updated, nil
+}
+
+// DeletePackSize moves a pack size to the trash if its version is the expected one, any version
+// when zero
+func (uc *PackSizeUseCase) DeletePackSize(ctx context.Context, id string, version int64) error {
+	err := uc.transactions.WithinTransaction(ctx, func(tx secondary.Transaction) error {
+		// Check if pack size exists, locking it against concurrent changes
+		packSize, err := tx.PackSizes().FindByID(ctx, id)
+		if err != nil {
+			return &errors.NotFoundError{
+				ID:  id,
+				Err: errors.ErrPackSizeNotFound,
+			}
+		}
+
+		// Delete from repository
+		if err := tx.PackSizes().Delete(ctx, id, version); err != nil {
+			return err
+		}
+
+		return addEvents(ctx, tx, entities.PackSizeDeleted, packSize)
+	})
+	if err != nil {
+		return err
+	}
+	uc.notifyChange(ctx)
+
+	return nil
+}
+
+// GetDeletedPackSizes retrieves the pack sizes in the trash
+func (uc *PackSizeUseCase) GetDeletedPackSizes(ctx context.Context) ([]*entities.PackSize, error) {
+	return uc.repository.FindDeleted(ctx)
+}
+
+// RestorePackSize takes a pack size out of the trash
+func (uc *PackSizeUseCase) RestorePackSize(ctx context.Context, id string) (*entities.PackSize, error) {
+	var restored *entities.PackSize
+	err := uc.transactions.WithinTransaction(ctx, func(tx secondary.Transaction) error {
+		var err error
+		restored, err = tx.PackSizes().Restore(ctx, id)
+		if err != nil {
+			return err
+		}
+
+		return addEvents(ctx, tx, entities.PackSizeRestored, restored)
+	})
+	if err != nil {
+		if stderr.Is(err, errors.ErrPackSizeNotFound) {
+			return nil, &errors.NotFoundError{
+				ID:  id,
+				Err: errors.ErrPackSizeNotFound,
+			}
+		}
+
+		return nil, err
+	}
+	uc.notifyChange(ctx)
+
+	return restored, nil
+}
+
+// PurgeDeletedPackSizes permanently removes the pack sizes deleted longer ago than the retention
+func (uc *PackSizeUseCase) PurgeDeletedPackSizes(ctx context.Context, retention time.Duration) (int64, error) {
+	return uc.repository.PurgeDeleted(ctx, time.Now().Add(-retention))
+}
+
+// CreatePackSizes creates several pack sizes, all of them or none
+func (uc *PackSizeUseCase) CreatePackSizes(ctx context.Context, definitions []entities.PackSizeDefinition) ([]*entities.PackSize, error) {
+	packSizes, err := newPackSizes(definitions)
+	if err != nil {
+		return nil, err
+	}
+
+	created := make([]*entities.PackSize, 0, len(packSizes))
+	err = uc.transactions.WithinTransaction(ctx, func(tx secondary.Transaction) error {
+		repository := tx.PackSizes()
+		for _, packSize := range packSizes {
+			ps, err := repository.Create(ctx, packSize)
+			if err != nil {
+				return err
+			}
+			created = append(created, ps)
+		}
+
+		return addEvents(ctx, tx, entities.PackSizeCreated, created...)
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.notifyChange(ctx)
+
+	return created, nil
+}
+
+// DeletePackSizes moves several pack sizes to the trash, all of them or none
+func (uc *PackSizeUseCase) DeletePackSizes(ctx context.Context, ids []string) error {
+	if len(ids) == 0 {
+		return &errors.ValidationError{
+			Field: "ids",
+			Err:   fmt.Errorf("%w: no pack sizes to delete", errors.ErrInvalidPackSize),
+		}
+	}
+
+	err := uc.transactions.WithinTransaction(ctx, func(tx secondary.Transaction) error {
+		repository := tx.PackSizes()
+		seen := make(map[string]bool, len(ids))
+		deleted := make([]*entities.PackSize, 0, len(ids))
+		for _, id := range ids {
+			if seen[id] {
+				continue
+			}
+			seen[id] = true
+
+			// Keep the pack size for its event, locking it against concurrent changes
+			notFound := &errors.NotFoundError{
+				ID:  id,
+				Err: errors.ErrPackSizeNotFound,
+			}
+			packSize, err := repository.FindByID(ctx, id)
+			if err != nil {
+				return notFound
+			}
+
+			if err := repository.Delete(ctx, id, 0); err != nil {
+				if stderr.Is(err, errors.ErrPackSizeNotFound) {
+					return notFound
+				}
+
+				return err
+			}
+			deleted = append(deleted, packSize)
+		}
+
+		return addEvents(ctx, tx, entities.PackSizeDeleted, deleted...)
+	})
+	if err != nil {
+		return err
+	}
+	uc.notifyChange(ctx)
+
+	return nil
+}
+
+// ReplacePackSizes makes the pack sizes exactly the defined ones at once: pack sizes of a size
+// that is not defined are deleted, the others are updated to the defined properties when they
+// differ and the missing sizes are created. Calculations never see a partly replaced set.
+func (uc *PackSizeUseCase) ReplacePackSizes(ctx context.Context, definitions []entities.PackSizeDefinition) (*entities.PackSizeChanges, error) {
+	replacements, err := newPackSizes(definitions)
+	if err != nil {
+		return nil, err
+	}
+
+	var changes *entities.PackSizeChanges
+	err = uc.transactions.WithinTransaction(ctx, func(tx secondary.Transaction) error {
+		current, err := tx.PackSizes().FindAll(ctx)
+		if err != nil {
+			return err
+		}
+
+		changes, err = applyPackSizeChanges(ctx, tx, planPackSizeChanges(current, replacements, true))
+
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	uc.notifyChange(ctx)
+
+	return changes, nil
+}
+
+// planPackSizeChanges returns the changes making the current pack sizes have the wanted sizes
+// and properties: the missing sizes are created and the existing ones updated when their
+// properties differ. Pack sizes of other sizes are deleted when deleteOthers is set. The planned
+// updates are the current pack sizes with the wanted properties.
+func planPackSizeChanges(current, wanted []*entities.PackSize, deleteOthers bool) *entities.PackSizeChanges {
+	sort.Slice(current, func(i, j int) bool {
+		return current[i].Size < current[j].Size
+	})
+	bySize := make(map[int]*entities.PackSize, len(current))
+	for _, ps := range current {
+		bySize[ps.Size] = ps
+	}
+
+	changes := &entities.PackSizeChanges{
+		Created: []*entities.PackSize{},
+		Updated: []*entities.PackSize{},
+		Deleted: []*entities.PackSize{},
+	}
+
+	if deleteOthers {
+		wantedSizes := make(map[int]bool, len(wanted))
+		for _, ps := range wanted {
+			wantedSizes[ps.Size] = true
+		}
+		for _, ps := range current {
+			if !wantedSizes[ps.Size] {
+				changes.Deleted = append(changes.Deleted, ps)
+			}
+		}
+	}
+
+	for _, ps := range wanted {
+		existing, ok := bySize[ps.Size]
+		switch {
+		case !ok:
+			changes.Created = append(changes.Created, ps)
+		case !existing.Attributes().Equal(ps.Attributes()):
+			// The wanted pack size is valid, so are its properties
+			_ = existing.SetAttributes(ps.Attributes())
+			changes.Updated = append(changes.Updated, existing)
+		}
+	}
+
+	return changes
+}
+
+// applyPackSizeChanges saves planned changes in the transaction with their events, deleting first
+// so the sizes of the deleted pack sizes are free, and returns the changes as saved
+func applyPackSizeChanges(ctx context.Context, tx secondary.Transaction, planned *entities.PackSizeChanges) (*entities.PackSizeChanges, error) {
+	repository := tx.PackSizes()
+	changes := &entities.PackSizeChanges{
+		Created: make([]*entities.PackSize, 0, len(planned.Created)),
+		Updated: make([]*entities.PackSize, 0, len(planned.Updated)),
+		Deleted: planned.Deleted,
+	}
+
+	for _, ps := range planned.Deleted {
+		if err := repository.Delete(ctx, ps.ID, ps.Version); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, ps := range planned.Created {
+		created, err := repository.Create(ctx, ps)
+		if err != nil {
+			return nil, err
 		}
+		changes.Created = append(changes.Created, created)
 	}
 
-	// Update pack size
-	if err := packSize.Update(size); err != nil {
+	for _, ps := range planned.Updated {
+		updated, err := repository.Update(ctx, ps)
+		if err != nil {
+			return nil, err
+		}
+		changes.Updated = append(changes.Updated, updated)
+	}
+
+	// Announce the changes in the order they were saved
+	if err := addEvents(ctx, tx, entities.PackSizeDeleted, changes.Deleted...); err != nil {
+		return nil, err
+	}
+	if err := addEvents(ctx, tx, entities.PackSizeCreated, changes.Created...); err != nil {
+		return nil, err
+	}
+	if err := addEvents(ctx, tx, entities.PackSizeUpdated, changes.Updated...); err != nil {
+		return nil, err
+	}
+
+	return changes, nil
+}
+
+// newPackSize creates a validated pack size entity of the definition
+func newPackSize(definition entities.PackSizeDefinition) (*entities.PackSize, error) {
+	packSize, err := entities.NewPackSize(definition.Size)
+	if err != nil {
 		return nil, &errors.ValidationError{
 			Field: "size",
 			Err:   err,
 		}
 	}
 
-	// Save to repository
-	return uc.repository.Update(packSize)
+	// Set optional properties
+	if err := packSize.SetAttributes(definition.Attributes); err != nil {
+		return nil, err
+	}
+
+	return packSize, nil
 }
 
-// DeletePackSize deletes a pack size
-func (uc *PackSizeUseCase) DeletePackSize(id string) error {
-	// Check if pack size exists
-	_, err := uc.repository.FindByID(id)
-	if err != nil {
-		return &errors.NotFoundError{
-			ID:  id,
-			Err: errors.ErrPackSizeNotFound,
+// newPackSizes creates the validated pack size entities of a non-empty list of definitions with
+// distinct sizes, naming the position of an invalid definition in the field of its error
+func newPackSizes(definitions []entities.PackSizeDefinition) ([]*entities.PackSize, error) {
+	if len(definitions) == 0 {
+		return nil, &errors.ValidationError{
+			Field: "pack_sizes",
+			Err:   fmt.Errorf("%w: at least one pack size is required", errors.ErrInvalidPackSize),
+		}
+	}
+
+	packSizes := make([]*entities.PackSize, len(definitions))
+	positions := make(map[int]int, len(definitions))
+	for i, definition := range definitions {
+		packSize, err := newPackSize(definition)
+		if err != nil {
+			var validationErr *errors.ValidationError
+			if stderr.As(err, &validationErr) {
+				return nil, &errors.ValidationError{
+					Field: fmt.Sprintf("pack_sizes[%d].%s", i, validationErr.Field),
+					Err:   validationErr.Err,
+				}
+			}
+
+			return nil, err
+		}
+
+		if first, ok := positions[packSize.Size]; ok {
+			return nil, fmt.Errorf("%w: size %d at pack_sizes[%d] and pack_sizes[%d]", errors.ErrDuplicatePackSize, packSize.Size, first, i)
 		}
+		positions[packSize.Size] = i
+		packSizes[i] = packSize
 	}
 
-	// Delete from repository
-	return uc.repository.Delete(id)
+	return packSizes, nil
 }
@@ -1,11 +1,16 @@
 package usecases
 
 import (
+	"context"
 	"errors"
 	"reflect"
 	"testing"
+	"time"
 
 	"go-pack-calculator/internal/domain/entities"
+	domainerrors "go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/ports/secondary"
+	"go-pack-calculator/internal/shared/types"
 )
 
 // Mock repository for testing PackSizeUseCase
@@ -17,44 +22,91 @@ type mockPackSizeRepoForPackSize struct {
 	updateErr    error
 	deleteErr    error
 	findByIDErr  error
+	restoreErr   error
 	totalCount   int64
+	purgedBefore time.Time
+	created      []*entities.PackSize
+	updated      []*entities.PackSize
+	deletedIDs   []string
 }
 
-func (m *mockPackSizeRepoForPackSize) Create(packSize *entities.PackSize) (*entities.PackSize, error) {
+// Mock transaction manager running the work on the repository and outbox directly
+type mockTransactionManager struct {
+	repository   secondary.PackSizeRepository
+	outbox       mockOutboxRepository
+	transactions int
+}
+
+func (m *mockTransactionManager) WithinTransaction(ctx context.Context, work func(tx secondary.Transaction) error) error {
+	m.transactions++
+	return work(m)
+}
+
+func (m *mockTransactionManager) PackSizes() secondary.PackSizeRepository {
+	return m.repository
+}
+
+func (m *mockTransactionManager) Outbox() secondary.OutboxRepository {
+	return &m.outbox
+}
+
+func (m *mockPackSizeRepoForPackSize) Create(ctx context.Context, packSize *entities.PackSize) (*entities.PackSize, error) {
 	if m.createErr != nil {
 		return nil, m.createErr
 	}
 	packSize.ID = "test-id"
+	m.created = append(m.created, packSize)
 	return packSize, nil
 }
 
-func (m *mockPackSizeRepoForPackSize) FindAll() ([]*entities.PackSize, error) {
+func (m *mockPackSizeRepoForPackSize) FindAll(ctx context.Context) ([]*entities.PackSize, error) {
 	return m.packSizes, m.err
 }
 
-func (m *mockPackSizeRepoForPackSize) FindAllPaginated(page, limit int64) ([]*entities.PackSize, int64, error) {
+func (m *mockPackSizeRepoForPackSize) FindAllPaginated(ctx context.Context, page, limit int64) ([]*entities.PackSize, int64, error) {
 	if m.err != nil {
 		return nil, 0, m.err
 	}
 	return m.packSizes, m.totalCount, nil
 }
 
-func (m *mockPackSizeRepoForPackSize) FindByID(id string) (*entities.PackSize, error) {
+func (m *mockPackSizeRepoForPackSize) FindByID(ctx context.Context, id string) (*entities.PackSize, error) {
 	if m.findByIDErr != nil {
 		return nil, m.findByIDErr
 	}
 	return m.packSizeByID, nil
 }
 
-func (m *mockPackSizeRepoForPackSize) Update(packSize *entities.PackSize) (*entities.PackSize, error) {
+func (m *mockPackSizeRepoForPackSize) Update(ctx context.Context, packSize *entities.PackSize) (*entities.PackSize, error) {
 	if m.updateErr != nil {
 		return nil, m.updateErr
 	}
+	m.updated = append(m.updated, packSize)
 	return packSize, nil
 }
 
-func (m *mockPackSizeRepoForPackSize) Delete(id string) error {
-	return m.deleteErr
+func (m *mockPackSizeRepoForPackSize) Delete(ctx context.Context, id string, version int64) error {
+	if m.deleteErr != nil {
+		return m.deleteErr
+	}
+	m.deletedIDs = append(m.deletedIDs, id)
+	return nil
+}
+
+func (m *mockPackSizeRepoForPackSize) FindDeleted(ctx context.Context) ([]*entities.PackSize, error) {
+	return m.packSizes, m.err
+}
+
+func (m *mockPackSizeRepoForPackSize) Restore(ctx context.Context, id string) (*entities.PackSize, error) {
+	if m.restoreErr != nil {
+		return nil, m.restoreErr
+	}
+	return m.packSizeByID, nil
+}
+
+func (m *mockPackSizeRepoForPackSize) PurgeDeleted(ctx context.Context, before time.Time) (int64, error) {
+	m.purgedBefore = before
+	return int64(len(m.packSizes)), m.err
 }
 
 func TestPackSizeUseCase_CreatePackSize(t *testing.T) {
@@ -92,10 +144,10 @@ func TestPackSizeUseCase_CreatePackSize(t *testing.T) {
 			}
 
 			// Create use case
-			useCase := NewPackSizeUseCase(mockRepo)
+			useCase := NewPackSizeUseCase(mockRepo, &mockTransactionManager{repository: mockRepo})
 
 			// Call the method
-			result, err := useCase.CreatePackSize(tt.size)
+			result, err := useCase.CreatePackSize(context.Background(), tt.size, entities.PackSizeAttributes{})
 
 			// Check error
 			if (err != nil) != tt.wantErr {
@@ -167,10 +219,10 @@ func TestPackSizeUseCase_GetAllPackSizes(t *testing.T) {
 			}
 
 			// Create use case
-			useCase := NewPackSizeUseCase(mockRepo)
+			useCase := NewPackSizeUseCase(mockRepo, &mockTransactionManager{repository: mockRepo})
 
 			// Call the method
-			result, err := useCase.GetAllPackSizes()
+			result, err := useCase.GetAllPackSizes(context.Background())
 
 			// Check error
 			if (err != nil) != tt.wantErr {
@@ -233,10 +285,10 @@ func TestPackSizeUseCase_GetPackSizeByID(t *testing.T) {
 			}
 
 			// Create use case
-			useCase := NewPackSizeUseCase(mockRepo)
+			useCase := NewPackSizeUseCase(mockRepo, &mockTransactionManager{repository: mockRepo})
 
 			// Call the method
-			result, err := useCase.GetPackSizeByID(tt.id)
+			result, err := useCase.GetPackSizeByID(context.Background(), tt.id)
 
 			// Check error
 			if (err != nil) != tt.wantErr {
@@ -261,10 +313,12 @@ func TestPackSizeUseCase_UpdatePackSize(t *testing.T) {
 	// Create test pack size
 	testPackSize, _ := entities.NewPackSize(100)
 	testPackSize.ID = "test-id"
+	testPackSize.Version = 2
 
 	tests := []struct {
 		name        string
 		id          string
+		version     int64
 		newSize     int
 		packSize    *entities.PackSize
 		findByIDErr error
@@ -307,6 +361,22 @@ func TestPackSizeUseCase_UpdatePackSize(t *testing.T) {
 			updateErr:   errors.New("update error"),
 			wantErr:     true,
 		},
+		{
+			name:     "Expected version",
+			id:       "test-id",
+			version:  2,
+			newSize:  300,
+			packSize: testPackSize,
+			wantErr:  false,
+		},
+		{
+			name:     "Version changed",
+			id:       "test-id",
+			version:  1,
+			newSize:  300,
+			packSize: testPackSize,
+			wantErr:  true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -319,10 +389,10 @@ func TestPackSizeUseCase_UpdatePackSize(t *testing.T) {
 			}
 
 			// Create use case
-			useCase := NewPackSizeUseCase(mockRepo)
+			useCase := NewPackSizeUseCase(mockRepo, &mockTransactionManager{repository: mockRepo})
 
 			// Call the method
-			result, err := useCase.UpdatePackSize(tt.id, tt.newSize)
+			result, err := useCase.UpdatePackSize(context.Background(), tt.id, tt.version, tt.newSize, entities.PackSizeAttributes{})
 
 			// Check error
 			if (err != nil) != tt.wantErr {
@@ -392,10 +462,10 @@ func TestPackSizeUseCase_DeletePackSize(t *testing.T) {
 			}
 
 			// Create use case
-			useCase := NewPackSizeUseCase(mockRepo)
+			useCase := NewPackSizeUseCase(mockRepo, &mockTransactionManager{repository: mockRepo})
 
 			// Call the method
-			err := useCase.DeletePackSize(tt.id)
+			err := useCase.DeletePackSize(context.Background(), tt.id, 0)
 
 			// Check error
 			if (err != nil) != tt.wantErr {
@@ -404,3 +474,524 @@ func TestPackSizeUseCase_DeletePackSize(t *testing.T) {
 		})
 	}
 }
+
+func TestPackSizeUseCase_RestorePackSize(t *testing.T) {
+	testPackSize, _ := entities.NewPackSize(100)
+	testPackSize.ID = "test-id"
+
+	tests := []struct {
+		name         string
+		restoreErr   error
+		wantNotFound bool
+		wantErr      bool
+	}{
+		{
+			name: "Success",
+		},
+		{
+			name:         "Not in the trash",
+			restoreErr:   domainerrors.ErrPackSizeNotFound,
+			wantNotFound: true,
+			wantErr:      true,
+		},
+		{
+			name:       "Size taken",
+			restoreErr: domainerrors.ErrDuplicatePackSize,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockPackSizeRepoForPackSize{
+				packSizeByID: testPackSize,
+				restoreErr:   tt.restoreErr,
+			}
+			useCase := NewPackSizeUseCase(mockRepo, &mockTransactionManager{repository: mockRepo})
+
+			result, err := useCase.RestorePackSize(context.Background(), "test-id")
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("RestorePackSize() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			var notFound *domainerrors.NotFoundError
+			if errors.As(err, &notFound) != tt.wantNotFound {
+				t.Errorf("RestorePackSize() error = %v, want not found %v", err, tt.wantNotFound)
+			}
+			if !tt.wantErr && result.ID != testPackSize.ID {
+				t.Errorf("RestorePackSize() ID = %v, want %v", result.ID, testPackSize.ID)
+			}
+		})
+	}
+}
+
+func TestPackSizeUseCase_PurgeDeletedPackSizes(t *testing.T) {
+	deleted, _ := entities.NewPackSize(100)
+	mockRepo := &mockPackSizeRepoForPackSize{packSizes: []*entities.PackSize{deleted}}
+	useCase := NewPackSizeUseCase(mockRepo, &mockTransactionManager{repository: mockRepo})
+
+	purged, err := useCase.PurgeDeletedPackSizes(context.Background(), 24*time.Hour)
+	if err != nil {
+		t.Fatalf("PurgeDeletedPackSizes() error = %v", err)
+	}
+	if purged != 1 {
+		t.Errorf("PurgeDeletedPackSizes() purged = %v, want 1", purged)
+	}
+
+	// Pack sizes deleted more than the retention ago are purged
+	if age := time.Since(mockRepo.purgedBefore); age < 24*time.Hour || age > 25*time.Hour {
+		t.Errorf("PurgeDeletedPackSizes() purged before %v, want a day ago", mockRepo.purgedBefore)
+	}
+}
+
+func TestPackSizeUseCase_OnChange(t *testing.T) {
+	packSize, _ := entities.NewPackSize(100)
+
+	tests := []struct {
+		name        string
+		repo        *mockPackSizeRepoForPackSize
+		change      func(uc *PackSizeUseCase) error
+		wantChanges int
+	}{
+		{
+			name: "Create notifies",
+			repo: &mockPackSizeRepoForPackSize{},
+			change: func(uc *PackSizeUseCase) error {
+				_, err := uc.CreatePackSize(context.Background(), 100, entities.PackSizeAttributes{})
+				return err
+			},
+			wantChanges: 1,
+		},
+		{
+			name: "Update notifies",
+			repo: &mockPackSizeRepoForPackSize{packSizeByID: packSize},
+			change: func(uc *PackSizeUseCase) error {
+				_, err := uc.UpdatePackSize(context.Background(), "test-id", 0, 200, entities.PackSizeAttributes{})
+				return err
+			},
+			wantChanges: 1,
+		},
+		{
+			name: "Delete notifies",
+			repo: &mockPackSizeRepoForPackSize{packSizeByID: packSize},
+			change: func(uc *PackSizeUseCase) error {
+				return uc.DeletePackSize(context.Background(), "test-id", 0)
+			},
+			wantChanges: 1,
+		},
+		{
+			name: "Restore notifies",
+			repo: &mockPackSizeRepoForPackSize{packSizeByID: packSize},
+			change: func(uc *PackSizeUseCase) error {
+				_, err := uc.RestorePackSize(context.Background(), "test-id")
+				return err
+			},
+			wantChanges: 1,
+		},
+		{
+			name: "Failed change does not notify",
+			repo: &mockPackSizeRepoForPackSize{createErr: errors.New("database error")},
+			change: func(uc *PackSizeUseCase) error {
+				_, err := uc.CreatePackSize(context.Background(), 100, entities.PackSizeAttributes{})
+				return err
+			},
+			wantChanges: 0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			uc := NewPackSizeUseCase(tt.repo, &mockTransactionManager{repository: tt.repo})
+
+			changes := 0
+			uc.OnChange(func() { changes++ })
+
+			_ = tt.change(uc)
+
+			if changes != tt.wantChanges {
+				t.Errorf("OnChange() listener called %d times, want %d", changes, tt.wantChanges)
+			}
+		})
+	}
+}
+
+func TestPackSizeUseCase_WatchValidity(t *testing.T) {
+	// A pack size becoming valid shortly
+	validFrom := time.Now().Add(50 * time.Millisecond)
+	scheduled, _ := entities.NewPackSize(750)
+	if err := scheduled.SetAttributes(entities.PackSizeAttributes{ValidFrom: &validFrom}); err != nil {
+		t.Fatalf("SetAttributes() error = %v", err)
+	}
+
+	uc := NewPackSizeUseCase(&mockPackSizeRepoForPackSize{packSizes: []*entities.PackSize{scheduled}}, &mockTransactionManager{})
+	changes := make(chan struct{}, 1)
+	uc.OnChange(func() { changes <- struct{}{} })
+
+	if err := uc.WatchValidity(context.Background()); err != nil {
+		t.Fatalf("WatchValidity() error = %v", err)
+	}
+
+	select {
+	case <-changes:
+		if time.Now().Before(validFrom) {
+			t.Errorf("WatchValidity() notified before the pack size became valid")
+		}
+	case <-time.After(time.Second):
+		t.Fatal("WatchValidity() did not notify when the pack size became valid")
+	}
+}
+
+func TestNextValidityChange(t *testing.T) {
+	now := time.Now()
+	past := now.Add(-time.Hour)
+	soon := now.Add(time.Hour)
+	later := now.Add(2 * time.Hour)
+
+	tests := []struct {
+		name      string
+		packSizes []*entities.PackSize
+		want      time.Time
+		wantFound bool
+	}{
+		{
+			name:      "No validity periods",
+			packSizes: []*entities.PackSize{{Size: 250}},
+		},
+		{
+			name:      "Only past boundaries",
+			packSizes: []*entities.PackSize{{Size: 250, ValidFrom: &past}},
+		},
+		{
+			name: "Earliest future boundary",
+			packSizes: []*entities.PackSize{
+				{Size: 250, ValidFrom: &past, ValidTo: &later},
+				{Size: 750, ValidFrom: &soon},
+			},
+			want:      soon,
+			wantFound: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, found := nextValidityChange(tt.packSizes, now)
+			if found != tt.wantFound || !got.Equal(tt.want) {
+				t.Errorf("nextValidityChange() = %v, %v, want %v, %v", got, found, tt.want, tt.wantFound)
+			}
+		})
+	}
+}
+
+func TestPackSizeUseCase_ReplacePackSizes(t *testing.T) {
+	// The current set is 250, 500 and 1000, 500 being labelled
+	current := make([]*entities.PackSize, 0, 3)
+	for i, size := range []int{1000, 250, 500} {
+		ps, _ := entities.NewPackSize(size)
+		ps.ID = []string{"id-1000", "id-250", "id-500"}[i]
+		current = append(current, ps)
+	}
+	if err := current[2].SetAttributes(entities.PackSizeAttributes{Name: "Medium"}); err != nil {
+		t.Fatalf("SetAttributes() error = %v", err)
+	}
+
+	mockRepo := &mockPackSizeRepoForPackSize{packSizes: current}
+	useCase := NewPackSizeUseCase(mockRepo, &mockTransactionManager{repository: mockRepo})
+	changes := 0
+	useCase.OnChange(func() { changes++ })
+
+	// Replace it by 500 unchanged, 1000 renamed and 2000
+	changed, err := useCase.ReplacePackSizes(context.Background(), []entities.PackSizeDefinition{
+		{Size: 500, Attributes: entities.PackSizeAttributes{Name: "Medium"}},
+		{Size: 1000, Attributes: entities.PackSizeAttributes{Name: "Large"}},
+		{Size: 2000},
+	})
+	if err != nil {
+		t.Fatalf("ReplacePackSizes() unexpected error = %v", err)
+	}
+
+	if !reflect.DeepEqual(mockRepo.deletedIDs, []string{"id-250"}) {
+		t.Errorf("ReplacePackSizes() deleted %v, want [id-250]", mockRepo.deletedIDs)
+	}
+	if len(changed.Updated) != 1 || changed.Updated[0].ID != "id-1000" || changed.Updated[0].Name != "Large" {
+		t.Errorf("ReplacePackSizes() updated %v, want only 1000 renamed", changed.Updated)
+	}
+	if len(changed.Created) != 1 || changed.Created[0].Size != 2000 {
+		t.Errorf("ReplacePackSizes() created %v, want only 2000", changed.Created)
+	}
+	if len(changed.Deleted) != 1 || changed.Deleted[0].Size != 250 {
+		t.Errorf("ReplacePackSizes() deleted %v, want only 250", changed.Deleted)
+	}
+	if changes != 1 {
+		t.Errorf("OnChange() listener called %d times, want once", changes)
+	}
+}
+
+func TestPackSizeUseCase_ReplacePackSizesInvalid(t *testing.T) {
+	tests := []struct {
+		name        string
+		definitions []entities.PackSizeDefinition
+		wantErr     error
+		wantField   string
+	}{
+		{
+			name:    "Empty set",
+			wantErr: domainerrors.ErrInvalidPackSize,
+		},
+		{
+			name:        "Invalid size",
+			definitions: []entities.PackSizeDefinition{{Size: 250}, {Size: -1}},
+			wantField:   "pack_sizes[1].size",
+		},
+		{
+			name:        "Invalid attribute",
+			definitions: []entities.PackSizeDefinition{{Size: 250, Attributes: entities.PackSizeAttributes{Weight: -1}}},
+			wantErr:     domainerrors.ErrInvalidPackSize,
+			wantField:   "pack_sizes[0].weight",
+		},
+		{
+			name:        "Duplicate size",
+			definitions: []entities.PackSizeDefinition{{Size: 250}, {Size: 250}},
+			wantErr:     domainerrors.ErrDuplicatePackSize,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockPackSizeRepoForPackSize{}
+			useCase := NewPackSizeUseCase(mockRepo, &mockTransactionManager{repository: mockRepo})
+
+			_, err := useCase.ReplacePackSizes(context.Background(), tt.definitions)
+
+			if err == nil {
+				t.Fatal("ReplacePackSizes() expected an error")
+			}
+			if tt.wantErr != nil && !errors.Is(err, tt.wantErr) {
+				t.Errorf("ReplacePackSizes() error = %v, want %v", err, tt.wantErr)
+			}
+			var validationErr *domainerrors.ValidationError
+			if tt.wantField != "" && (!errors.As(err, &validationErr) || validationErr.Field != tt.wantField) {
+				t.Errorf("ReplacePackSizes() error = %v, want a validation error of %s", err, tt.wantField)
+			}
+			if len(mockRepo.created)+len(mockRepo.updated)+len(mockRepo.deletedIDs) != 0 {
+				t.Error("ReplacePackSizes() changed pack sizes despite the invalid set")
+			}
+		})
+	}
+}
+
+func TestPackSizeUseCase_CreatePackSizes(t *testing.T) {
+	mockRepo := &mockPackSizeRepoForPackSize{}
+	useCase := NewPackSizeUseCase(mockRepo, &mockTransactionManager{repository: mockRepo})
+
+	created, err := useCase.CreatePackSizes(context.Background(), []entities.PackSizeDefinition{{Size: 300}, {Size: 600}})
+	if err != nil {
+		t.Fatalf("CreatePackSizes() unexpected error = %v", err)
+	}
+	if len(created) != 2 || created[0].Size != 300 || created[1].Size != 600 {
+		t.Errorf("CreatePackSizes() = %v, want 300 and 600", created)
+	}
+
+	// A failing creation fails them all
+	mockRepo.createErr = domainerrors.ErrDuplicatePackSize
+	if _, err := useCase.CreatePackSizes(context.Background(), []entities.PackSizeDefinition{{Size: 900}}); !errors.Is(err, domainerrors.ErrDuplicatePackSize) {
+		t.Errorf("CreatePackSizes() error = %v, want ErrDuplicatePackSize", err)
+	}
+}
+
+func TestPackSizeUseCase_DeletePackSizes(t *testing.T) {
+	packSize, _ := entities.NewPackSize(100)
+	mockRepo := &mockPackSizeRepoForPackSize{packSizeByID: packSize}
+	useCase := NewPackSizeUseCase(mockRepo, &mockTransactionManager{repository: mockRepo})
+
+	if err := useCase.DeletePackSizes(context.Background(), []string{"a", "b", "a"}); err != nil {
+		t.Fatalf("DeletePackSizes() unexpected error = %v", err)
+	}
+	if !reflect.DeepEqual(mockRepo.deletedIDs, []string{"a", "b"}) {
+		t.Errorf("DeletePackSizes() deleted %v, want [a b]", mockRepo.deletedIDs)
+	}
+
+	if err := useCase.DeletePackSizes(context.Background(), nil); !errors.Is(err, domainerrors.ErrInvalidPackSize) {
+		t.Errorf("DeletePackSizes() error = %v, want ErrInvalidPackSize", err)
+	}
+
+	mockRepo.deleteErr = domainerrors.ErrPackSizeNotFound
+	err := useCase.DeletePackSizes(context.Background(), []string{"missing"})
+	var notFoundErr *domainerrors.NotFoundError
+	if !errors.As(err, &notFoundErr) || notFoundErr.ID != "missing" {
+		t.Errorf("DeletePackSizes() error = %v, want not found error of missing", err)
+	}
+}
+
+func TestPackSizeUseCase_ChangesRunInTransactions(t *testing.T) {
+	packSize, _ := entities.NewPackSize(100)
+	packSize.ID = "test-id"
+
+	tests := []struct {
+		name   string
+		change func(uc *PackSizeUseCase) error
+	}{
+		{
+			name: "Create",
+			change: func(uc *PackSizeUseCase) error {
+				_, err := uc.CreatePackSize(context.Background(), 200, entities.PackSizeAttributes{})
+				return err
+			},
+		},
+		{
+			name: "Update",
+			change: func(uc *PackSizeUseCase) error {
+				_, err := uc.UpdatePackSize(context.Background(), "test-id", 0, 200, entities.PackSizeAttributes{})
+				return err
+			},
+		},
+		{
+			name: "Delete",
+			change: func(uc *PackSizeUseCase) error {
+				return uc.DeletePackSize(context.Background(), "test-id", 0)
+			},
+		},
+		{
+			name: "Restore",
+			change: func(uc *PackSizeUseCase) error {
+				_, err := uc.RestorePackSize(context.Background(), "test-id")
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			// Every call outside the transaction fails the change
+			failure := errors.New("outside the transaction")
+			outside := &mockPackSizeRepoForPackSize{
+				err:         failure,
+				createErr:   failure,
+				updateErr:   failure,
+				deleteErr:   failure,
+				findByIDErr: failure,
+				restoreErr:  failure,
+			}
+			inside := &mockPackSizeRepoForPackSize{packSizeByID: packSize}
+			transactions := &mockTransactionManager{repository: inside}
+			uc := NewPackSizeUseCase(outside, transactions)
+
+			if err := tt.change(uc); err != nil {
+				t.Fatalf("%s unexpected error = %v", tt.name, err)
+			}
+			if transactions.transactions != 1 {
+				t.Errorf("%s ran in %d transactions, want 1", tt.name, transactions.transactions)
+			}
+		})
+	}
+}
+
+func TestPackSizeUseCase_ChangesAddEvents(t *testing.T) {
+	ctx := types.WithRequestInfo(context.Background(), types.RequestInfo{ClaimedActor: "alice", RequestID: "req-1"})
+
+	tests := []struct {
+		name      string
+		change    func(uc *PackSizeUseCase) error
+		wantTypes []entities.PackSizeEventType
+	}{
+		{
+			name: "Create",
+			change: func(uc *PackSizeUseCase) error {
+				_, err := uc.CreatePackSize(ctx, 200, entities.PackSizeAttributes{})
+				return err
+			},
+			wantTypes: []entities.PackSizeEventType{entities.PackSizeCreated},
+		},
+		{
+			name: "Update",
+			change: func(uc *PackSizeUseCase) error {
+				_, err := uc.UpdatePackSize(ctx, "id-250", 0, 200, entities.PackSizeAttributes{})
+				return err
+			},
+			wantTypes: []entities.PackSizeEventType{entities.PackSizeUpdated},
+		},
+		{
+			name: "Delete",
+			change: func(uc *PackSizeUseCase) error {
+				return uc.DeletePackSize(ctx, "id-250", 0)
+			},
+			wantTypes: []entities.PackSizeEventType{entities.PackSizeDeleted},
+		},
+		{
+			name: "Restore",
+			change: func(uc *PackSizeUseCase) error {
+				_, err := uc.RestorePackSize(ctx, "id-250")
+				return err
+			},
+			wantTypes: []entities.PackSizeEventType{entities.PackSizeRestored},
+		},
+		{
+			name: "Create several",
+			change: func(uc *PackSizeUseCase) error {
+				_, err := uc.CreatePackSizes(ctx, []entities.PackSizeDefinition{{Size: 100}, {Size: 200}})
+				return err
+			},
+			wantTypes: []entities.PackSizeEventType{entities.PackSizeCreated, entities.PackSizeCreated},
+		},
+		{
+			name: "Delete several",
+			change: func(uc *PackSizeUseCase) error {
+				return uc.DeletePackSizes(ctx, []string{"id-250", "id-500"})
+			},
+			wantTypes: []entities.PackSizeEventType{entities.PackSizeDeleted, entities.PackSizeDeleted},
+		},
+		{
+			name: "Replace",
+			change: func(uc *PackSizeUseCase) error {
+				_, err := uc.ReplacePackSizes(ctx, []entities.PackSizeDefinition{{Size: 500, Attributes: entities.PackSizeAttributes{Name: "Medium"}}, {Size: 1000}})
+				return err
+			},
+			// 250 is deleted, 1000 created and 500 renamed
+			wantTypes: []entities.PackSizeEventType{entities.PackSizeDeleted, entities.PackSizeCreated, entities.PackSizeUpdated},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			stored := storedPackSizes(t)
+			mockRepo := &mockPackSizeRepoForPackSize{packSizes: stored, packSizeByID: stored[0]}
+			transactions := &mockTransactionManager{repository: mockRepo}
+			uc := NewPackSizeUseCase(mockRepo, transactions)
+
+			if err := tt.change(uc); err != nil {
+				t.Fatalf("%s unexpected error = %v", tt.name, err)
+			}
+
+			events := transactions.outbox.events
+			eventTypes := make([]entities.PackSizeEventType, len(events))
+			for i, event := range events {
+				eventTypes[i] = event.Type
+				if event.PackSize == nil || event.PackSizeID != event.PackSize.ID {
+					t.Errorf("%s event %d pack size = %v, want the changed pack size", tt.name, i, event.PackSize)
+				}
+				if event.ClaimedActor != "alice" || event.RequestID != "req-1" {
+					t.Errorf("%s event %d attributed to %q in %q, want alice in req-1", tt.name, i, event.ClaimedActor, event.RequestID)
+				}
+			}
+			if !reflect.DeepEqual(eventTypes, tt.wantTypes) {
+				t.Errorf("%s added events %v, want %v", tt.name, eventTypes, tt.wantTypes)
+			}
+		})
+	}
+}
+
+func TestPackSizeUseCase_ChangeFailsWithoutEvent(t *testing.T) {
+	mockRepo := &mockPackSizeRepoForPackSize{}
+	transactions := &mockTransactionManager{repository: mockRepo}
+	transactions.outbox.addErr = domainerrors.ErrDatabaseOperation
+	uc := NewPackSizeUseCase(mockRepo, transactions)
+	notified := 0
+	uc.OnChange(func() { notified++ })
+
+	// The change is rolled back with its event, so nobody is told about it
+	if _, err := uc.CreatePackSize(context.Background(), 200, entities.PackSizeAttributes{}); !errors.Is(err, domainerrors.ErrDatabaseOperation) {
+		t.Errorf("CreatePackSize() error = %v, want ErrDatabaseOperation", err)
+	}
+	if notified != 0 {
+		t.Errorf("OnChange() listener called %d times, want 0", notified)
+	}
+}
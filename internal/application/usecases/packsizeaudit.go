@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"context"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// PackSizeAuditUseCase represents the application use cases for the audit trail of pack sizes
+type PackSizeAuditUseCase struct {
+	auditRepository    secondary.PackSizeAuditRepository
+	packSizeRepository secondary.PackSizeRepository
+}
+
+// NewPackSizeAuditUseCase creates a new pack size audit use case
+func NewPackSizeAuditUseCase(auditRepository secondary.PackSizeAuditRepository, packSizeRepository secondary.PackSizeRepository) *PackSizeAuditUseCase {
+	return &PackSizeAuditUseCase{
+		auditRepository:    auditRepository,
+		packSizeRepository: packSizeRepository,
+	}
+}
+
+// GetPackSizeHistory retrieves the audit entries of a pack size, oldest first. The history of
+// deleted and purged pack sizes is kept, pack sizes written before auditing have an empty one.
+func (uc *PackSizeAuditUseCase) GetPackSizeHistory(ctx context.Context, id string) ([]*entities.PackSizeAuditEntry, error) {
+	entries, err := uc.auditRepository.FindByPackSizeID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// Without entries the pack size must still exist
+	if len(entries) == 0 {
+		if _, err := uc.packSizeRepository.FindByID(ctx, id); err != nil {
+			return nil, &errors.NotFoundError{
+				ID:  id,
+				Err: errors.ErrPackSizeNotFound,
+			}
+		}
+	}
+
+	return entries, nil
+}
+
+// GetAuditFeed retrieves the audit entries of every pack size with pagination, newest first
+func (uc *PackSizeAuditUseCase) GetAuditFeed(ctx context.Context, page, limit int64) ([]*entities.PackSizeAuditEntry, int64, error) {
+	return uc.auditRepository.FindAllPaginated(ctx, page, limit)
+}
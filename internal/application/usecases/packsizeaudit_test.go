@@ -0,0 +1,106 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"go-pack-calculator/internal/domain/entities"
+	domainerrors "go-pack-calculator/internal/domain/errors"
+)
+
+// Mock audit repository for testing PackSizeAuditUseCase
+type mockPackSizeAuditRepo struct {
+	entries []*entities.PackSizeAuditEntry
+	total   int64
+	err     error
+}
+
+func (m *mockPackSizeAuditRepo) FindByPackSizeID(ctx context.Context, packSizeID string) ([]*entities.PackSizeAuditEntry, error) {
+	return m.entries, m.err
+}
+
+func (m *mockPackSizeAuditRepo) FindAllPaginated(ctx context.Context, page, limit int64) ([]*entities.PackSizeAuditEntry, int64, error) {
+	return m.entries, m.total, m.err
+}
+
+func TestPackSizeAuditUseCase_GetPackSizeHistory(t *testing.T) {
+	packSize, _ := entities.NewPackSize(100)
+	packSize.ID = "test-id"
+	created := entities.NewPackSizeAuditEntry(entities.AuditActionCreated, nil, packSize, "alice", "req-1")
+
+	tests := []struct {
+		name         string
+		entries      []*entities.PackSizeAuditEntry
+		auditErr     error
+		findByIDErr  error
+		wantLen      int
+		wantErr      bool
+		wantNotFound bool
+	}{
+		{
+			name:    "With entries",
+			entries: []*entities.PackSizeAuditEntry{created},
+			wantLen: 1,
+		},
+		{
+			name:        "Deleted or purged pack size keeps its history",
+			entries:     []*entities.PackSizeAuditEntry{created},
+			findByIDErr: domainerrors.ErrPackSizeNotFound,
+			wantLen:     1,
+		},
+		{
+			name:    "Pack size written before auditing",
+			entries: []*entities.PackSizeAuditEntry{},
+			wantLen: 0,
+		},
+		{
+			name:         "Unknown pack size",
+			entries:      []*entities.PackSizeAuditEntry{},
+			findByIDErr:  domainerrors.ErrPackSizeNotFound,
+			wantErr:      true,
+			wantNotFound: true,
+		},
+		{
+			name:     "Repository error",
+			auditErr: errors.New("database error"),
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditRepo := &mockPackSizeAuditRepo{entries: tt.entries, err: tt.auditErr}
+			packSizeRepo := &mockPackSizeRepoForPackSize{packSizeByID: packSize, findByIDErr: tt.findByIDErr}
+			useCase := NewPackSizeAuditUseCase(auditRepo, packSizeRepo)
+
+			entries, err := useCase.GetPackSizeHistory(context.Background(), "test-id")
+
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("GetPackSizeHistory() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantNotFound && !errors.Is(err, domainerrors.ErrPackSizeNotFound) {
+				t.Errorf("GetPackSizeHistory() error = %v, want ErrPackSizeNotFound", err)
+			}
+			if !tt.wantErr && len(entries) != tt.wantLen {
+				t.Errorf("GetPackSizeHistory() returned %d entries, want %d", len(entries), tt.wantLen)
+			}
+		})
+	}
+}
+
+func TestPackSizeAuditUseCase_GetAuditFeed(t *testing.T) {
+	packSize, _ := entities.NewPackSize(100)
+	entry := entities.NewPackSizeAuditEntry(entities.AuditActionCreated, nil, packSize, "alice", "req-1")
+	auditRepo := &mockPackSizeAuditRepo{entries: []*entities.PackSizeAuditEntry{entry}, total: 7}
+	useCase := NewPackSizeAuditUseCase(auditRepo, &mockPackSizeRepoForPackSize{})
+
+	entries, total, err := useCase.GetAuditFeed(context.Background(), 1, 10)
+
+	if err != nil {
+		t.Fatalf("GetAuditFeed() unexpected error = %v", err)
+	}
+	if len(entries) != 1 || total != 7 {
+		t.Errorf("GetAuditFeed() = %d entries of %d, want 1 of 7", len(entries), total)
+	}
+}
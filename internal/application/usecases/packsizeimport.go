@@ -0,0 +1,157 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// ImportPackSizes validates imported rows and saves them at once with the strategy, unless a row
+// is invalid or it is a dry run. The report tells what the import does with every valid row either
+// way. Only an import that is saved takes a transaction locking the pack sizes; the others are
+// planned against the pack sizes as read.
+func (uc *PackSizeUseCase) ImportPackSizes(ctx context.Context, rows []entities.PackSizeImportRow, strategy entities.ImportStrategy, dryRun bool) (*entities.PackSizeImportReport, error) {
+	if !strategy.IsValid() {
+		return nil, &errors.ValidationError{
+			Field: "strategy",
+			Err:   fmt.Errorf("%w: unknown strategy %q", errors.ErrInvalidImport, strategy),
+		}
+	}
+	if strategy == "" {
+		strategy = entities.ImportStrategyMerge
+	}
+	if len(rows) == 0 {
+		return nil, &errors.ValidationError{
+			Field: "pack_sizes",
+			Err:   fmt.Errorf("%w: no pack sizes to import", errors.ErrInvalidImport),
+		}
+	}
+
+	report := &entities.PackSizeImportReport{
+		Strategy: strategy,
+		DryRun:   dryRun,
+		Rows:     make([]entities.PackSizeImportRowResult, len(rows)),
+		Deleted:  []*entities.PackSize{},
+	}
+	packSizes := validateImportRows(rows, report.Rows)
+	apply := !dryRun && report.Valid()
+
+	valid := make([]*entities.PackSize, 0, len(packSizes))
+	for _, ps := range packSizes {
+		if ps != nil {
+			valid = append(valid, ps)
+		}
+	}
+	deleteOthers := strategy == entities.ImportStrategyReplace
+
+	var current []*entities.PackSize
+	var changes *entities.PackSizeChanges
+	var err error
+	if apply {
+		err = uc.transactions.WithinTransaction(ctx, func(tx secondary.Transaction) error {
+			var err error
+			current, err = tx.PackSizes().FindAll(ctx)
+			if err != nil {
+				return err
+			}
+
+			changes, err = applyPackSizeChanges(ctx, tx, planPackSizeChanges(current, valid, deleteOthers))
+
+			return err
+		})
+	} else {
+		current, err = uc.repository.FindAll(ctx)
+		if err == nil {
+			changes = planPackSizeChanges(current, valid, deleteOthers)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	// Every imported size is either created, updated or already stored as it is
+	results := make(map[int]entities.PackSizeImportRowResult, len(packSizes))
+	for _, ps := range current {
+		results[ps.Size] = entities.PackSizeImportRowResult{Action: entities.ImportActionUnchanged, PackSize: ps}
+	}
+	for _, ps := range changes.Created {
+		results[ps.Size] = entities.PackSizeImportRowResult{Action: entities.ImportActionCreate, PackSize: ps}
+	}
+	for _, ps := range changes.Updated {
+		results[ps.Size] = entities.PackSizeImportRowResult{Action: entities.ImportActionUpdate, PackSize: ps}
+	}
+	for i, ps := range packSizes {
+		if ps == nil {
+			continue
+		}
+		report.Rows[i].Action = results[ps.Size].Action
+		report.Rows[i].PackSize = results[ps.Size].PackSize
+	}
+	report.Deleted = changes.Deleted
+
+	if apply {
+		report.Applied = true
+		if len(changes.Created)+len(changes.Updated)+len(changes.Deleted) > 0 {
+			uc.notifyChange(ctx)
+		}
+	}
+
+	return report, nil
+}
+
+// validateImportRows fills the results of the rows with their size and, for an invalid row, its
+// errors. It returns the pack sizes of the rows, nil for the invalid ones.
+func validateImportRows(rows []entities.PackSizeImportRow, results []entities.PackSizeImportRowResult) []*entities.PackSize {
+	packSizes := make([]*entities.PackSize, len(rows))
+	sizeRows := make(map[int]int, len(rows))
+	for i, row := range rows {
+		results[i] = entities.PackSizeImportRowResult{
+			Row:    row.Row,
+			Size:   row.Definition.Size,
+			Errors: []string{},
+		}
+
+		if len(row.Errors) > 0 {
+			results[i].Action = entities.ImportActionInvalid
+			results[i].Errors = row.Errors
+
+			continue
+		}
+
+		packSize, err := newPackSize(row.Definition)
+		if err != nil {
+			results[i].Action = entities.ImportActionInvalid
+			results[i].Errors = append(results[i].Errors, err.Error())
+
+			continue
+		}
+
+		if first, ok := sizeRows[packSize.Size]; ok {
+			results[i].Action = entities.ImportActionInvalid
+			results[i].Errors = append(results[i].Errors, fmt.Sprintf("%v: size %d is also on row %d", errors.ErrDuplicatePackSize, packSize.Size, first))
+
+			continue
+		}
+		sizeRows[packSize.Size] = row.Row
+		packSizes[i] = packSize
+	}
+
+	return packSizes
+}
+
+// ExportPackSizes retrieves the pack sizes to export, smallest first
+func (uc *PackSizeUseCase) ExportPackSizes(ctx context.Context) ([]*entities.PackSize, error) {
+	packSizes, err := uc.repository.FindAll(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(packSizes, func(i, j int) bool {
+		return packSizes[i].Size < packSizes[j].Size
+	})
+
+	return packSizes, nil
+}
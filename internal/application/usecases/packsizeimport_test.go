@@ -0,0 +1,207 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"go-pack-calculator/internal/domain/entities"
+	domainerrors "go-pack-calculator/internal/domain/errors"
+)
+
+// storedPackSizes returns stored pack sizes of 250, labelled Small, and 500
+func storedPackSizes(t *testing.T) []*entities.PackSize {
+	t.Helper()
+
+	small, _ := entities.NewPackSize(250)
+	small.ID = "id-250"
+	if err := small.SetAttributes(entities.PackSizeAttributes{Name: "Small"}); err != nil {
+		t.Fatalf("SetAttributes() error = %v", err)
+	}
+	medium, _ := entities.NewPackSize(500)
+	medium.ID = "id-500"
+
+	return []*entities.PackSize{small, medium}
+}
+
+func TestPackSizeUseCase_ImportPackSizes(t *testing.T) {
+	rows := []entities.PackSizeImportRow{
+		{Row: 1, Definition: entities.PackSizeDefinition{Size: 250, Attributes: entities.PackSizeAttributes{Name: "Small"}}},
+		{Row: 2, Definition: entities.PackSizeDefinition{Size: 500, Attributes: entities.PackSizeAttributes{Name: "Medium"}}},
+		{Row: 3, Definition: entities.PackSizeDefinition{Size: 1000}},
+	}
+
+	tests := []struct {
+		name         string
+		strategy     entities.ImportStrategy
+		dryRun       bool
+		wantActions  []entities.ImportAction
+		wantDeleted  []string
+		wantApplied  bool
+		wantCreated  int
+		wantUpdated  int
+		wantNotified int
+	}{
+		{
+			name:         "Merge",
+			wantActions:  []entities.ImportAction{entities.ImportActionUnchanged, entities.ImportActionUpdate, entities.ImportActionCreate},
+			wantApplied:  true,
+			wantCreated:  1,
+			wantUpdated:  1,
+			wantNotified: 1,
+		},
+		{
+			name:         "Dry run",
+			dryRun:       true,
+			wantActions:  []entities.ImportAction{entities.ImportActionUnchanged, entities.ImportActionUpdate, entities.ImportActionCreate},
+			wantApplied:  false,
+			wantNotified: 0,
+		},
+		{
+			name:     "Replace",
+			strategy: entities.ImportStrategyReplace,
+			// Without the first row, the stored 250 is deleted
+			wantActions:  []entities.ImportAction{entities.ImportActionUpdate, entities.ImportActionCreate},
+			wantDeleted:  []string{"id-250"},
+			wantApplied:  true,
+			wantCreated:  1,
+			wantUpdated:  1,
+			wantNotified: 1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := &mockPackSizeRepoForPackSize{packSizes: storedPackSizes(t)}
+			transactions := &mockTransactionManager{repository: mockRepo}
+			useCase := NewPackSizeUseCase(mockRepo, transactions)
+			notified := 0
+			useCase.OnChange(func() { notified++ })
+
+			imported := rows
+			if tt.strategy == entities.ImportStrategyReplace {
+				imported = rows[1:]
+			}
+			report, err := useCase.ImportPackSizes(context.Background(), imported, tt.strategy, tt.dryRun)
+			if err != nil {
+				t.Fatalf("ImportPackSizes() unexpected error = %v", err)
+			}
+
+			actions := make([]entities.ImportAction, len(report.Rows))
+			for i, row := range report.Rows {
+				actions[i] = row.Action
+				if row.PackSize == nil || row.PackSize.Size != row.Size {
+					t.Errorf("ImportPackSizes() row %d pack size = %v, want one of size %d", row.Row, row.PackSize, row.Size)
+				}
+			}
+			if !reflect.DeepEqual(actions, tt.wantActions) {
+				t.Errorf("ImportPackSizes() actions = %v, want %v", actions, tt.wantActions)
+			}
+
+			deleted := make([]string, 0, len(report.Deleted))
+			for _, ps := range report.Deleted {
+				deleted = append(deleted, ps.ID)
+			}
+			if !reflect.DeepEqual(deleted, append([]string{}, tt.wantDeleted...)) {
+				t.Errorf("ImportPackSizes() deleted = %v, want %v", deleted, tt.wantDeleted)
+			}
+
+			if report.Applied != tt.wantApplied {
+				t.Errorf("ImportPackSizes() applied = %v, want %v", report.Applied, tt.wantApplied)
+			}
+			if len(mockRepo.created) != tt.wantCreated || len(mockRepo.updated) != tt.wantUpdated {
+				t.Errorf("ImportPackSizes() saved %d created and %d updated, want %d and %d",
+					len(mockRepo.created), len(mockRepo.updated), tt.wantCreated, tt.wantUpdated)
+			}
+			if tt.dryRun && len(mockRepo.deletedIDs) != 0 {
+				t.Errorf("ImportPackSizes() deleted %v in a dry run", mockRepo.deletedIDs)
+			}
+
+			// Only an import that is saved locks the pack sizes in a transaction
+			wantTransactions := 0
+			if tt.wantApplied {
+				wantTransactions = 1
+			}
+			if transactions.transactions != wantTransactions {
+				t.Errorf("ImportPackSizes() ran %d transactions, want %d", transactions.transactions, wantTransactions)
+			}
+			if notified != tt.wantNotified {
+				t.Errorf("OnChange() listener called %d times, want %d", notified, tt.wantNotified)
+			}
+		})
+	}
+}
+
+func TestPackSizeUseCase_ImportPackSizesInvalidRows(t *testing.T) {
+	mockRepo := &mockPackSizeRepoForPackSize{packSizes: storedPackSizes(t)}
+	transactions := &mockTransactionManager{repository: mockRepo}
+	useCase := NewPackSizeUseCase(mockRepo, transactions)
+
+	report, err := useCase.ImportPackSizes(context.Background(), []entities.PackSizeImportRow{
+		{Row: 1, Definition: entities.PackSizeDefinition{Size: 1000}},
+		{Row: 2, Errors: []string{`invalid size "x"`}},
+		{Row: 3, Definition: entities.PackSizeDefinition{Size: -5}},
+		{Row: 4, Definition: entities.PackSizeDefinition{Size: 1000, Attributes: entities.PackSizeAttributes{Name: "Large"}}},
+	}, entities.ImportStrategyMerge, false)
+	if err != nil {
+		t.Fatalf("ImportPackSizes() unexpected error = %v", err)
+	}
+
+	if report.Valid() || report.Applied {
+		t.Errorf("ImportPackSizes() valid = %v, applied = %v, want neither", report.Valid(), report.Applied)
+	}
+	if len(mockRepo.created)+len(mockRepo.updated)+len(mockRepo.deletedIDs) != 0 {
+		t.Error("ImportPackSizes() changed pack sizes despite the invalid rows")
+	}
+	if transactions.transactions != 0 {
+		t.Errorf("ImportPackSizes() ran %d transactions for invalid rows, want none", transactions.transactions)
+	}
+
+	// The valid row is still reported with what the import would do
+	if report.Rows[0].Action != entities.ImportActionCreate {
+		t.Errorf("ImportPackSizes() row 1 action = %v, want create", report.Rows[0].Action)
+	}
+	for _, row := range report.Rows[1:] {
+		if row.Action != entities.ImportActionInvalid || len(row.Errors) != 1 {
+			t.Errorf("ImportPackSizes() row %d = %v with errors %v, want invalid with one error", row.Row, row.Action, row.Errors)
+		}
+	}
+	if want := "pack size already exists: size 1000 is also on row 1"; report.Rows[3].Errors[0] != want {
+		t.Errorf("ImportPackSizes() row 4 error = %q, want %q", report.Rows[3].Errors[0], want)
+	}
+}
+
+func TestPackSizeUseCase_ImportPackSizesRejected(t *testing.T) {
+	mockRepo := &mockPackSizeRepoForPackSize{}
+	useCase := NewPackSizeUseCase(mockRepo, &mockTransactionManager{repository: mockRepo})
+	rows := []entities.PackSizeImportRow{{Row: 1, Definition: entities.PackSizeDefinition{Size: 250}}}
+
+	var validationErr *domainerrors.ValidationError
+	if _, err := useCase.ImportPackSizes(context.Background(), rows, "upsert", false); !errors.As(err, &validationErr) || validationErr.Field != "strategy" {
+		t.Errorf("ImportPackSizes() error = %v, want a validation error of strategy", err)
+	}
+	if _, err := useCase.ImportPackSizes(context.Background(), nil, entities.ImportStrategyMerge, false); !errors.Is(err, domainerrors.ErrInvalidImport) {
+		t.Errorf("ImportPackSizes() error = %v, want ErrInvalidImport", err)
+	}
+
+	// A failing save fails the import
+	mockRepo.createErr = domainerrors.ErrDatabaseOperation
+	if _, err := useCase.ImportPackSizes(context.Background(), rows, "", false); !errors.Is(err, domainerrors.ErrDatabaseOperation) {
+		t.Errorf("ImportPackSizes() error = %v, want ErrDatabaseOperation", err)
+	}
+}
+
+func TestPackSizeUseCase_ExportPackSizes(t *testing.T) {
+	stored := storedPackSizes(t)
+	mockRepo := &mockPackSizeRepoForPackSize{packSizes: []*entities.PackSize{stored[1], stored[0]}}
+	useCase := NewPackSizeUseCase(mockRepo, &mockTransactionManager{repository: mockRepo})
+
+	packSizes, err := useCase.ExportPackSizes(context.Background())
+	if err != nil {
+		t.Fatalf("ExportPackSizes() unexpected error = %v", err)
+	}
+	if len(packSizes) != 2 || packSizes[0].Size != 250 || packSizes[1].Size != 500 {
+		t.Errorf("ExportPackSizes() = %v, want 250 then 500", packSizes)
+	}
+}
@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"context"
+	"sync"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/ports/secondary"
+)
+
+// ShippingRateUseCase represents the application use cases for carrier rate tables
+type ShippingRateUseCase struct {
+	repository secondary.ShippingRateRepository
+	mu         sync.RWMutex
+	listeners  []func()
+}
+
+// NewShippingRateUseCase creates a new shipping rate use case
+func NewShippingRateUseCase(repository secondary.ShippingRateRepository) *ShippingRateUseCase {
+	return &ShippingRateUseCase{
+		repository: repository,
+	}
+}
+
+// OnChange registers a listener called after the rate table is replaced
+func (uc *ShippingRateUseCase) OnChange(listener func()) {
+	uc.mu.Lock()
+	defer uc.mu.Unlock()
+
+	uc.listeners = append(uc.listeners, listener)
+}
+
+// notifyChange calls the listeners registered with OnChange
+func (uc *ShippingRateUseCase) notifyChange() {
+	uc.mu.RLock()
+	listeners := uc.listeners
+	uc.mu.RUnlock()
+
+	for _, listener := range listeners {
+		listener()
+	}
+}
+
+// ImportShippingRates replaces the rate table with the given rates
+func (uc *ShippingRateUseCase) ImportShippingRates(ctx context.Context, rates []*entities.ShippingRate) ([]*entities.ShippingRate, error) {
+	// Validate every rate before replacing the table
+	for _, rate := range rates {
+		if err := rate.Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	imported, err := uc.repository.ReplaceAll(ctx, rates)
+	if err != nil {
+		return nil, err
+	}
+	uc.notifyChange()
+
+	return imported, nil
+}
+
+// GetAllShippingRates retrieves the rate table
+func (uc *ShippingRateUseCase) GetAllShippingRates(ctx context.Context) ([]*entities.ShippingRate, error) {
+	return uc.repository.FindAll(ctx)
+}
@@ -0,0 +1,125 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+
+	domainerrors "go-pack-calculator/internal/domain/errors"
+)
+
+// dimensionTolerance absorbs floating point error when comparing dimensions
+const dimensionTolerance = 1e-9
+
+// Dimensions represents the outer size of a pack or the inner size of a box in centimetres
+type Dimensions struct {
+	Length float64 `json:"length"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+}
+
+// IsZero reports whether no dimensions are set
+func (d Dimensions) IsZero() bool {
+	return d.Length == 0 && d.Width == 0 && d.Height == 0
+}
+
+// IsValid reports whether every dimension is greater than zero
+func (d Dimensions) IsValid() bool {
+	return d.Length > 0 && d.Width > 0 && d.Height > 0
+}
+
+// Volume returns the volume in cubic centimetres
+func (d Dimensions) Volume() float64 {
+	return d.Length * d.Width * d.Height
+}
+
+// FitsIn reports whether the dimensions fit in the other dimensions without rotation
+func (d Dimensions) FitsIn(other Dimensions) bool {
+	return d.Length <= other.Length+dimensionTolerance &&
+		d.Width <= other.Width+dimensionTolerance &&
+		d.Height <= other.Height+dimensionTolerance
+}
+
+// Rotations returns the distinct axis-aligned orientations of the dimensions
+func (d Dimensions) Rotations() []Dimensions {
+	candidates := []Dimensions{
+		{Length: d.Length, Width: d.Width, Height: d.Height},
+		{Length: d.Length, Width: d.Height, Height: d.Width},
+		{Length: d.Width, Width: d.Length, Height: d.Height},
+		{Length: d.Width, Width: d.Height, Height: d.Length},
+		{Length: d.Height, Width: d.Length, Height: d.Width},
+		{Length: d.Height, Width: d.Width, Height: d.Length},
+	}
+
+	rotations := make([]Dimensions, 0, len(candidates))
+	for _, candidate := range candidates {
+		duplicate := false
+		for _, rotation := range rotations {
+			if rotation == candidate {
+				duplicate = true
+				break
+			}
+		}
+		if !duplicate {
+			rotations = append(rotations, candidate)
+		}
+	}
+
+	return rotations
+}
+
+// Position represents the corner of a placed pack closest to the corner of its box
+type Position struct {
+	X float64 `json:"x"`
+	Y float64 `json:"y"`
+	Z float64 `json:"z"`
+}
+
+// Box represents a shipping box from the box catalog
+type Box struct {
+	Name       string     `json:"name"`
+	Dimensions Dimensions `json:"dimensions"`
+	MaxWeight  float64    `json:"max_weight"` // Kilograms
+}
+
+// Validate validates the box entity
+func (b *Box) Validate() error {
+	switch {
+	case strings.TrimSpace(b.Name) == "":
+		return boxError("name", "name must not be empty")
+	case !b.Dimensions.IsValid():
+		return boxError("dimensions", "dimensions must be greater than zero")
+	case b.MaxWeight <= 0:
+		return boxError("max_weight", "max weight must be greater than zero")
+	}
+
+	return nil
+}
+
+// PackPlacement represents a pack placed in a box, with its dimensions as rotated
+type PackPlacement struct {
+	PackSize   int        `json:"pack_size"`
+	Position   Position   `json:"position"`
+	Dimensions Dimensions `json:"dimensions"`
+}
+
+// BoxAssignment represents a box and the packs placed in it
+type BoxAssignment struct {
+	Box         Box             `json:"box"`
+	Packs       map[int]int     `json:"packs"` // Map of pack size to quantity
+	Placements  []PackPlacement `json:"placements"`
+	TotalWeight float64         `json:"total_weight"` // Kilograms
+}
+
+// BoxPackingResult represents the packs of a calculation assigned to shipping boxes
+type BoxPackingResult struct {
+	Calculation *CalculationResult `json:"calculation"`
+	Boxes       []BoxAssignment    `json:"boxes"`
+}
+
+// boxError builds a validation error for a box field
+func boxError(field, message string) error {
+	return &domainerrors.ValidationError{
+		Field: field,
+		Err:   fmt.Errorf("%w: %s", domainerrors.ErrInvalidBox, message),
+	}
+}
@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+
+	domainerrors "go-pack-calculator/internal/domain/errors"
+)
+
+func TestDimensions_Rotations(t *testing.T) {
+	tests := []struct {
+		name       string
+		dimensions Dimensions
+		want       int
+	}{
+		{name: "Cube", dimensions: Dimensions{Length: 10, Width: 10, Height: 10}, want: 1},
+		{name: "Square base", dimensions: Dimensions{Length: 10, Width: 10, Height: 20}, want: 3},
+		{name: "All different", dimensions: Dimensions{Length: 10, Width: 20, Height: 30}, want: 6},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rotations := tt.dimensions.Rotations()
+			if len(rotations) != tt.want {
+				t.Errorf("Dimensions.Rotations() returned %d rotations, want %d", len(rotations), tt.want)
+			}
+
+			for _, rotation := range rotations {
+				if rotation.Volume() != tt.dimensions.Volume() {
+					t.Errorf("Dimensions.Rotations() volume = %v, want %v", rotation.Volume(), tt.dimensions.Volume())
+				}
+			}
+		})
+	}
+}
+
+func TestBox_Validate(t *testing.T) {
+	valid := Dimensions{Length: 30, Width: 20, Height: 10}
+
+	tests := []struct {
+		name    string
+		box     Box
+		wantErr bool
+	}{
+		{name: "Valid box", box: Box{Name: "M", Dimensions: valid, MaxWeight: 10}, wantErr: false},
+		{name: "Empty name", box: Box{Name: " ", Dimensions: valid, MaxWeight: 10}, wantErr: true},
+		{name: "Missing dimension", box: Box{Name: "M", Dimensions: Dimensions{Length: 30, Width: 20}, MaxWeight: 10}, wantErr: true},
+		{name: "Zero max weight", box: Box{Name: "M", Dimensions: valid, MaxWeight: 0}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.box.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Box.Validate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr && !errors.Is(err, domainerrors.ErrInvalidBox) {
+				t.Errorf("Box.Validate() error = %v, want %v", err, domainerrors.ErrInvalidBox)
+			}
+		})
+	}
+}
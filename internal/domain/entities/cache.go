@@ -0,0 +1,21 @@
+package entities
+
+// CacheStats represents the usage of the calculation cache
+type CacheStats struct {
+	Hits      int64  `json:"hits"`
+	Misses    int64  `json:"misses"`
+	Evictions int64  `json:"evictions"`
+	Entries   int    `json:"entries"`
+	Capacity  int    `json:"capacity"`
+	Version   uint64 `json:"version"` // Version of the pack size set the entries were calculated with
+}
+
+// HitRatio returns the share of lookups answered from the cache
+func (s CacheStats) HitRatio() float64 {
+	lookups := s.Hits + s.Misses
+	if lookups == 0 {
+		return 0
+	}
+
+	return float64(s.Hits) / float64(lookups)
+}
@@ -0,0 +1,30 @@
+package entities
+
+import "testing"
+
+func TestCacheStats_HitRatio(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats CacheStats
+		want  float64
+	}{
+		{
+			name:  "No lookups",
+			stats: CacheStats{},
+			want:  0,
+		},
+		{
+			name:  "Hits and misses",
+			stats: CacheStats{Hits: 3, Misses: 1},
+			want:  0.75,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.stats.HitRatio(); got != tt.want {
+				t.Errorf("HitRatio() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,10 +1,63 @@
 package entities
 
+import (
+	"sort"
+	"time"
+)
+
 // CalculationResult represents the result of a pack calculation
 type CalculationResult struct {
-	ItemsOrdered int         `json:"items_ordered"`
-	TotalItems   int         `json:"total_items"`
-	Packs        map[int]int `json:"packs"` // Map of pack size to quantity
+	ID                string             `json:"id,omitempty"`
+	ItemsOrdered      int                `json:"items_ordered"`
+	TotalItems        int                `json:"total_items"`
+	Packs             map[int]int        `json:"packs"`        // Map of pack size to quantity
+	TotalWeight       float64            `json:"total_weight"` // Kilograms
+	ShippingEstimates []ShippingEstimate `json:"shipping_estimates,omitempty"`
+	Footprint         PackagingFootprint `json:"footprint"`
+	CreatedAt         time.Time          `json:"created_at"`
+}
+
+// PackagingFootprint represents the packaging material used by a calculation and its emissions
+type PackagingFootprint struct {
+	Material  float64 `json:"material"`  // Kilograms of packaging material
+	Emissions float64 `json:"emissions"` // Kilograms of CO2e
+}
+
+// CalculationObjective represents what a calculation minimises among the packings
+// that ship the fewest items
+type CalculationObjective string
+
+const (
+	// ObjectiveFewestPacks minimises the number of packs
+	ObjectiveFewestPacks CalculationObjective = "packs"
+	// ObjectiveLeastMaterial minimises the packaging material
+	ObjectiveLeastMaterial CalculationObjective = "material"
+)
+
+// IsValid reports whether the objective is known, an empty objective means the default
+func (o CalculationObjective) IsValid() bool {
+	switch o {
+	case "", ObjectiveFewestPacks, ObjectiveLeastMaterial:
+		return true
+	default:
+		return false
+	}
+}
+
+// CalculationOptions represents the optional preferences of a pack calculation
+type CalculationOptions struct {
+	// Objective defaults to ObjectiveFewestPacks
+	Objective CalculationObjective
+	// OptimizeShipping selects, among packings tied on items and the objective, the one that is cheapest to ship
+	OptimizeShipping bool
+	// AsOf selects the pack sizes valid at that time, e.g. a future ship date, instead of the current ones
+	AsOf time.Time
+}
+
+// PackLine represents the quantity of one pack size in a packing
+type PackLine struct {
+	PackSize int `json:"pack_size"`
+	Quantity int `json:"quantity"`
 }
 
 // NewCalculationResult creates a new calculation result
@@ -19,5 +72,39 @@ func NewCalculationResult(itemsOrdered int, packs map[int]int) *CalculationResul
 		ItemsOrdered: itemsOrdered,
 		TotalItems:   totalItems,
 		Packs:        packs,
+		CreatedAt:    time.Now(),
 	}
 }
+
+// TotalPacks returns the number of packs in the result
+func (r *CalculationResult) TotalPacks() int {
+	totalPacks := 0
+	for _, quantity := range r.Packs {
+		totalPacks += quantity
+	}
+
+	return totalPacks
+}
+
+// Overshoot returns the number of items sent beyond the items ordered
+func (r *CalculationResult) Overshoot() int {
+	return r.TotalItems - r.ItemsOrdered
+}
+
+// Lines returns the packs of the result ordered by pack size, largest first
+func (r *CalculationResult) Lines() []PackLine {
+	lines := make([]PackLine, 0, len(r.Packs))
+	for size, quantity := range r.Packs {
+		if quantity == 0 {
+			continue
+		}
+
+		lines = append(lines, PackLine{PackSize: size, Quantity: quantity})
+	}
+
+	sort.Slice(lines, func(i, j int) bool {
+		return lines[i].PackSize > lines[j].PackSize
+	})
+
+	return lines
+}
@@ -56,3 +56,51 @@ func TestNewCalculationResult(t *testing.T) {
 		})
 	}
 }
+
+func TestCalculationResult_TotalPacks(t *testing.T) {
+	result := NewCalculationResult(751, map[int]int{500: 1, 100: 3})
+	if got := result.TotalPacks(); got != 4 {
+		t.Errorf("CalculationResult.TotalPacks() = %v, want %v", got, 4)
+	}
+
+	empty := NewCalculationResult(0, map[int]int{})
+	if got := empty.TotalPacks(); got != 0 {
+		t.Errorf("CalculationResult.TotalPacks() = %v, want %v", got, 0)
+	}
+}
+
+func TestCalculationResult_Overshoot(t *testing.T) {
+	result := NewCalculationResult(251, map[int]int{500: 1})
+	if got := result.Overshoot(); got != 249 {
+		t.Errorf("CalculationResult.Overshoot() = %v, want %v", got, 249)
+	}
+}
+
+func TestCalculationResult_Lines(t *testing.T) {
+	result := NewCalculationResult(1251, map[int]int{250: 1, 1000: 1, 500: 0})
+	want := []PackLine{{PackSize: 1000, Quantity: 1}, {PackSize: 250, Quantity: 1}}
+	if got := result.Lines(); !reflect.DeepEqual(got, want) {
+		t.Errorf("CalculationResult.Lines() = %v, want %v", got, want)
+	}
+}
+
+func TestCalculationObjective_IsValid(t *testing.T) {
+	tests := []struct {
+		name      string
+		objective CalculationObjective
+		want      bool
+	}{
+		{name: "Default", objective: "", want: true},
+		{name: "Fewest packs", objective: ObjectiveFewestPacks, want: true},
+		{name: "Least material", objective: ObjectiveLeastMaterial, want: true},
+		{name: "Unknown", objective: "cheapest", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.objective.IsValid(); got != tt.want {
+				t.Errorf("CalculationObjective.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
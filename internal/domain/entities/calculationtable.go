@@ -0,0 +1,7 @@
+package entities
+
+// CalculationTable represents the optimal packings of a range of quantities
+type CalculationTable struct {
+	PackSizes []int                // Distinct pack sizes, largest first
+	Results   []*CalculationResult // One result per quantity, in ascending order
+}
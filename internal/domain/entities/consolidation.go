@@ -0,0 +1,30 @@
+package entities
+
+// Order represents a single customer order taking part in a consolidation
+type Order struct {
+	ID           string `json:"id"`
+	ItemsOrdered int    `json:"items_ordered"`
+}
+
+// OrderAllocation represents the share of a consolidated packing assigned back to an order
+type OrderAllocation struct {
+	OrderID        string      `json:"order_id"`
+	ItemsOrdered   int         `json:"items_ordered"`
+	AllocatedItems int         `json:"allocated_items"`
+	Packs          map[int]int `json:"packs"` // Map of pack size to quantity
+}
+
+// ConsolidationSavings represents what is saved by packing orders together
+type ConsolidationSavings struct {
+	Items int `json:"items"`
+	Packs int `json:"packs"`
+}
+
+// ConsolidationResult represents the result of consolidating several orders into one packing
+type ConsolidationResult struct {
+	Orders      []Order              `json:"orders"`
+	Combined    *CalculationResult   `json:"combined"`
+	Separate    []*CalculationResult `json:"separate"` // One result per order, in order
+	Allocations []OrderAllocation    `json:"allocations"`
+	Savings     ConsolidationSavings `json:"savings"`
+}
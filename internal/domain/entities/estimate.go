@@ -0,0 +1,11 @@
+package entities
+
+import "time"
+
+// CalculationEstimate represents the expected cost of calculating the packs of an order
+type CalculationEstimate struct {
+	States      int64         // Totals the solver keeps in memory
+	Steps       int64         // Pack additions the solver evaluates
+	MemoryBytes int64         // Approximate peak memory
+	Duration    time.Duration // Approximate CPU time
+}
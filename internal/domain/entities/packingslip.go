@@ -0,0 +1,60 @@
+package entities
+
+import "time"
+
+// DocumentFormat represents the output format of a generated document
+type DocumentFormat string
+
+// Supported document formats
+const (
+	DocumentFormatPDF  DocumentFormat = "pdf"
+	DocumentFormatText DocumentFormat = "txt"
+)
+
+// Document represents a generated, printable document
+type Document struct {
+	Filename    string
+	ContentType string
+	Content     []byte
+}
+
+// PackingSlipLine represents a single pack size on a pick list
+type PackingSlipLine struct {
+	PackSize int `json:"pack_size"`
+	Quantity int `json:"quantity"`
+	Items    int `json:"items"`
+}
+
+// PackingSlip represents the pick list and packing slip of a calculation
+type PackingSlip struct {
+	CalculationID string            `json:"calculation_id"`
+	ItemsOrdered  int               `json:"items_ordered"`
+	TotalItems    int               `json:"total_items"`
+	TotalPacks    int               `json:"total_packs"`
+	Overshoot     int               `json:"overshoot"`
+	Lines         []PackingSlipLine `json:"lines"` // Sorted by pack size, largest first
+	CalculatedAt  time.Time         `json:"calculated_at"`
+}
+
+// NewPackingSlip creates a packing slip from a calculation result
+func NewPackingSlip(result *CalculationResult) *PackingSlip {
+	// Largest packs are picked first
+	lines := make([]PackingSlipLine, 0, len(result.Packs))
+	for _, line := range result.Lines() {
+		lines = append(lines, PackingSlipLine{
+			PackSize: line.PackSize,
+			Quantity: line.Quantity,
+			Items:    line.PackSize * line.Quantity,
+		})
+	}
+
+	return &PackingSlip{
+		CalculationID: result.ID,
+		ItemsOrdered:  result.ItemsOrdered,
+		TotalItems:    result.TotalItems,
+		TotalPacks:    result.TotalPacks(),
+		Overshoot:     result.Overshoot(),
+		Lines:         lines,
+		CalculatedAt:  result.CreatedAt,
+	}
+}
@@ -0,0 +1,34 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestNewPackingSlip(t *testing.T) {
+	result := NewCalculationResult(12001, map[int]int{5000: 2, 2000: 1, 250: 1})
+	result.ID = "calc-id"
+
+	slip := NewPackingSlip(result)
+
+	wantLines := []PackingSlipLine{
+		{PackSize: 5000, Quantity: 2, Items: 10000},
+		{PackSize: 2000, Quantity: 1, Items: 2000},
+		{PackSize: 250, Quantity: 1, Items: 250},
+	}
+	if !reflect.DeepEqual(slip.Lines, wantLines) {
+		t.Errorf("NewPackingSlip().Lines = %v, want %v", slip.Lines, wantLines)
+	}
+	if slip.CalculationID != "calc-id" {
+		t.Errorf("NewPackingSlip().CalculationID = %v, want %v", slip.CalculationID, "calc-id")
+	}
+	if slip.TotalItems != 12250 {
+		t.Errorf("NewPackingSlip().TotalItems = %v, want %v", slip.TotalItems, 12250)
+	}
+	if slip.TotalPacks != 4 {
+		t.Errorf("NewPackingSlip().TotalPacks = %v, want %v", slip.TotalPacks, 4)
+	}
+	if slip.Overshoot != 249 {
+		t.Errorf("NewPackingSlip().Overshoot = %v, want %v", slip.Overshoot, 249)
+	}
+}
@@ -0,0 +1,77 @@
+package entities
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// PackingTable holds the optimal packing of every quantity up to a ceiling for a pack size set.
+// Quantities sharing a packing share a row, so the table only grows with the distinct packings.
+type PackingTable struct {
+	Sizes     []int   // Distinct pack sizes, largest first
+	Ceiling   int     // Largest quantity in the table
+	Index     []int32 // Row holding the packing of each quantity from 0 to Ceiling
+	Rows      []int32 // Pack quantities of every row, one column per size
+	CreatedAt time.Time
+}
+
+// PackSizeSetKey returns the key identifying a set of pack sizes, ignoring order and duplicates
+func PackSizeSetKey(sizes []int) string {
+	distinct := DistinctPackSizes(sizes)
+
+	parts := make([]string, len(distinct))
+	for i, size := range distinct {
+		parts[i] = strconv.Itoa(size)
+	}
+
+	return strings.Join(parts, ",")
+}
+
+// DistinctPackSizes returns a copy of the sizes without duplicates, largest first
+func DistinctPackSizes(sizes []int) []int {
+	distinct := make([]int, 0, len(sizes))
+	seen := make(map[int]bool, len(sizes))
+	for _, size := range sizes {
+		if !seen[size] {
+			seen[size] = true
+			distinct = append(distinct, size)
+		}
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(distinct)))
+
+	return distinct
+}
+
+// Key returns the key of the pack size set of the table
+func (t *PackingTable) Key() string {
+	return PackSizeSetKey(t.Sizes)
+}
+
+// Matches reports whether the table was built for the given pack sizes
+func (t *PackingTable) Matches(sizes []int) bool {
+	return t.Key() == PackSizeSetKey(sizes)
+}
+
+// Lookup returns the optimal packing of the quantity, it reports false beyond the ceiling
+func (t *PackingTable) Lookup(itemsOrdered int) (map[int]int, bool) {
+	if itemsOrdered <= 0 || itemsOrdered > t.Ceiling {
+		return nil, false
+	}
+
+	row := int(t.Index[itemsOrdered]) * len(t.Sizes)
+	packs := make(map[int]int)
+	for i, size := range t.Sizes {
+		if quantity := t.Rows[row+i]; quantity > 0 {
+			packs[size] = int(quantity)
+		}
+	}
+
+	return packs, true
+}
+
+// MemoryBytes returns the approximate memory held by the table
+func (t *PackingTable) MemoryBytes() int64 {
+	return int64(len(t.Index)+len(t.Rows)) * 4
+}
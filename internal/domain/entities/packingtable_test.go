@@ -0,0 +1,97 @@
+package entities
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestPackSizeSetKey(t *testing.T) {
+	tests := []struct {
+		name  string
+		sizes []int
+		want  string
+	}{
+		{
+			name:  "Sorted largest first",
+			sizes: []int{250, 1000, 500},
+			want:  "1000,500,250",
+		},
+		{
+			name:  "Duplicates ignored",
+			sizes: []int{250, 500, 250},
+			want:  "500,250",
+		},
+		{
+			name:  "Empty set",
+			sizes: []int{},
+			want:  "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := PackSizeSetKey(tt.sizes); got != tt.want {
+				t.Errorf("PackSizeSetKey() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPackingTable_Lookup(t *testing.T) {
+	// Packings of 0 to 3 items with packs of 2 and 1: {}, {1: 1}, {2: 1}, {2: 1, 1: 1}
+	table := &PackingTable{
+		Sizes:   []int{2, 1},
+		Ceiling: 3,
+		Index:   []int32{0, 1, 2, 3},
+		Rows:    []int32{0, 0, 0, 1, 1, 0, 1, 1},
+	}
+
+	tests := []struct {
+		name         string
+		itemsOrdered int
+		want         map[int]int
+		wantOK       bool
+	}{
+		{
+			name:         "Single pack",
+			itemsOrdered: 2,
+			want:         map[int]int{2: 1},
+			wantOK:       true,
+		},
+		{
+			name:         "Several packs",
+			itemsOrdered: 3,
+			want:         map[int]int{2: 1, 1: 1},
+			wantOK:       true,
+		},
+		{
+			name:         "Beyond the ceiling",
+			itemsOrdered: 4,
+			wantOK:       false,
+		},
+		{
+			name:         "Zero items",
+			itemsOrdered: 0,
+			wantOK:       false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := table.Lookup(tt.itemsOrdered)
+			if ok != tt.wantOK {
+				t.Fatalf("Lookup() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if ok && !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Lookup() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if !table.Matches([]int{1, 2, 2}) {
+		t.Errorf("Matches() = false, want true")
+	}
+	if table.Matches([]int{1, 2, 3}) {
+		t.Errorf("Matches() = true, want false")
+	}
+}
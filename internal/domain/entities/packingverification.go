@@ -0,0 +1,29 @@
+package entities
+
+// PackingRule identifies one of the business rules every packing must follow
+type PackingRule int
+
+const (
+	RuleWholePacks  PackingRule = 1 // Only whole packs of the configured sizes are sent
+	RuleFewestItems PackingRule = 2 // The fewest items fulfilling the order are sent
+	RuleFewestPacks PackingRule = 3 // Within rule 2, as few packs as possible are sent
+)
+
+// RuleViolation represents a business rule broken by a packing
+type RuleViolation struct {
+	Rule    PackingRule
+	Message string
+}
+
+// PackingVerification represents the check of a proposed packing against the business rules
+type PackingVerification struct {
+	ItemsOrdered int
+	Proposed     *CalculationResult // Nil when the proposed packing breaks rule 1
+	Optimal      *CalculationResult
+	Violations   []RuleViolation
+}
+
+// Valid returns whether the proposed packing follows every rule
+func (v *PackingVerification) Valid() bool {
+	return len(v.Violations) == 0
+}
@@ -0,0 +1,79 @@
+package entities
+
+// QuantityDemand represents how often a quantity is ordered
+type QuantityDemand struct {
+	ItemsOrdered int
+	Frequency    int // Relative weight of the quantity in the distribution
+}
+
+// PackSetComparison represents the packings of a quantity distribution with two pack size sets
+type PackSetComparison struct {
+	Current    PackSetMetrics
+	Candidate  PackSetMetrics
+	Quantities []QuantityComparison
+}
+
+// QuantityComparison represents the packings of one quantity with both pack size sets
+type QuantityComparison struct {
+	ItemsOrdered int
+	Frequency    int
+	Current      *CalculationResult
+	Candidate    *CalculationResult
+}
+
+// OvershootDifference returns how many more items the candidate set ships than the current set
+func (q QuantityComparison) OvershootDifference() int {
+	return q.Candidate.Overshoot() - q.Current.Overshoot()
+}
+
+// PacksDifference returns how many more packs the candidate set uses than the current set
+func (q QuantityComparison) PacksDifference() int {
+	return q.Candidate.TotalPacks() - q.Current.TotalPacks()
+}
+
+// PackSetMetrics represents the aggregate packing metrics of a pack size set over a quantity distribution
+type PackSetMetrics struct {
+	PackSizes           []int   // Distinct pack sizes, largest first
+	AverageOvershoot    float64 // Weighted by frequency
+	AveragePacks        float64 // Weighted by frequency
+	WorstOvershoot      int
+	WorstOvershootItems int // Quantity with the worst overshoot, the smallest on ties
+	MostPacks           int
+	MostPacksItems      int     // Quantity needing the most packs, the smallest on ties
+	OvershootRatio      float64 // Items shipped beyond the items ordered per item ordered, weighted by frequency
+}
+
+// NewPackSetMetrics aggregates the results of a pack size set, one per quantity of the demand
+func NewPackSetMetrics(packSizes []int, demand []QuantityDemand, results []*CalculationResult) PackSetMetrics {
+	metrics := PackSetMetrics{PackSizes: packSizes}
+
+	var frequency, overshoot, packs, ordered int
+	for i, result := range results {
+		weight := demand[i].Frequency
+		frequency += weight
+		overshoot += result.Overshoot() * weight
+		packs += result.TotalPacks() * weight
+		ordered += result.ItemsOrdered * weight
+
+		if result.Overshoot() > metrics.WorstOvershoot ||
+			(result.Overshoot() == metrics.WorstOvershoot && (metrics.WorstOvershootItems == 0 || result.ItemsOrdered < metrics.WorstOvershootItems)) {
+			metrics.WorstOvershoot = result.Overshoot()
+			metrics.WorstOvershootItems = result.ItemsOrdered
+		}
+		if result.TotalPacks() > metrics.MostPacks ||
+			(result.TotalPacks() == metrics.MostPacks && result.ItemsOrdered < metrics.MostPacksItems) {
+			metrics.MostPacks = result.TotalPacks()
+			metrics.MostPacksItems = result.ItemsOrdered
+		}
+	}
+
+	if frequency > 0 {
+		metrics.AverageOvershoot = float64(overshoot) / float64(frequency)
+		metrics.AveragePacks = float64(packs) / float64(frequency)
+	}
+	if ordered > 0 {
+		metrics.OvershootRatio = float64(overshoot) / float64(ordered)
+	}
+
+	return metrics
+}
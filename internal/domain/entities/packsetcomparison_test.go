@@ -0,0 +1,51 @@
+package entities
+
+import "testing"
+
+func TestNewPackSetMetrics(t *testing.T) {
+	demand := []QuantityDemand{
+		{ItemsOrdered: 250, Frequency: 3},
+		{ItemsOrdered: 251, Frequency: 1},
+		{ItemsOrdered: 1250, Frequency: 1},
+	}
+	results := []*CalculationResult{
+		NewCalculationResult(250, map[int]int{250: 1}),
+		NewCalculationResult(251, map[int]int{500: 1}),
+		NewCalculationResult(1250, map[int]int{1000: 1, 250: 1}),
+	}
+
+	metrics := NewPackSetMetrics([]int{1000, 500, 250}, demand, results)
+
+	// 249 items over 5 orders, 6 packs over 5 orders, 249 items over 2251 ordered
+	if metrics.AverageOvershoot != 249.0/5 {
+		t.Errorf("AverageOvershoot = %v, want %v", metrics.AverageOvershoot, 249.0/5)
+	}
+	if metrics.AveragePacks != 6.0/5 {
+		t.Errorf("AveragePacks = %v, want %v", metrics.AveragePacks, 6.0/5)
+	}
+	if metrics.OvershootRatio != 249.0/2251 {
+		t.Errorf("OvershootRatio = %v, want %v", metrics.OvershootRatio, 249.0/2251)
+	}
+	if metrics.WorstOvershoot != 249 || metrics.WorstOvershootItems != 251 {
+		t.Errorf("WorstOvershoot = %d at %d, want 249 at 251", metrics.WorstOvershoot, metrics.WorstOvershootItems)
+	}
+	if metrics.MostPacks != 2 || metrics.MostPacksItems != 1250 {
+		t.Errorf("MostPacks = %d at %d, want 2 at 1250", metrics.MostPacks, metrics.MostPacksItems)
+	}
+}
+
+func TestQuantityComparison_Differences(t *testing.T) {
+	comparison := QuantityComparison{
+		ItemsOrdered: 251,
+		Frequency:    1,
+		Current:      NewCalculationResult(251, map[int]int{500: 1}),
+		Candidate:    NewCalculationResult(251, map[int]int{200: 1, 100: 1}),
+	}
+
+	if got := comparison.OvershootDifference(); got != -200 {
+		t.Errorf("OvershootDifference() = %d, want -200", got)
+	}
+	if got := comparison.PacksDifference(); got != 1 {
+		t.Errorf("PacksDifference() = %d, want 1", got)
+	}
+}
@@ -2,15 +2,67 @@ package entities
 
 import (
 	"errors"
+	"fmt"
+	"slices"
+	"strings"
 	"time"
+
+	domainerrors "go-pack-calculator/internal/domain/errors"
+)
+
+// Maximum lengths of the descriptive properties of a pack size
+const (
+	MaxPackSizeNameLength = 100
+	MaxPackSizeSKULength  = 64
+	MaxPackSizeTagLength  = 50
+	MaxPackSizeTags       = 20
 )
 
 // PackSize represents a pack size entity
 type PackSize struct {
-	ID        string    `json:"id"`
-	Size      int       `json:"size"`
-	CreatedAt time.Time `json:"created_at"`
-	UpdatedAt time.Time `json:"updated_at"`
+	ID             string     `json:"id"`
+	Version        int64      `json:"version"` // Incremented by every change, for optimistic concurrency
+	Size           int        `json:"size"`
+	Name           string     `json:"name"`            // Human readable label
+	SKU            string     `json:"sku"`             // Stock keeping unit or barcode
+	Tags           []string   `json:"tags"`            // Free-form labels, trimmed and without duplicates
+	Active         bool       `json:"active"`          // Only active pack sizes are used by calculations
+	Weight         float64    `json:"weight"`          // Weight of a full pack in kilograms
+	MaterialWeight float64    `json:"material_weight"` // Weight of the packaging material in kilograms
+	EmissionFactor float64    `json:"emission_factor"` // Kilograms of CO2e per kilogram of packaging material
+	Dimensions     Dimensions `json:"dimensions"`
+	ValidFrom      *time.Time `json:"valid_from,omitempty"` // Used by calculations from this time on, always when nil
+	ValidTo        *time.Time `json:"valid_to,omitempty"`   // Used by calculations before this time, always when nil
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+	DeletedAt      *time.Time `json:"deleted_at,omitempty"` // Set while the pack size is in the trash
+}
+
+// PackSizeAttributes holds the optional properties of a pack size
+type PackSizeAttributes struct {
+	Weight         float64
+	MaterialWeight float64
+	EmissionFactor float64
+	Dimensions     Dimensions
+	Name           string
+	SKU            string
+	Tags           []string
+	Active         *bool // Active when nil
+	ValidFrom      *time.Time
+	ValidTo        *time.Time
+}
+
+// PackSizeDefinition holds the size and the optional properties of a pack size to create
+type PackSizeDefinition struct {
+	Size       int
+	Attributes PackSizeAttributes
+}
+
+// PackSizeChanges lists the pack sizes created, updated and deleted by replacing the pack size set
+type PackSizeChanges struct {
+	Created []*PackSize
+	Updated []*PackSize
+	Deleted []*PackSize
 }
 
 // NewPackSize creates a new pack size entity
@@ -23,6 +75,8 @@ func NewPackSize(size int) (*PackSize, error) {
 
 	return &PackSize{
 		Size:      size,
+		Tags:      []string{},
+		Active:    true,
 		CreatedAt: now,
 		UpdatedAt: now,
 	}, nil
@@ -48,3 +102,155 @@ func (p *PackSize) Update(size int) error {
 
 	return nil
 }
+
+// SetAttributes validates and sets the optional properties of the pack size
+func (p *PackSize) SetAttributes(attributes PackSizeAttributes) error {
+	if attributes.Weight < 0 {
+		return &domainerrors.ValidationError{
+			Field: "weight",
+			Err:   fmt.Errorf("%w: weight must not be negative", domainerrors.ErrInvalidPackSize),
+		}
+	}
+
+	if attributes.MaterialWeight < 0 {
+		return &domainerrors.ValidationError{
+			Field: "material_weight",
+			Err:   fmt.Errorf("%w: material weight must not be negative", domainerrors.ErrInvalidPackSize),
+		}
+	}
+	if attributes.EmissionFactor < 0 {
+		return &domainerrors.ValidationError{
+			Field: "emission_factor",
+			Err:   fmt.Errorf("%w: emission factor must not be negative", domainerrors.ErrInvalidPackSize),
+		}
+	}
+	if !attributes.Dimensions.IsZero() && !attributes.Dimensions.IsValid() {
+		return &domainerrors.ValidationError{
+			Field: "dimensions",
+			Err:   fmt.Errorf("%w: dimensions must all be greater than zero", domainerrors.ErrInvalidPackSize),
+		}
+	}
+
+	name := strings.TrimSpace(attributes.Name)
+	if len(name) > MaxPackSizeNameLength {
+		return &domainerrors.ValidationError{
+			Field: "name",
+			Err:   fmt.Errorf("%w: name must be at most %d characters", domainerrors.ErrInvalidPackSize, MaxPackSizeNameLength),
+		}
+	}
+	sku := strings.TrimSpace(attributes.SKU)
+	if len(sku) > MaxPackSizeSKULength {
+		return &domainerrors.ValidationError{
+			Field: "sku",
+			Err:   fmt.Errorf("%w: SKU must be at most %d characters", domainerrors.ErrInvalidPackSize, MaxPackSizeSKULength),
+		}
+	}
+	tags, err := normalizeTags(attributes.Tags)
+	if err != nil {
+		return err
+	}
+	if attributes.ValidFrom != nil && attributes.ValidTo != nil && !attributes.ValidTo.After(*attributes.ValidFrom) {
+		return &domainerrors.ValidationError{
+			Field: "valid_to",
+			Err:   fmt.Errorf("%w: valid_to must be after valid_from", domainerrors.ErrInvalidPackSize),
+		}
+	}
+
+	p.Name = name
+	p.SKU = sku
+	p.Tags = tags
+	p.Active = attributes.Active == nil || *attributes.Active
+	p.Weight = attributes.Weight
+	p.MaterialWeight = attributes.MaterialWeight
+	p.EmissionFactor = attributes.EmissionFactor
+	p.Dimensions = attributes.Dimensions
+	p.ValidFrom = attributes.ValidFrom
+	p.ValidTo = attributes.ValidTo
+	p.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// Attributes returns the optional properties of the pack size
+func (p *PackSize) Attributes() PackSizeAttributes {
+	active := p.Active
+
+	return PackSizeAttributes{
+		Weight:         p.Weight,
+		MaterialWeight: p.MaterialWeight,
+		EmissionFactor: p.EmissionFactor,
+		Dimensions:     p.Dimensions,
+		Name:           p.Name,
+		SKU:            p.SKU,
+		Tags:           p.Tags,
+		Active:         &active,
+		ValidFrom:      p.ValidFrom,
+		ValidTo:        p.ValidTo,
+	}
+}
+
+// IsValidAt reports whether the pack size is in its validity period at the given time
+func (p *PackSize) IsValidAt(t time.Time) bool {
+	if p.ValidFrom != nil && t.Before(*p.ValidFrom) {
+		return false
+	}
+
+	return p.ValidTo == nil || t.Before(*p.ValidTo)
+}
+
+// Emissions returns the kilograms of CO2e of the packaging material of one pack
+func (p *PackSize) Emissions() float64 {
+	return p.MaterialWeight * p.EmissionFactor
+}
+
+// Equal reports whether the attributes are the same, an unset active flag being active and
+// validity bounds compared as instants
+func (a PackSizeAttributes) Equal(other PackSizeAttributes) bool {
+	return a.Weight == other.Weight &&
+		a.MaterialWeight == other.MaterialWeight &&
+		a.EmissionFactor == other.EmissionFactor &&
+		a.Dimensions == other.Dimensions &&
+		a.Name == other.Name &&
+		a.SKU == other.SKU &&
+		slices.Equal(a.Tags, other.Tags) &&
+		(a.Active == nil || *a.Active) == (other.Active == nil || *other.Active) &&
+		equalTimes(a.ValidFrom, other.ValidFrom) &&
+		equalTimes(a.ValidTo, other.ValidTo)
+}
+
+// equalTimes reports whether two optional times are both unset or the same instant
+func equalTimes(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Equal(*b)
+}
+
+// normalizeTags trims the tags and removes duplicates, keeping the first occurrence of each tag
+func normalizeTags(tags []string) ([]string, error) {
+	if len(tags) > MaxPackSizeTags {
+		return nil, &domainerrors.ValidationError{
+			Field: "tags",
+			Err:   fmt.Errorf("%w: at most %d tags", domainerrors.ErrInvalidPackSize, MaxPackSizeTags),
+		}
+	}
+
+	normalized := make([]string, 0, len(tags))
+	seen := make(map[string]bool, len(tags))
+	for _, tag := range tags {
+		tag = strings.TrimSpace(tag)
+		if tag == "" || len(tag) > MaxPackSizeTagLength {
+			return nil, &domainerrors.ValidationError{
+				Field: "tags",
+				Err:   fmt.Errorf("%w: tags must have 1 to %d characters", domainerrors.ErrInvalidPackSize, MaxPackSizeTagLength),
+			}
+		}
+		if !seen[tag] {
+			seen[tag] = true
+			normalized = append(normalized, tag)
+		}
+	}
+
+	return normalized, nil
+}
@@ -1,6 +1,8 @@
 package entities
 
 import (
+	"reflect"
+	"strings"
 	"testing"
 	"time"
 )
@@ -148,3 +150,241 @@ func TestPackSize_Update(t *testing.T) {
 		})
 	}
 }
+
+func TestPackSize_SetAttributes(t *testing.T) {
+	start := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	tests := []struct {
+		name       string
+		attributes PackSizeAttributes
+		wantErr    bool
+	}{
+		{
+			name:       "Valid weight",
+			attributes: PackSizeAttributes{Weight: 2.5},
+			wantErr:    false,
+		},
+		{
+			name:       "Zero weight",
+			attributes: PackSizeAttributes{Weight: 0},
+			wantErr:    false,
+		},
+		{
+			name:       "Negative weight",
+			attributes: PackSizeAttributes{Weight: -1},
+			wantErr:    true,
+		},
+		{
+			name:       "Valid material",
+			attributes: PackSizeAttributes{Weight: 2.5, MaterialWeight: 0.2, EmissionFactor: 1.5},
+			wantErr:    false,
+		},
+		{
+			name:       "Negative material weight",
+			attributes: PackSizeAttributes{MaterialWeight: -0.2},
+			wantErr:    true,
+		},
+		{
+			name:       "Negative emission factor",
+			attributes: PackSizeAttributes{EmissionFactor: -1.5},
+			wantErr:    true,
+		},
+		{
+			name:       "Valid dimensions",
+			attributes: PackSizeAttributes{Dimensions: Dimensions{Length: 30, Width: 20, Height: 10}},
+			wantErr:    false,
+		},
+		{
+			name:       "Partial dimensions",
+			attributes: PackSizeAttributes{Dimensions: Dimensions{Length: 30, Width: 20}},
+			wantErr:    true,
+		},
+		{
+			name:       "Valid details",
+			attributes: PackSizeAttributes{Name: "Small box", SKU: "PK-250", Tags: []string{"small", "cardboard"}},
+			wantErr:    false,
+		},
+		{
+			name:       "Name too long",
+			attributes: PackSizeAttributes{Name: strings.Repeat("a", MaxPackSizeNameLength+1)},
+			wantErr:    true,
+		},
+		{
+			name:       "SKU too long",
+			attributes: PackSizeAttributes{SKU: strings.Repeat("1", MaxPackSizeSKULength+1)},
+			wantErr:    true,
+		},
+		{
+			name:       "Empty tag",
+			attributes: PackSizeAttributes{Tags: []string{"small", " "}},
+			wantErr:    true,
+		},
+		{
+			name:       "Too many tags",
+			attributes: PackSizeAttributes{Tags: make([]string, MaxPackSizeTags+1)},
+			wantErr:    true,
+		},
+		{
+			name:       "Valid period",
+			attributes: PackSizeAttributes{ValidFrom: &start, ValidTo: &end},
+			wantErr:    false,
+		},
+		{
+			name:       "Open-ended period",
+			attributes: PackSizeAttributes{ValidFrom: &start},
+			wantErr:    false,
+		},
+		{
+			name:       "Period ending before it starts",
+			attributes: PackSizeAttributes{ValidFrom: &end, ValidTo: &start},
+			wantErr:    true,
+		},
+		{
+			name:       "Empty period",
+			attributes: PackSizeAttributes{ValidFrom: &start, ValidTo: &start},
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packSize, _ := NewPackSize(250)
+
+			err := packSize.SetAttributes(tt.attributes)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("PackSize.SetAttributes() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if !tt.wantErr && packSize.Weight != tt.attributes.Weight {
+				t.Errorf("PackSize.SetAttributes() weight = %v, want %v", packSize.Weight, tt.attributes.Weight)
+			}
+
+			if !tt.wantErr && packSize.MaterialWeight != tt.attributes.MaterialWeight {
+				t.Errorf("PackSize.SetAttributes() material weight = %v, want %v", packSize.MaterialWeight, tt.attributes.MaterialWeight)
+			}
+
+			if !tt.wantErr && packSize.EmissionFactor != tt.attributes.EmissionFactor {
+				t.Errorf("PackSize.SetAttributes() emission factor = %v, want %v", packSize.EmissionFactor, tt.attributes.EmissionFactor)
+			}
+		})
+	}
+}
+
+func TestPackSize_SetAttributesDetails(t *testing.T) {
+	inactive := false
+
+	tests := []struct {
+		name       string
+		attributes PackSizeAttributes
+		wantName   string
+		wantSKU    string
+		wantTags   []string
+		wantActive bool
+	}{
+		{
+			name:       "Active by default",
+			attributes: PackSizeAttributes{},
+			wantTags:   []string{},
+			wantActive: true,
+		},
+		{
+			name:       "Deactivated",
+			attributes: PackSizeAttributes{Active: &inactive},
+			wantTags:   []string{},
+			wantActive: false,
+		},
+		{
+			name:       "Trimmed details and duplicate tags",
+			attributes: PackSizeAttributes{Name: " Small box ", SKU: " PK-250", Tags: []string{"small ", "cardboard", "small"}},
+			wantName:   "Small box",
+			wantSKU:    "PK-250",
+			wantTags:   []string{"small", "cardboard"},
+			wantActive: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packSize, _ := NewPackSize(250)
+
+			if err := packSize.SetAttributes(tt.attributes); err != nil {
+				t.Fatalf("PackSize.SetAttributes() error = %v", err)
+			}
+
+			if packSize.Name != tt.wantName || packSize.SKU != tt.wantSKU {
+				t.Errorf("PackSize.SetAttributes() name, SKU = %q, %q, want %q, %q", packSize.Name, packSize.SKU, tt.wantName, tt.wantSKU)
+			}
+			if !reflect.DeepEqual(packSize.Tags, tt.wantTags) {
+				t.Errorf("PackSize.SetAttributes() tags = %v, want %v", packSize.Tags, tt.wantTags)
+			}
+			if packSize.Active != tt.wantActive {
+				t.Errorf("PackSize.SetAttributes() active = %v, want %v", packSize.Active, tt.wantActive)
+			}
+		})
+	}
+}
+
+func TestPackSize_IsValidAt(t *testing.T) {
+	start := time.Date(2026, 11, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 1, 0)
+
+	tests := []struct {
+		name      string
+		validFrom *time.Time
+		validTo   *time.Time
+		at        time.Time
+		want      bool
+	}{
+		{
+			name: "Always valid",
+			at:   start,
+			want: true,
+		},
+		{
+			name:      "Before the start",
+			validFrom: &start,
+			at:        start.Add(-time.Second),
+			want:      false,
+		},
+		{
+			name:      "At the start",
+			validFrom: &start,
+			validTo:   &end,
+			at:        start,
+			want:      true,
+		},
+		{
+			name:      "At the end",
+			validFrom: &start,
+			validTo:   &end,
+			at:        end,
+			want:      false,
+		},
+		{
+			name:    "Before the end",
+			validTo: &end,
+			at:      end.Add(-time.Second),
+			want:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packSize := &PackSize{Size: 250, ValidFrom: tt.validFrom, ValidTo: tt.validTo}
+
+			if got := packSize.IsValidAt(tt.at); got != tt.want {
+				t.Errorf("PackSize.IsValidAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPackSize_Emissions(t *testing.T) {
+	packSize := &PackSize{Size: 250, MaterialWeight: 0.5, EmissionFactor: 2}
+
+	if got := packSize.Emissions(); got != 1 {
+		t.Errorf("PackSize.Emissions() = %v, want %v", got, 1)
+	}
+}
@@ -0,0 +1,46 @@
+package entities
+
+import "time"
+
+// AuditAction represents the kind of change an audit entry records
+type AuditAction string
+
+// Changes recorded in the audit trail of pack sizes
+const (
+	AuditActionCreated  AuditAction = "created"
+	AuditActionUpdated  AuditAction = "updated"
+	AuditActionDeleted  AuditAction = "deleted"
+	AuditActionRestored AuditAction = "restored"
+	AuditActionPurged   AuditAction = "purged"
+)
+
+// PackSizeAuditEntry records a change of a pack size, it is never changed once written
+type PackSizeAuditEntry struct {
+	ID           string      `json:"id"`
+	PackSizeID   string      `json:"pack_size_id"`
+	Action       AuditAction `json:"action"`
+	ClaimedActor string      `json:"claimed_actor"` // Who the request claimed to come from, not verified
+	RequestID    string      `json:"request_id"`    // Request that made the change
+	Before       *PackSize   `json:"before"`        // nil for created pack sizes
+	After        *PackSize   `json:"after"`         // nil for deleted pack sizes
+	Timestamp    time.Time   `json:"timestamp"`
+}
+
+// NewPackSizeAuditEntry creates an audit entry of a change of a pack size made now
+func NewPackSizeAuditEntry(action AuditAction, before, after *PackSize, claimedActor, requestID string) *PackSizeAuditEntry {
+	entry := &PackSizeAuditEntry{
+		Action:       action,
+		ClaimedActor: claimedActor,
+		RequestID:    requestID,
+		Before:       before,
+		After:        after,
+		Timestamp:    time.Now(),
+	}
+	if after != nil {
+		entry.PackSizeID = after.ID
+	} else if before != nil {
+		entry.PackSizeID = before.ID
+	}
+
+	return entry
+}
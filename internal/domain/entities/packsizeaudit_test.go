@@ -0,0 +1,41 @@
+package entities
+
+import "testing"
+
+func TestNewPackSizeAuditEntry(t *testing.T) {
+	packSize := &PackSize{ID: "test-id", Size: 250}
+
+	tests := []struct {
+		name   string
+		action AuditAction
+		before *PackSize
+		after  *PackSize
+	}{
+		{
+			name:   "Created",
+			action: AuditActionCreated,
+			after:  packSize,
+		},
+		{
+			name:   "Deleted",
+			action: AuditActionDeleted,
+			before: packSize,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			entry := NewPackSizeAuditEntry(tt.action, tt.before, tt.after, "alice", "req-1")
+
+			if entry.PackSizeID != "test-id" {
+				t.Errorf("NewPackSizeAuditEntry() pack size ID = %q, want %q", entry.PackSizeID, "test-id")
+			}
+			if entry.Action != tt.action || entry.ClaimedActor != "alice" || entry.RequestID != "req-1" {
+				t.Errorf("NewPackSizeAuditEntry() = %+v", entry)
+			}
+			if entry.Timestamp.IsZero() {
+				t.Error("NewPackSizeAuditEntry() timestamp not set")
+			}
+		})
+	}
+}
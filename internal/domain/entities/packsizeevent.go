@@ -0,0 +1,58 @@
+package entities
+
+import "time"
+
+// PackSizeEventType represents the kind of change a pack size event announces
+type PackSizeEventType string
+
+// Pack size changes announced to downstream systems
+const (
+	PackSizeCreated  PackSizeEventType = "pack_size.created"
+	PackSizeUpdated  PackSizeEventType = "pack_size.updated"
+	PackSizeDeleted  PackSizeEventType = "pack_size.deleted"
+	PackSizeRestored PackSizeEventType = "pack_size.restored"
+)
+
+// PackSizeEvent is a domain event announcing a change of a pack size. It is delivered at least
+// once, so consumers skip the IDs they have already handled.
+type PackSizeEvent struct {
+	ID           string            `json:"id"`
+	Type         PackSizeEventType `json:"type"`
+	PackSizeID   string            `json:"pack_size_id"`
+	PackSize     *PackSize         `json:"pack_size"`     // The pack size after the change, before it for deleted pack sizes
+	ClaimedActor string            `json:"claimed_actor"` // Who the request claimed to come from, not verified
+	RequestID    string            `json:"request_id"`    // Request that made the change
+	OccurredAt   time.Time         `json:"occurred_at"`
+}
+
+// NewPackSizeEvent creates an event of a change of a pack size made now
+func NewPackSizeEvent(eventType PackSizeEventType, packSize *PackSize, claimedActor, requestID string) *PackSizeEvent {
+	return &PackSizeEvent{
+		Type:         eventType,
+		PackSizeID:   packSize.ID,
+		PackSize:     packSize,
+		ClaimedActor: claimedActor,
+		RequestID:    requestID,
+		OccurredAt:   time.Now(),
+	}
+}
+
+// OutboxEntry is an event saved in the outbox with the change it announces, waiting to be
+// delivered to the publishers
+type OutboxEntry struct {
+	Event         *PackSizeEvent
+	Attempts      int       // Failed deliveries so far
+	NextAttemptAt time.Time // Not delivered before
+	LastError     string    // Why the last delivery failed
+}
+
+// RetryDelay returns how long to wait after the given number of failed deliveries: the backoff
+// doubled for every failure after the first, at most the maximum
+func RetryDelay(attempts int, backoff, maxBackoff time.Duration) time.Duration {
+	delay := backoff
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, maxBackoff)
+}
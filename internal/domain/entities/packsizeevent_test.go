@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNewPackSizeEvent(t *testing.T) {
+	packSize := &PackSize{ID: "test-id", Size: 250}
+
+	event := NewPackSizeEvent(PackSizeDeleted, packSize, "alice", "req-1")
+
+	if event.PackSizeID != "test-id" || event.PackSize != packSize {
+		t.Errorf("NewPackSizeEvent() pack size = %q %v, want %q", event.PackSizeID, event.PackSize, "test-id")
+	}
+	if event.Type != PackSizeDeleted || event.ClaimedActor != "alice" || event.RequestID != "req-1" {
+		t.Errorf("NewPackSizeEvent() = %+v", event)
+	}
+	if event.OccurredAt.IsZero() {
+		t.Error("NewPackSizeEvent() occurred at not set")
+	}
+}
+
+func TestRetryDelay(t *testing.T) {
+	tests := []struct {
+		attempts int
+		want     time.Duration
+	}{
+		{attempts: 1, want: time.Second},
+		{attempts: 2, want: 2 * time.Second},
+		{attempts: 4, want: 8 * time.Second},
+		{attempts: 7, want: time.Minute},
+		{attempts: 1000, want: time.Minute},
+	}
+
+	for _, tt := range tests {
+		if got := RetryDelay(tt.attempts, time.Second, time.Minute); got != tt.want {
+			t.Errorf("RetryDelay(%d) = %v, want %v", tt.attempts, got, tt.want)
+		}
+	}
+}
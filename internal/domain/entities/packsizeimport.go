@@ -0,0 +1,73 @@
+package entities
+
+// ImportStrategy represents how imported pack sizes are combined with the stored ones
+type ImportStrategy string
+
+const (
+	// ImportStrategyMerge creates the imported sizes that are missing and updates the others,
+	// keeping the pack sizes of sizes that are not imported
+	ImportStrategyMerge ImportStrategy = "merge"
+	// ImportStrategyReplace also deletes the pack sizes of sizes that are not imported
+	ImportStrategyReplace ImportStrategy = "replace"
+)
+
+// IsValid reports whether the strategy is known, an empty strategy means merge
+func (s ImportStrategy) IsValid() bool {
+	switch s {
+	case "", ImportStrategyMerge, ImportStrategyReplace:
+		return true
+	default:
+		return false
+	}
+}
+
+// ImportAction represents what an import does with a row
+type ImportAction string
+
+const (
+	// ImportActionCreate creates a pack size of a size that is not stored
+	ImportActionCreate ImportAction = "create"
+	// ImportActionUpdate updates the stored pack size of the size to the properties of the row
+	ImportActionUpdate ImportAction = "update"
+	// ImportActionUnchanged leaves the stored pack size of the size, which already has the properties of the row
+	ImportActionUnchanged ImportAction = "unchanged"
+	// ImportActionInvalid rejects a row that cannot be read or fails validation
+	ImportActionInvalid ImportAction = "invalid"
+)
+
+// PackSizeImportRow represents a pack size read from an imported file
+type PackSizeImportRow struct {
+	Row        int // Position of the pack size in the file, from 1
+	Definition PackSizeDefinition
+	Errors     []string // Problems reading the row, which make it invalid
+}
+
+// PackSizeImportRowResult represents the outcome of an imported row
+type PackSizeImportRowResult struct {
+	Row      int
+	Size     int
+	Action   ImportAction
+	Errors   []string
+	PackSize *PackSize // The pack size after the import, nil when the row is invalid
+}
+
+// PackSizeImportReport represents the outcome of an import, row by row. An import is applied
+// entirely or not at all: a single invalid row or a dry run leaves the pack sizes unchanged.
+type PackSizeImportReport struct {
+	Strategy ImportStrategy
+	DryRun   bool
+	Applied  bool
+	Rows     []PackSizeImportRowResult
+	Deleted  []*PackSize // Pack sizes deleted by the replace strategy
+}
+
+// Valid returns whether every row of the import is valid
+func (r *PackSizeImportReport) Valid() bool {
+	for _, row := range r.Rows {
+		if row.Action == ImportActionInvalid {
+			return false
+		}
+	}
+
+	return true
+}
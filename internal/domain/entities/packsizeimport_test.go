@@ -0,0 +1,41 @@
+package entities
+
+import "testing"
+
+func TestImportStrategy_IsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		strategy ImportStrategy
+		want     bool
+	}{
+		{name: "Default", strategy: "", want: true},
+		{name: "Merge", strategy: ImportStrategyMerge, want: true},
+		{name: "Replace", strategy: ImportStrategyReplace, want: true},
+		{name: "Unknown", strategy: "upsert", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.strategy.IsValid(); got != tt.want {
+				t.Errorf("ImportStrategy.IsValid() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPackSizeImportReport_Valid(t *testing.T) {
+	report := &PackSizeImportReport{
+		Rows: []PackSizeImportRowResult{
+			{Row: 1, Action: ImportActionCreate},
+			{Row: 2, Action: ImportActionUnchanged},
+		},
+	}
+	if !report.Valid() {
+		t.Error("PackSizeImportReport.Valid() = false, want true")
+	}
+
+	report.Rows = append(report.Rows, PackSizeImportRowResult{Row: 3, Action: ImportActionInvalid})
+	if report.Valid() {
+		t.Error("PackSizeImportReport.Valid() = true, want false with an invalid row")
+	}
+}
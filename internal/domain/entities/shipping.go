@@ -0,0 +1,72 @@
+package entities
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	domainerrors "go-pack-calculator/internal/domain/errors"
+)
+
+// ShippingRate represents one row of a carrier rate table. A shipment is covered by the
+// rate when its weight and number of parcels do not exceed the limits of the rate.
+type ShippingRate struct {
+	ID         string    `json:"id"`
+	Carrier    string    `json:"carrier"`
+	MaxWeight  float64   `json:"max_weight"` // Kilograms
+	MaxParcels int       `json:"max_parcels"`
+	Price      float64   `json:"price"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// ShippingEstimate represents the estimated cost of shipping a packing with a carrier
+type ShippingEstimate struct {
+	Carrier string  `json:"carrier"`
+	Price   float64 `json:"price"`
+}
+
+// NewShippingRate creates a new shipping rate entity
+func NewShippingRate(carrier string, maxWeight float64, maxParcels int, price float64) (*ShippingRate, error) {
+	rate := &ShippingRate{
+		Carrier:    strings.TrimSpace(carrier),
+		MaxWeight:  maxWeight,
+		MaxParcels: maxParcels,
+		Price:      price,
+		CreatedAt:  time.Now(),
+	}
+
+	if err := rate.Validate(); err != nil {
+		return nil, err
+	}
+
+	return rate, nil
+}
+
+// Validate validates the shipping rate entity
+func (r *ShippingRate) Validate() error {
+	switch {
+	case r.Carrier == "":
+		return shippingRateError("carrier", "carrier must not be empty")
+	case r.MaxWeight <= 0:
+		return shippingRateError("max_weight", "max weight must be greater than zero")
+	case r.MaxParcels <= 0:
+		return shippingRateError("max_parcels", "max parcels must be greater than zero")
+	case r.Price < 0:
+		return shippingRateError("price", "price must not be negative")
+	}
+
+	return nil
+}
+
+// Covers reports whether a shipment of the given weight and number of parcels can be sent at this rate
+func (r *ShippingRate) Covers(weight float64, parcels int) bool {
+	return weight <= r.MaxWeight && parcels <= r.MaxParcels
+}
+
+// shippingRateError builds a validation error for a shipping rate field
+func shippingRateError(field, message string) error {
+	return &domainerrors.ValidationError{
+		Field: field,
+		Err:   fmt.Errorf("%w: %s", domainerrors.ErrInvalidShippingRate, message),
+	}
+}
@@ -0,0 +1,95 @@
+package entities
+
+import (
+	"errors"
+	"testing"
+
+	domainerrors "go-pack-calculator/internal/domain/errors"
+)
+
+func TestNewShippingRate(t *testing.T) {
+	tests := []struct {
+		name       string
+		carrier    string
+		maxWeight  float64
+		maxParcels int
+		price      float64
+		wantErr    bool
+	}{
+		{
+			name:       "Valid rate",
+			carrier:    "DHL",
+			maxWeight:  20,
+			maxParcels: 5,
+			price:      12.5,
+			wantErr:    false,
+		},
+		{
+			name:       "Empty carrier",
+			carrier:    "  ",
+			maxWeight:  20,
+			maxParcels: 5,
+			price:      12.5,
+			wantErr:    true,
+		},
+		{
+			name:       "Zero max weight",
+			carrier:    "DHL",
+			maxWeight:  0,
+			maxParcels: 5,
+			price:      12.5,
+			wantErr:    true,
+		},
+		{
+			name:       "Zero max parcels",
+			carrier:    "DHL",
+			maxWeight:  20,
+			maxParcels: 0,
+			price:      12.5,
+			wantErr:    true,
+		},
+		{
+			name:       "Negative price",
+			carrier:    "DHL",
+			maxWeight:  20,
+			maxParcels: 5,
+			price:      -1,
+			wantErr:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewShippingRate(tt.carrier, tt.maxWeight, tt.maxParcels, tt.price)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewShippingRate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			if tt.wantErr {
+				if !errors.Is(err, domainerrors.ErrInvalidShippingRate) {
+					t.Errorf("NewShippingRate() error = %v, want ErrInvalidShippingRate", err)
+				}
+				return
+			}
+
+			if got.Carrier != tt.carrier || got.MaxWeight != tt.maxWeight || got.MaxParcels != tt.maxParcels || got.Price != tt.price {
+				t.Errorf("NewShippingRate() = %+v", got)
+			}
+		})
+	}
+}
+
+func TestShippingRate_Covers(t *testing.T) {
+	rate, _ := NewShippingRate("DHL", 20, 5, 12.5)
+
+	if !rate.Covers(20, 5) {
+		t.Errorf("ShippingRate.Covers() = false for shipment at the limits")
+	}
+	if rate.Covers(20.1, 5) {
+		t.Errorf("ShippingRate.Covers() = true for shipment above the weight limit")
+	}
+	if rate.Covers(10, 6) {
+		t.Errorf("ShippingRate.Covers() = true for shipment above the parcel limit")
+	}
+}
@@ -3,15 +3,36 @@ package errors
 import (
 	"errors"
 	"fmt"
+	"time"
 )
 
 // Domain errors
 var (
 	ErrPackSizeNotFound     = errors.New("pack size not found")
+	ErrDuplicatePackSize    = errors.New("pack size already exists")
 	ErrInvalidPackSize      = errors.New("invalid pack size")
 	ErrInvalidItemsOrdered  = errors.New("invalid items ordered")
 	ErrNoPackSizesAvailable = errors.New("no pack sizes available")
 	ErrDatabaseOperation    = errors.New("database operation failed")
+	ErrNoOrders             = errors.New("no orders to consolidate")
+	ErrDuplicateOrderID     = errors.New("duplicate order ID")
+	ErrCalculationNotFound  = errors.New("calculation not found")
+	ErrUnsupportedFormat    = errors.New("unsupported document format")
+	ErrInvalidShippingRate  = errors.New("invalid shipping rate")
+	ErrInvalidObjective     = errors.New("invalid calculation objective")
+	ErrInvalidBox           = errors.New("invalid box")
+	ErrNoBoxes              = errors.New("no boxes available")
+	ErrMissingDimensions    = errors.New("pack size has no dimensions")
+	ErrPackDoesNotFitBox    = errors.New("pack does not fit in any box")
+	ErrCalculationTooLarge  = errors.New("calculation exceeds the computation budget")
+	ErrCalculatorBusy       = errors.New("calculator is busy")
+	ErrPackingTableNotFound = errors.New("packing table not found")
+	ErrInvalidRange         = errors.New("invalid quantity range")
+	ErrNoQuantities         = errors.New("no quantities to compare")
+	ErrInvalidFrequency     = errors.New("invalid quantity frequency")
+	ErrVersionMismatch      = errors.New("pack size was modified by another request")
+	ErrInvalidImport        = errors.New("invalid import")
+	ErrOutboxEntryNotFound  = errors.New("outbox entry not found")
 )
 
 // NotFoundError represents a not found error
@@ -45,3 +66,19 @@ func (e *ValidationError) Error() string {
 func (e *ValidationError) Unwrap() error {
 	return e.Err
 }
+
+// RetryAfterError represents a temporary error that can be retried after a delay
+type RetryAfterError struct {
+	RetryAfter time.Duration
+	Err        error
+}
+
+// Error returns the error message
+func (e *RetryAfterError) Error() string {
+	return fmt.Sprintf("%v, retry after %s", e.Err, e.RetryAfter)
+}
+
+// Unwrap returns the wrapped error
+func (e *RetryAfterError) Unwrap() error {
+	return e.Err
+}
@@ -3,6 +3,7 @@ package errors
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -45,6 +46,24 @@ func TestValidationError(t *testing.T) {
 	assert.True(t, errors.Is(err, ErrInvalidPackSize))
 }
 
+func TestRetryAfterError(t *testing.T) {
+	// Create a RetryAfterError
+	err := &RetryAfterError{
+		RetryAfter: 2 * time.Second,
+		Err:        ErrCalculatorBusy,
+	}
+
+	// Test Error() method
+	expectedErrorMessage := "calculator is busy, retry after 2s"
+	assert.Equal(t, expectedErrorMessage, err.Error())
+
+	// Test Unwrap() method
+	assert.Equal(t, ErrCalculatorBusy, err.Unwrap())
+
+	// Test errors.Is
+	assert.True(t, errors.Is(err, ErrCalculatorBusy))
+}
+
 func TestDomainErrors(t *testing.T) {
 	// Test that all domain errors are defined
 	assert.NotNil(t, ErrPackSizeNotFound)
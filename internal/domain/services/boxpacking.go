@@ -0,0 +1,277 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+)
+
+// packingTolerance absorbs floating point error when summing weights and lengths
+const packingTolerance = 1e-9
+
+// BoxPackingService assigns packs to physical shipping boxes
+type BoxPackingService struct{}
+
+// NewBoxPackingService creates a new box packing service
+func NewBoxPackingService() *BoxPackingService {
+	return &BoxPackingService{}
+}
+
+// packItem represents a single pack to be placed in a box
+type packItem struct {
+	size       int
+	dimensions entities.Dimensions
+	weight     float64
+}
+
+// freeSpace represents an empty cuboid inside a box
+type freeSpace struct {
+	position   entities.Position
+	dimensions entities.Dimensions
+}
+
+// openBox represents a box that is being filled
+type openBox struct {
+	box        entities.Box
+	spaces     []freeSpace
+	items      []packItem
+	placements []entities.PackPlacement
+	weight     float64
+}
+
+// Pack assigns the packs to as few boxes of the catalog as possible using 3D
+// first-fit-decreasing with rotation. Packs are placed largest first into the
+// first open box with room, opening the largest box type that holds the pack
+// when none has. Every box is then swapped for the smallest box type its packs
+// fit in.
+func (s *BoxPackingService) Pack(
+	packs map[int]int,
+	packSizes map[int]*entities.PackSize,
+	boxes []entities.Box,
+) ([]entities.BoxAssignment, error) {
+	if len(boxes) == 0 {
+		return nil, errors.ErrNoBoxes
+	}
+	for i := range boxes {
+		if err := boxes[i].Validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	items, err := expandPacks(packs, packSizes)
+	if err != nil {
+		return nil, err
+	}
+
+	// Sort the catalog from the smallest to the largest box, keeping the given order on ties
+	catalog := make([]entities.Box, len(boxes))
+	copy(catalog, boxes)
+	sort.SliceStable(catalog, func(i, j int) bool {
+		if catalog[i].Dimensions.Volume() != catalog[j].Dimensions.Volume() {
+			return catalog[i].Dimensions.Volume() < catalog[j].Dimensions.Volume()
+		}
+
+		return catalog[i].MaxWeight < catalog[j].MaxWeight
+	})
+
+	opened := make([]*openBox, 0)
+	for _, item := range items {
+		placed := false
+		for _, b := range opened {
+			if b.place(item) {
+				placed = true
+				break
+			}
+		}
+		if placed {
+			continue
+		}
+
+		// Open the largest box type that holds the pack, leaving the most room for later packs
+		for i := len(catalog) - 1; i >= 0 && !placed; i-- {
+			b := newOpenBox(catalog[i])
+			if b.place(item) {
+				opened = append(opened, b)
+				placed = true
+			}
+		}
+		if !placed {
+			return nil, fmt.Errorf("%w: pack size %d", errors.ErrPackDoesNotFitBox, item.size)
+		}
+	}
+
+	assignments := make([]entities.BoxAssignment, len(opened))
+	for i, b := range opened {
+		assignments[i] = shrinkBox(b, catalog).assignment()
+	}
+
+	return assignments, nil
+}
+
+// expandPacks turns the pack quantities into single packs, largest volume first
+func expandPacks(packs map[int]int, packSizes map[int]*entities.PackSize) ([]packItem, error) {
+	sizes := make([]int, 0, len(packs))
+	for size := range packs {
+		sizes = append(sizes, size)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(sizes)))
+
+	items := make([]packItem, 0)
+	for _, size := range sizes {
+		packSize, ok := packSizes[size]
+		if !ok || !packSize.Dimensions.IsValid() {
+			return nil, fmt.Errorf("%w: pack size %d", errors.ErrMissingDimensions, size)
+		}
+
+		for n := 0; n < packs[size]; n++ {
+			items = append(items, packItem{
+				size:       size,
+				dimensions: packSize.Dimensions,
+				weight:     packSize.Weight,
+			})
+		}
+	}
+
+	// Sizes were added largest first, so equal volumes stay in that order
+	sort.SliceStable(items, func(i, j int) bool {
+		if items[i].dimensions.Volume() != items[j].dimensions.Volume() {
+			return items[i].dimensions.Volume() > items[j].dimensions.Volume()
+		}
+
+		return items[i].weight > items[j].weight
+	})
+
+	return items, nil
+}
+
+// shrinkBox repacks the packs of a box into the smallest box type that holds them.
+// The catalog must be sorted from the smallest to the largest box.
+func shrinkBox(b *openBox, catalog []entities.Box) *openBox {
+	for _, box := range catalog {
+		if box.Dimensions.Volume() >= b.box.Dimensions.Volume() {
+			break
+		}
+
+		candidate := newOpenBox(box)
+		fits := true
+		for _, item := range b.items {
+			if !candidate.place(item) {
+				fits = false
+				break
+			}
+		}
+		if fits {
+			return candidate
+		}
+	}
+
+	return b
+}
+
+// newOpenBox creates an empty open box
+func newOpenBox(box entities.Box) *openBox {
+	return &openBox{
+		box:    box,
+		spaces: []freeSpace{{dimensions: box.Dimensions}},
+	}
+}
+
+// place puts the pack in the first free space it fits in, trying every rotation.
+// It reports whether the pack was placed.
+func (b *openBox) place(item packItem) bool {
+	if b.weight+item.weight > b.box.MaxWeight+packingTolerance {
+		return false
+	}
+
+	for i, space := range b.spaces {
+		for _, rotation := range item.dimensions.Rotations() {
+			if !rotation.FitsIn(space.dimensions) {
+				continue
+			}
+
+			b.items = append(b.items, item)
+			b.placements = append(b.placements, entities.PackPlacement{
+				PackSize:   item.size,
+				Position:   space.position,
+				Dimensions: rotation,
+			})
+			b.weight += item.weight
+
+			// Replace the used space with the space left around the pack
+			spaces := make([]freeSpace, 0, len(b.spaces)+2)
+			spaces = append(spaces, b.spaces[:i]...)
+			spaces = append(spaces, b.spaces[i+1:]...)
+			spaces = append(spaces, splitSpace(space, rotation)...)
+			sortSpaces(spaces)
+			b.spaces = spaces
+
+			return true
+		}
+	}
+
+	return false
+}
+
+// assignment converts the open box into a box assignment
+func (b *openBox) assignment() entities.BoxAssignment {
+	packs := make(map[int]int)
+	for _, item := range b.items {
+		packs[item.size]++
+	}
+
+	return entities.BoxAssignment{
+		Box:         b.box,
+		Packs:       packs,
+		Placements:  b.placements,
+		TotalWeight: b.weight,
+	}
+}
+
+// splitSpace divides the space left after placing a pack in its corner into up to
+// three disjoint spaces: beside, behind and on top of the pack
+func splitSpace(space freeSpace, placed entities.Dimensions) []freeSpace {
+	position, size := space.position, space.dimensions
+
+	candidates := []freeSpace{
+		{
+			position:   entities.Position{X: position.X + placed.Length, Y: position.Y, Z: position.Z},
+			dimensions: entities.Dimensions{Length: size.Length - placed.Length, Width: size.Width, Height: size.Height},
+		},
+		{
+			position:   entities.Position{X: position.X, Y: position.Y + placed.Width, Z: position.Z},
+			dimensions: entities.Dimensions{Length: placed.Length, Width: size.Width - placed.Width, Height: size.Height},
+		},
+		{
+			position:   entities.Position{X: position.X, Y: position.Y, Z: position.Z + placed.Height},
+			dimensions: entities.Dimensions{Length: placed.Length, Width: placed.Width, Height: size.Height - placed.Height},
+		},
+	}
+
+	spaces := make([]freeSpace, 0, len(candidates))
+	for _, candidate := range candidates {
+		if candidate.dimensions.Length > packingTolerance &&
+			candidate.dimensions.Width > packingTolerance &&
+			candidate.dimensions.Height > packingTolerance {
+			spaces = append(spaces, candidate)
+		}
+	}
+
+	return spaces
+}
+
+// sortSpaces orders the free spaces bottom first, then back to front and left to right
+func sortSpaces(spaces []freeSpace) {
+	sort.SliceStable(spaces, func(i, j int) bool {
+		a, b := spaces[i].position, spaces[j].position
+		if a.Z != b.Z {
+			return a.Z < b.Z
+		}
+		if a.Y != b.Y {
+			return a.Y < b.Y
+		}
+
+		return a.X < b.X
+	})
+}
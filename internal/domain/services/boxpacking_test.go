@@ -0,0 +1,169 @@
+package services
+
+import (
+	stderr "errors"
+	"reflect"
+	"testing"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+)
+
+// createBoxTestPackSize is a helper function to create pack sizes with dimensions for tests
+func createBoxTestPackSize(size int, length, width, height, weight float64) *entities.PackSize {
+	return &entities.PackSize{
+		Size:       size,
+		Weight:     weight,
+		Dimensions: entities.Dimensions{Length: length, Width: width, Height: height},
+	}
+}
+
+func TestBoxPackingService_Pack(t *testing.T) {
+	service := NewBoxPackingService()
+
+	cube := createBoxTestPackSize(250, 10, 10, 10, 1)
+	long := createBoxTestPackSize(500, 30, 10, 10, 2)
+	heavy := createBoxTestPackSize(1000, 10, 10, 10, 6)
+	flat := createBoxTestPackSize(2000, 0, 0, 0, 1)
+
+	small := entities.Box{Name: "S", Dimensions: entities.Dimensions{Length: 10, Width: 10, Height: 10}, MaxWeight: 10}
+	medium := entities.Box{Name: "M", Dimensions: entities.Dimensions{Length: 20, Width: 20, Height: 20}, MaxWeight: 10}
+	tall := entities.Box{Name: "T", Dimensions: entities.Dimensions{Length: 10, Width: 10, Height: 30}, MaxWeight: 10}
+	large := entities.Box{Name: "L", Dimensions: entities.Dimensions{Length: 50, Width: 50, Height: 50}, MaxWeight: 30}
+
+	packSizes := map[int]*entities.PackSize{250: cube, 500: long, 1000: heavy, 2000: flat}
+
+	tests := []struct {
+		name      string
+		packs     map[int]int
+		boxes     []entities.Box
+		wantBoxes []string
+		wantPacks []map[int]int
+		wantErr   error
+	}{
+		{
+			name:      "Eight cubes fill one box",
+			packs:     map[int]int{250: 8},
+			boxes:     []entities.Box{medium},
+			wantBoxes: []string{"M"},
+			wantPacks: []map[int]int{{250: 8}},
+		},
+		{
+			name:      "Ninth cube opens a second box",
+			packs:     map[int]int{250: 9},
+			boxes:     []entities.Box{medium},
+			wantBoxes: []string{"M", "M"},
+			wantPacks: []map[int]int{{250: 8}, {250: 1}},
+		},
+		{
+			name:      "Pack is rotated to fit",
+			packs:     map[int]int{500: 1},
+			boxes:     []entities.Box{tall},
+			wantBoxes: []string{"T"},
+			wantPacks: []map[int]int{{500: 1}},
+		},
+		{
+			name:      "Weight limit splits boxes",
+			packs:     map[int]int{1000: 2},
+			boxes:     []entities.Box{medium},
+			wantBoxes: []string{"M", "M"},
+			wantPacks: []map[int]int{{1000: 1}, {1000: 1}},
+		},
+		{
+			name:      "Smallest box that holds the packs is chosen",
+			packs:     map[int]int{250: 1},
+			boxes:     []entities.Box{large, small, medium},
+			wantBoxes: []string{"S"},
+			wantPacks: []map[int]int{{250: 1}},
+		},
+		{
+			name:      "Largest packs are placed first",
+			packs:     map[int]int{250: 2, 500: 1},
+			boxes:     []entities.Box{small, large},
+			wantBoxes: []string{"L"},
+			wantPacks: []map[int]int{{500: 1, 250: 2}},
+		},
+		{
+			name:    "No boxes",
+			packs:   map[int]int{250: 1},
+			boxes:   []entities.Box{},
+			wantErr: errors.ErrNoBoxes,
+		},
+		{
+			name:    "Invalid box",
+			packs:   map[int]int{250: 1},
+			boxes:   []entities.Box{{Name: "X", MaxWeight: 10}},
+			wantErr: errors.ErrInvalidBox,
+		},
+		{
+			name:    "Pack size without dimensions",
+			packs:   map[int]int{2000: 1},
+			boxes:   []entities.Box{large},
+			wantErr: errors.ErrMissingDimensions,
+		},
+		{
+			name:    "Pack does not fit any box",
+			packs:   map[int]int{500: 1},
+			boxes:   []entities.Box{small, medium},
+			wantErr: errors.ErrPackDoesNotFitBox,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assignments, err := service.Pack(tt.packs, packSizes, tt.boxes)
+
+			// Check error
+			if !stderr.Is(err, tt.wantErr) {
+				t.Errorf("Pack() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			// If expecting an error, don't check the result
+			if tt.wantErr != nil {
+				return
+			}
+
+			if len(assignments) != len(tt.wantBoxes) {
+				t.Fatalf("Pack() returned %d boxes, want %d", len(assignments), len(tt.wantBoxes))
+			}
+
+			for i, assignment := range assignments {
+				if assignment.Box.Name != tt.wantBoxes[i] {
+					t.Errorf("Pack() box %d = %v, want %v", i, assignment.Box.Name, tt.wantBoxes[i])
+				}
+				if !reflect.DeepEqual(assignment.Packs, tt.wantPacks[i]) {
+					t.Errorf("Pack() box %d packs = %v, want %v", i, assignment.Packs, tt.wantPacks[i])
+				}
+				if assignment.TotalWeight > assignment.Box.MaxWeight {
+					t.Errorf("Pack() box %d weight = %v, exceeds %v", i, assignment.TotalWeight, assignment.Box.MaxWeight)
+				}
+				assertPlacementsValid(t, assignment)
+			}
+		})
+	}
+}
+
+// assertPlacementsValid checks that every placement lies inside its box and no two overlap
+func assertPlacementsValid(t *testing.T, assignment entities.BoxAssignment) {
+	t.Helper()
+
+	inner := assignment.Box.Dimensions
+	for i, a := range assignment.Placements {
+		if a.Position.X < 0 || a.Position.Y < 0 || a.Position.Z < 0 ||
+			a.Position.X+a.Dimensions.Length > inner.Length ||
+			a.Position.Y+a.Dimensions.Width > inner.Width ||
+			a.Position.Z+a.Dimensions.Height > inner.Height {
+			t.Errorf("placement %d = %+v lies outside box %+v", i, a, inner)
+		}
+
+		for j := i + 1; j < len(assignment.Placements); j++ {
+			b := assignment.Placements[j]
+			if a.Position.X < b.Position.X+b.Dimensions.Length && b.Position.X < a.Position.X+a.Dimensions.Length &&
+				a.Position.Y < b.Position.Y+b.Dimensions.Width && b.Position.Y < a.Position.Y+a.Dimensions.Width &&
+				a.Position.Z < b.Position.Z+b.Dimensions.Height && b.Position.Z < a.Position.Z+a.Dimensions.Height {
+				t.Errorf("placements %d and %d overlap: %+v, %+v", i, j, a, b)
+			}
+		}
+	}
+}
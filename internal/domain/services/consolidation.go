@@ -0,0 +1,137 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+)
+
+// PackSolver calculates the optimal packing of a quantity
+type PackSolver func(ctx context.Context, itemsOrdered int) (map[int]int, error)
+
+// OrderConsolidationService packs several orders together and compares the
+// result with packing each order on its own
+type OrderConsolidationService struct{}
+
+// NewOrderConsolidationService creates a new order consolidation service
+func NewOrderConsolidationService() *OrderConsolidationService {
+	return &OrderConsolidationService{}
+}
+
+// Consolidate calculates the combined optimal packing for the orders, the
+// separate packing of every order and the savings between the two. Every
+// packing is calculated by the solver, which enforces the pack sizes and the
+// computation limits. The packs of the combined packing are allocated back to
+// the orders deterministically.
+func (s *OrderConsolidationService) Consolidate(ctx context.Context, orders []entities.Order, solve PackSolver) (*entities.ConsolidationResult, error) {
+	if len(orders) == 0 {
+		return nil, errors.ErrNoOrders
+	}
+
+	// Normalise order IDs, defaulting to the 1-based position of the order
+	normalized := make([]entities.Order, len(orders))
+	seen := make(map[string]struct{}, len(orders))
+	totalOrdered := 0
+
+	for i, order := range orders {
+		if order.ItemsOrdered <= 0 {
+			return nil, errors.ErrInvalidItemsOrdered
+		}
+
+		if order.ID == "" {
+			order.ID = strconv.Itoa(i + 1)
+		}
+		if _, exists := seen[order.ID]; exists {
+			return nil, errors.ErrDuplicateOrderID
+		}
+		seen[order.ID] = struct{}{}
+
+		// The combined order must fit in an int
+		if order.ItemsOrdered > math.MaxInt-totalOrdered {
+			return nil, &errors.ValidationError{
+				Field: "orders",
+				Err:   fmt.Errorf("%w: the orders total more than %d items", errors.ErrCalculationTooLarge, math.MaxInt),
+			}
+		}
+
+		normalized[i] = order
+		totalOrdered += order.ItemsOrdered
+	}
+
+	// Pack every order on its own
+	separate := make([]*entities.CalculationResult, len(normalized))
+	separateItems, separatePacks := 0, 0
+
+	for i, order := range normalized {
+		packs, err := solve(ctx, order.ItemsOrdered)
+		if err != nil {
+			return nil, err
+		}
+
+		separate[i] = entities.NewCalculationResult(order.ItemsOrdered, packs)
+		separateItems += separate[i].TotalItems
+		separatePacks += separate[i].TotalPacks()
+	}
+
+	// Pack all orders together
+	packs, err := solve(ctx, totalOrdered)
+	if err != nil {
+		return nil, err
+	}
+	combined := entities.NewCalculationResult(totalOrdered, packs)
+
+	return &entities.ConsolidationResult{
+		Orders:      normalized,
+		Combined:    combined,
+		Separate:    separate,
+		Allocations: allocatePacks(normalized, combined.Packs),
+		Savings: entities.ConsolidationSavings{
+			Items: separateItems - combined.TotalItems,
+			Packs: separatePacks - combined.TotalPacks(),
+		},
+	}, nil
+}
+
+// allocatePacks assigns every pack, largest first, to the order with the most
+// items still uncovered. Ties go to the order that comes first, so the same
+// input always yields the same allocation.
+func allocatePacks(orders []entities.Order, packs map[int]int) []entities.OrderAllocation {
+	sizes := make([]int, 0, len(packs))
+	for size := range packs {
+		sizes = append(sizes, size)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(sizes)))
+
+	allocations := make([]entities.OrderAllocation, len(orders))
+	remaining := make([]int, len(orders))
+	for i, order := range orders {
+		allocations[i] = entities.OrderAllocation{
+			OrderID:      order.ID,
+			ItemsOrdered: order.ItemsOrdered,
+			Packs:        make(map[int]int),
+		}
+		remaining[i] = order.ItemsOrdered
+	}
+
+	for _, size := range sizes {
+		for n := 0; n < packs[size]; n++ {
+			target := 0
+			for i := 1; i < len(remaining); i++ {
+				if remaining[i] > remaining[target] {
+					target = i
+				}
+			}
+
+			allocations[target].Packs[size]++
+			allocations[target].AllocatedItems += size
+			remaining[target] -= size
+		}
+	}
+
+	return allocations
+}
@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	stderr "errors"
+	"math"
+	"reflect"
+	"testing"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+)
+
+// calculatorSolver solves every quantity with the pack calculator and the given pack sizes
+func calculatorSolver(packSizes []int) PackSolver {
+	calculator := NewPackCalculatorService()
+	return func(ctx context.Context, itemsOrdered int) (map[int]int, error) {
+		return calculator.CalculateOptimalPacks(ctx, itemsOrdered, packSizes)
+	}
+}
+
+func TestOrderConsolidationService_Consolidate(t *testing.T) {
+	service := NewOrderConsolidationService()
+	defaultSizes := []int{250, 500, 1000, 2000, 5000}
+
+	tests := []struct {
+		name            string
+		orders          []entities.Order
+		packSizes       []int
+		wantCombined    map[int]int
+		wantSavings     entities.ConsolidationSavings
+		wantAllocations []map[int]int
+		wantErr         error
+	}{
+		{
+			name:            "Two single item orders share a pack",
+			orders:          []entities.Order{{ID: "a", ItemsOrdered: 1}, {ID: "b", ItemsOrdered: 1}},
+			packSizes:       defaultSizes,
+			wantCombined:    map[int]int{250: 1},
+			wantSavings:     entities.ConsolidationSavings{Items: 250, Packs: 1},
+			wantAllocations: []map[int]int{{250: 1}, {}},
+		},
+		{
+			name:            "Combined order saves items",
+			orders:          []entities.Order{{ID: "a", ItemsOrdered: 251}, {ID: "b", ItemsOrdered: 251}},
+			packSizes:       defaultSizes,
+			wantCombined:    map[int]int{500: 1, 250: 1},
+			wantSavings:     entities.ConsolidationSavings{Items: 250, Packs: 0},
+			wantAllocations: []map[int]int{{500: 1}, {250: 1}},
+		},
+		{
+			name:            "Larger order receives the largest pack",
+			orders:          []entities.Order{{ID: "a", ItemsOrdered: 250}, {ID: "b", ItemsOrdered: 1000}},
+			packSizes:       defaultSizes,
+			wantCombined:    map[int]int{1000: 1, 250: 1},
+			wantSavings:     entities.ConsolidationSavings{Items: 0, Packs: 0},
+			wantAllocations: []map[int]int{{250: 1}, {1000: 1}},
+		},
+		{
+			name:      "No orders",
+			orders:    []entities.Order{},
+			packSizes: defaultSizes,
+			wantErr:   errors.ErrNoOrders,
+		},
+		{
+			name:      "Invalid items ordered",
+			orders:    []entities.Order{{ID: "a", ItemsOrdered: 0}},
+			packSizes: defaultSizes,
+			wantErr:   errors.ErrInvalidItemsOrdered,
+		},
+		{
+			name:      "Duplicate order IDs",
+			orders:    []entities.Order{{ID: "a", ItemsOrdered: 1}, {ID: "a", ItemsOrdered: 2}},
+			packSizes: defaultSizes,
+			wantErr:   errors.ErrDuplicateOrderID,
+		},
+		{
+			name:      "No pack sizes",
+			orders:    []entities.Order{{ID: "a", ItemsOrdered: 1}},
+			packSizes: []int{},
+			wantErr:   errors.ErrNoPackSizesAvailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.Consolidate(context.Background(), tt.orders, calculatorSolver(tt.packSizes))
+
+			// Check error
+			if err != tt.wantErr {
+				t.Errorf("Consolidate() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			// If expecting an error, don't check the result
+			if tt.wantErr != nil {
+				return
+			}
+
+			if !reflect.DeepEqual(result.Combined.Packs, tt.wantCombined) {
+				t.Errorf("Consolidate() combined = %v, want %v", result.Combined.Packs, tt.wantCombined)
+			}
+
+			if result.Savings != tt.wantSavings {
+				t.Errorf("Consolidate() savings = %+v, want %+v", result.Savings, tt.wantSavings)
+			}
+
+			if len(result.Separate) != len(tt.orders) {
+				t.Fatalf("Consolidate() returned %d separate results, want %d", len(result.Separate), len(tt.orders))
+			}
+
+			for i, allocation := range result.Allocations {
+				if allocation.OrderID != tt.orders[i].ID {
+					t.Errorf("Consolidate() allocation %d order = %v, want %v", i, allocation.OrderID, tt.orders[i].ID)
+				}
+				if !reflect.DeepEqual(allocation.Packs, tt.wantAllocations[i]) {
+					t.Errorf("Consolidate() allocation %d packs = %v, want %v", i, allocation.Packs, tt.wantAllocations[i])
+				}
+			}
+		})
+	}
+}
+
+func TestOrderConsolidationService_DefaultOrderIDs(t *testing.T) {
+	service := NewOrderConsolidationService()
+
+	result, err := service.Consolidate(context.Background(),
+		[]entities.Order{{ItemsOrdered: 100}, {ItemsOrdered: 200}},
+		calculatorSolver([]int{250, 500}),
+	)
+	if err != nil {
+		t.Fatalf("Consolidate() unexpected error = %v", err)
+	}
+
+	if result.Orders[0].ID != "1" || result.Orders[1].ID != "2" {
+		t.Errorf("Consolidate() order IDs = %v, %v, want 1, 2", result.Orders[0].ID, result.Orders[1].ID)
+	}
+}
+
+func TestOrderConsolidationService_TotalOverflow(t *testing.T) {
+	service := NewOrderConsolidationService()
+
+	solved := 0
+	solve := func(ctx context.Context, itemsOrdered int) (map[int]int, error) {
+		solved++
+		return map[int]int{itemsOrdered: 1}, nil
+	}
+
+	_, err := service.Consolidate(context.Background(),
+		[]entities.Order{{ItemsOrdered: math.MaxInt}, {ItemsOrdered: 1}},
+		solve,
+	)
+
+	var validationErr *errors.ValidationError
+	if !stderr.As(err, &validationErr) || !stderr.Is(err, errors.ErrCalculationTooLarge) {
+		t.Errorf("Consolidate() error = %v, want a validation error for a too large calculation", err)
+	}
+	if solved != 0 {
+		t.Errorf("Consolidate() solved %d packings before rejecting the orders, want 0", solved)
+	}
+}
@@ -0,0 +1,121 @@
+package services
+
+import (
+	"time"
+
+	"go-pack-calculator/internal/domain/entities"
+)
+
+// Measured costs of the solvers, rounded up
+const (
+	searchBytesPerState = 320
+	searchStepDuration  = 350 * time.Nanosecond
+	costBytesPerState   = 24
+	costStepDuration    = 10 * time.Nanosecond
+	tableBytesPerTotal  = 16
+	tableTotalDuration  = 15 * time.Nanosecond
+	tableBytesPerCount  = 4
+	tableStepDuration   = 10 * time.Nanosecond
+)
+
+// EstimateOptimalPacks estimates the memory and time CalculateOptimalPacks needs. The search
+// only reaches totals that are multiples of the greatest common divisor of the pack sizes.
+func (s *PackCalculatorService) EstimateOptimalPacks(itemsOrdered int, packSizes []int) entities.CalculationEstimate {
+	maxSize, divisor := packSizeBounds(packSizes)
+	if divisor == 0 {
+		return entities.CalculationEstimate{}
+	}
+
+	states := (int64(itemsOrdered) + int64(maxSize)) / int64(divisor)
+
+	return newEstimate(states, len(packSizes), searchBytesPerState, searchStepDuration)
+}
+
+// EstimateOptimalPacksByCost estimates the memory and time CalculateOptimalPacksByCost needs
+func (s *PackCalculatorService) EstimateOptimalPacksByCost(itemsOrdered int, packSizes []int) entities.CalculationEstimate {
+	maxSize, _ := packSizeBounds(packSizes)
+	states := int64(itemsOrdered) + int64(maxSize)
+
+	return newEstimate(states, len(packSizes), costBytesPerState, costStepDuration)
+}
+
+// EstimatePackingTable estimates the memory and time BuildPackingTable needs. The builder scans
+// every total up to the ceiling plus the largest size, and stores a row of a count per pack size
+// for every reachable total, a multiple of the greatest common divisor of the pack sizes.
+func (s *PackCalculatorService) EstimatePackingTable(ceiling int, packSizes []int) entities.CalculationEstimate {
+	maxSize, divisor := packSizeBounds(packSizes)
+	if divisor == 0 {
+		return entities.CalculationEstimate{}
+	}
+
+	totals := int64(ceiling) + int64(maxSize)
+	states := totals / int64(divisor)
+	steps := states * int64(len(entities.DistinctPackSizes(packSizes)))
+
+	return entities.CalculationEstimate{
+		States:      states,
+		Steps:       steps,
+		MemoryBytes: totals*tableBytesPerTotal + steps*tableBytesPerCount,
+		Duration:    time.Duration(totals)*tableTotalDuration + time.Duration(steps)*tableStepDuration,
+	}
+}
+
+// ReduceLargeOrder splits a large order into a number of largest packs that every packing with
+// the fewest items and packs contains, and the remaining items still to be calculated.
+//
+// Such a packing holds fewer non-largest packs than the largest size L: among L of them some
+// subset sums to a multiple of L and could be swapped for fewer largest packs. The non-largest
+// packs therefore hold fewer than L² items, so an order of n > L² items contains more than
+// (n - L²) / L largest packs. Adding those packs to the optimal packing of the remaining items
+// gives an optimal packing of the order.
+func (s *PackCalculatorService) ReduceLargeOrder(itemsOrdered int, packSizes []int) (remaining, largestPacks int) {
+	maxSize, _ := packSizeBounds(packSizes)
+	if maxSize == 0 {
+		return itemsOrdered, 0
+	}
+
+	square := int64(maxSize) * int64(maxSize)
+	if int64(itemsOrdered) <= square {
+		return itemsOrdered, 0
+	}
+
+	largestPacks = int((int64(itemsOrdered) - square) / int64(maxSize))
+
+	return itemsOrdered - largestPacks*maxSize, largestPacks
+}
+
+// packSizeBounds returns the largest pack size and the greatest common divisor of the pack sizes
+func packSizeBounds(packSizes []int) (maxSize, divisor int) {
+	for _, size := range packSizes {
+		if size <= 0 {
+			continue
+		}
+		if size > maxSize {
+			maxSize = size
+		}
+		divisor = gcd(divisor, size)
+	}
+
+	return maxSize, divisor
+}
+
+// gcd returns the greatest common divisor of two non-negative numbers
+func gcd(a, b int) int {
+	for b != 0 {
+		a, b = b, a%b
+	}
+
+	return a
+}
+
+// newEstimate builds an estimate from the number of states and the measured cost per state
+func newEstimate(states int64, sizes int, bytesPerState int64, stepDuration time.Duration) entities.CalculationEstimate {
+	steps := states * int64(sizes)
+
+	return entities.CalculationEstimate{
+		States:      states,
+		Steps:       steps,
+		MemoryBytes: states * bytesPerState,
+		Duration:    time.Duration(steps) * stepDuration,
+	}
+}
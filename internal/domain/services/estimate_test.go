@@ -0,0 +1,114 @@
+package services
+
+import (
+	"context"
+	"testing"
+)
+
+func TestPackCalculatorService_EstimateOptimalPacks(t *testing.T) {
+	service := NewPackCalculatorService()
+
+	// Only multiples of 250 are reachable with these sizes
+	coarse := service.EstimateOptimalPacks(1000000, []int{250, 500, 1000})
+	if coarse.States != 4004 {
+		t.Errorf("EstimateOptimalPacks() states = %d, want %d", coarse.States, 4004)
+	}
+	if coarse.Steps != 3*coarse.States {
+		t.Errorf("EstimateOptimalPacks() steps = %d, want %d", coarse.Steps, 3*coarse.States)
+	}
+
+	fine := service.EstimateOptimalPacks(1000000, []int{23, 31, 53})
+	if fine.MemoryBytes <= coarse.MemoryBytes || fine.Duration <= coarse.Duration {
+		t.Errorf("EstimateOptimalPacks() = %+v, want more than %+v", fine, coarse)
+	}
+
+	// The packing table spans every total, whichever are reachable
+	table := service.EstimatePackingTable(1000000, []int{250, 500, 1000})
+	if table.MemoryBytes <= coarse.MemoryBytes {
+		t.Errorf("EstimatePackingTable() = %+v, want more than %+v", table, coarse)
+	}
+	built, err := service.BuildPackingTable(context.Background(), 1000000, []int{250, 500, 1000})
+	if err != nil {
+		t.Fatalf("BuildPackingTable() error = %v", err)
+	}
+	if built.MemoryBytes() > table.MemoryBytes {
+		t.Errorf("EstimatePackingTable() memory = %d, the table holds %d", table.MemoryBytes, built.MemoryBytes())
+	}
+
+	byCost := service.EstimateOptimalPacksByCost(1000000, []int{250, 500, 1000})
+	if byCost.States != 1001000 {
+		t.Errorf("EstimateOptimalPacksByCost() states = %d, want %d", byCost.States, 1001000)
+	}
+}
+
+func TestPackCalculatorService_ReduceLargeOrder(t *testing.T) {
+	service := NewPackCalculatorService()
+
+	tests := []struct {
+		name             string
+		itemsOrdered     int
+		packSizes        []int
+		wantRemaining    int
+		wantLargestPacks int
+	}{
+		{
+			name:             "Small order is not reduced",
+			itemsOrdered:     2500,
+			packSizes:        []int{23, 31, 53},
+			wantRemaining:    2500,
+			wantLargestPacks: 0,
+		},
+		{
+			name:             "Large order is reduced",
+			itemsOrdered:     500000,
+			packSizes:        []int{23, 31, 53},
+			wantRemaining:    2809 + 500000%53,
+			wantLargestPacks: (500000 - 2809) / 53,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			remaining, largestPacks := service.ReduceLargeOrder(tt.itemsOrdered, tt.packSizes)
+			if remaining != tt.wantRemaining || largestPacks != tt.wantLargestPacks {
+				t.Errorf("ReduceLargeOrder() = %d, %d, want %d, %d", remaining, largestPacks, tt.wantRemaining, tt.wantLargestPacks)
+			}
+		})
+	}
+}
+
+func TestPackCalculatorService_ReduceLargeOrderIsExact(t *testing.T) {
+	service := NewPackCalculatorService()
+	packSizes := []int{6, 9, 20}
+
+	for itemsOrdered := 1; itemsOrdered <= 1000; itemsOrdered++ {
+		full, err := service.CalculateOptimalPacks(context.Background(), itemsOrdered, append([]int(nil), packSizes...))
+		if err != nil {
+			t.Fatalf("CalculateOptimalPacks(%d) unexpected error = %v", itemsOrdered, err)
+		}
+
+		remaining, largestPacks := service.ReduceLargeOrder(itemsOrdered, packSizes)
+		reduced, err := service.CalculateOptimalPacks(context.Background(), remaining, append([]int(nil), packSizes...))
+		if err != nil {
+			t.Fatalf("CalculateOptimalPacks(%d) unexpected error = %v", remaining, err)
+		}
+		reduced[20] += largestPacks
+
+		fullItems, fullPacks := packTotals(full)
+		reducedItems, reducedPacks := packTotals(reduced)
+		if fullItems != reducedItems || fullPacks != reducedPacks {
+			t.Fatalf("order %d: reduced packing ships %d items in %d packs, want %d in %d",
+				itemsOrdered, reducedItems, reducedPacks, fullItems, fullPacks)
+		}
+	}
+}
+
+// packTotals returns the number of items and packs of a packing
+func packTotals(packs map[int]int) (items, count int) {
+	for size, quantity := range packs {
+		items += size * quantity
+		count += quantity
+	}
+
+	return items, count
+}
@@ -1,18 +1,24 @@
 package services
 
 import (
+	"context"
 	"sort"
 
 	"go-pack-calculator/internal/domain/errors"
 )
 
+// cancellationCheckInterval is the number of solver steps between checks of the context
+const cancellationCheckInterval = 1024
+
 type PackCalculatorService struct{}
 
 func NewPackCalculatorService() *PackCalculatorService {
 	return &PackCalculatorService{}
 }
 
-func (s *PackCalculatorService) CalculateOptimalPacks(itemsOrdered int, packSizes []int) (map[int]int, error) {
+// CalculateOptimalPacks calculates the packing that ships the fewest items and, among those, uses
+// the fewest packs. The pack sizes are not modified and the same input always yields the same packing.
+func (s *PackCalculatorService) CalculateOptimalPacks(ctx context.Context, itemsOrdered int, packSizes []int) (map[int]int, error) {
 	if itemsOrdered <= 0 {
 		return nil, errors.ErrInvalidItemsOrdered
 	}
@@ -20,7 +26,8 @@ func (s *PackCalculatorService) CalculateOptimalPacks(itemsOrdered int, packSize
 		return nil, errors.ErrNoPackSizesAvailable
 	}
 
-	// Sort pack sizes in descending order
+	// Sort a copy of the pack sizes in descending order, leaving the caller's slice untouched
+	packSizes = append([]int(nil), packSizes...)
 	sort.Sort(sort.Reverse(sort.IntSlice(packSizes)))
 
 	// Define a state structure for our dynamic programming approach
@@ -41,7 +48,14 @@ func (s *PackCalculatorService) CalculateOptimalPacks(itemsOrdered int, packSize
 	visited[0] = true
 
 	// BFS to find all possible combinations
-	for len(queue) > 0 {
+	for step := 0; len(queue) > 0; step++ {
+		// Stop when the caller is no longer waiting for the result
+		if step%cancellationCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
 		current := queue[0]
 		queue = queue[1:]
 
@@ -138,3 +152,106 @@ func (s *PackCalculatorService) CalculateOptimalPacks(itemsOrdered int, packSize
 
 	return result, nil
 }
+
+// PackCostFunc returns the primary and secondary cost of shipping one pack of the given size
+type PackCostFunc func(size int) (primary, secondary float64)
+
+// costTolerance is the difference below which two summed costs are considered equal
+const costTolerance = 1e-9
+
+// CalculateOptimalPacksByCost calculates the packing that ships the fewest items (rule 2)
+// and, among those, has the lowest summed primary cost, breaking ties by the lowest
+// summed secondary cost. The pack sizes are not modified.
+func (s *PackCalculatorService) CalculateOptimalPacksByCost(
+	ctx context.Context,
+	itemsOrdered int,
+	packSizes []int,
+	cost PackCostFunc,
+) (map[int]int, error) {
+	if itemsOrdered <= 0 {
+		return nil, errors.ErrInvalidItemsOrdered
+	}
+	if len(packSizes) == 0 {
+		return nil, errors.ErrNoPackSizesAvailable
+	}
+
+	maxSize := 0
+	for _, size := range packSizes {
+		if size <= 0 {
+			return nil, errors.ErrInvalidPackSize
+		}
+		if size > maxSize {
+			maxSize = size
+		}
+	}
+
+	// The smallest reachable total that covers the order is always below itemsOrdered + maxSize,
+	// otherwise removing any pack would still cover the order
+	limit := itemsOrdered + maxSize
+
+	type state struct {
+		primary   float64
+		secondary float64
+		used      int
+	}
+
+	better := func(a, b state) bool {
+		if a.primary < b.primary-costTolerance {
+			return true
+		}
+		if a.primary > b.primary+costTolerance {
+			return false
+		}
+
+		return a.secondary < b.secondary-costTolerance
+	}
+
+	// dp[total] holds the cheapest way to reach exactly total items, used == 0 means unreachable
+	dp := make([]state, limit)
+	for total := 1; total < limit; total++ {
+		// Stop when the caller is no longer waiting for the result
+		if total%cancellationCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		for _, size := range packSizes {
+			if size > total {
+				continue
+			}
+
+			prev := total - size
+			if prev > 0 && dp[prev].used == 0 {
+				continue
+			}
+
+			primary, secondary := cost(size)
+			candidate := state{
+				primary:   dp[prev].primary + primary,
+				secondary: dp[prev].secondary + secondary,
+				used:      size,
+			}
+			if dp[total].used == 0 || better(candidate, dp[total]) {
+				dp[total] = candidate
+			}
+		}
+	}
+
+	// The first reachable total that covers the order ships the fewest items
+	for total := itemsOrdered; total < limit; total++ {
+		if dp[total].used == 0 {
+			continue
+		}
+
+		// Backtrack to build the result
+		result := make(map[int]int)
+		for current := total; current > 0; current -= dp[current].used {
+			result[dp[current].used]++
+		}
+
+		return result, nil
+	}
+
+	return nil, errors.ErrInvalidPackSize
+}
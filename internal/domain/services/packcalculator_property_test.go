@@ -0,0 +1,242 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"testing/quick"
+)
+
+// oracleMaxItems bounds the orders checked against the exhaustive oracle, which enumerates
+// every packing and slows down quickly as orders grow
+const oracleMaxItems = 300
+
+// oracleOptimum enumerates every combination of packs shipping fewer than itemsOrdered plus
+// the largest pack size items and returns the smallest total covering the order and the
+// fewest packs reaching that total. It shares no code with the solvers.
+func oracleOptimum(itemsOrdered int, packSizes []int) (bestTotal, bestPacks int) {
+	limit := itemsOrdered
+	for _, size := range packSizes {
+		limit = max(limit, itemsOrdered+size)
+	}
+
+	bestTotal, bestPacks = -1, -1
+	var enumerate func(i, total, packs int)
+	enumerate = func(i, total, packs int) {
+		if i == len(packSizes) {
+			if total < itemsOrdered {
+				return
+			}
+			if bestTotal == -1 || total < bestTotal || (total == bestTotal && packs < bestPacks) {
+				bestTotal, bestPacks = total, packs
+			}
+			return
+		}
+
+		for quantity := 0; total+quantity*packSizes[i] < limit; quantity++ {
+			enumerate(i+1, total+quantity*packSizes[i], packs+quantity)
+		}
+	}
+	enumerate(0, 0, 0)
+
+	return bestTotal, bestPacks
+}
+
+// checkOptimalPacks checks the solver result for an order against the oracle and the rules
+// every packing must follow, returning a description of the first violation
+func checkOptimalPacks(service *PackCalculatorService, itemsOrdered int, packSizes []int) error {
+	original := append([]int(nil), packSizes...)
+	packs, err := service.CalculateOptimalPacks(context.Background(), itemsOrdered, packSizes)
+	if err != nil {
+		return fmt.Errorf("order %d with %v: unexpected error %w", itemsOrdered, original, err)
+	}
+
+	// The input slice is not mutated
+	if !reflect.DeepEqual(packSizes, original) {
+		return fmt.Errorf("order %d with %v: pack sizes reordered to %v", itemsOrdered, original, packSizes)
+	}
+
+	// Only whole packs of the given sizes are used
+	allowed := make(map[int]bool, len(packSizes))
+	for _, size := range packSizes {
+		allowed[size] = true
+	}
+	for size, quantity := range packs {
+		if !allowed[size] || quantity <= 0 {
+			return fmt.Errorf("order %d with %v: invalid packing %v", itemsOrdered, packSizes, packs)
+		}
+	}
+
+	items, count := packTotals(packs)
+	wantItems, wantPacks := oracleOptimum(itemsOrdered, packSizes)
+
+	switch {
+	case items < itemsOrdered:
+		return fmt.Errorf("order %d with %v: packing %v ships only %d items", itemsOrdered, packSizes, packs, items)
+	case items != wantItems:
+		return fmt.Errorf("order %d with %v: packing %v ships %d items, %d is reachable", itemsOrdered, packSizes, packs, items, wantItems)
+	case count != wantPacks:
+		return fmt.Errorf("order %d with %v: packing %v uses %d packs, %d is possible", itemsOrdered, packSizes, packs, count, wantPacks)
+	}
+
+	return nil
+}
+
+// packInput maps arbitrary generated values onto a small order and a set of pack sizes the
+// oracle can enumerate
+func packInput(order uint16, sizes []uint8) (int, []int) {
+	itemsOrdered := int(order)%oracleMaxItems + 1
+
+	packSizes := make([]int, 0, 3)
+	for _, size := range sizes {
+		if len(packSizes) == cap(packSizes) {
+			break
+		}
+		packSizes = append(packSizes, int(size)%60+1)
+	}
+	if len(packSizes) == 0 {
+		packSizes = append(packSizes, 1)
+	}
+
+	return itemsOrdered, packSizes
+}
+
+func TestPackCalculatorService_CalculateOptimalPacksMatchesOracle(t *testing.T) {
+	service := NewPackCalculatorService()
+
+	tests := []struct {
+		name      string
+		packSizes []int
+	}{
+		{
+			name:      "Standard pack sizes scaled down",
+			packSizes: []int{5, 10, 20, 50},
+		},
+		{
+			name:      "Chicken nuggets",
+			packSizes: []int{6, 9, 20},
+		},
+		{
+			name:      "Coprime pack sizes",
+			packSizes: []int{23, 31, 53},
+		},
+		{
+			name:      "Duplicate pack sizes",
+			packSizes: []int{7, 3, 7},
+		},
+		{
+			name:      "Single pack size",
+			packSizes: []int{13},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for itemsOrdered := 1; itemsOrdered <= oracleMaxItems; itemsOrdered++ {
+				if err := checkOptimalPacks(service, itemsOrdered, tt.packSizes); err != nil {
+					t.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestPackCalculatorService_CalculateOptimalPacksProperties(t *testing.T) {
+	service := NewPackCalculatorService()
+
+	property := func(order uint16, sizes []uint8) bool {
+		itemsOrdered, packSizes := packInput(order, sizes)
+		if err := checkOptimalPacks(service, itemsOrdered, packSizes); err != nil {
+			t.Log(err)
+			return false
+		}
+
+		return true
+	}
+
+	if err := quick.Check(property, &quick.Config{MaxCount: 500}); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestPackCalculatorService_InputNotMutated(t *testing.T) {
+	service := NewPackCalculatorService()
+	unitCost := func(size int) (float64, float64) { return 1, 1 }
+
+	tests := []struct {
+		name string
+		call func(packSizes []int)
+	}{
+		{
+			name: "CalculateOptimalPacks",
+			call: func(packSizes []int) { _, _ = service.CalculateOptimalPacks(context.Background(), 751, packSizes) },
+		},
+		{
+			name: "CalculateOptimalPacksByCost",
+			call: func(packSizes []int) {
+				_, _ = service.CalculateOptimalPacksByCost(context.Background(), 751, packSizes, unitCost)
+			},
+		},
+		{
+			name: "BuildPackingTable",
+			call: func(packSizes []int) { _, _ = service.BuildPackingTable(context.Background(), 1000, packSizes) },
+		},
+		{
+			name: "EstimateOptimalPacks",
+			call: func(packSizes []int) { _ = service.EstimateOptimalPacks(751, packSizes) },
+		},
+		{
+			name: "ReduceLargeOrder",
+			call: func(packSizes []int) { _, _ = service.ReduceLargeOrder(1000000, packSizes) },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			packSizes := []int{100, 500, 250, 100}
+			tt.call(packSizes)
+
+			if want := []int{100, 500, 250, 100}; !reflect.DeepEqual(packSizes, want) {
+				t.Errorf("%s() reordered the pack sizes to %v, want %v", tt.name, packSizes, want)
+			}
+		})
+	}
+}
+
+func TestPackCalculatorService_CalculateOptimalPacksIsDeterministic(t *testing.T) {
+	service := NewPackCalculatorService()
+
+	// Packings of 12 with sizes 1, 2, 3, 6 and 9 tie on items and packs, the order of the sizes must not matter
+	want, err := service.CalculateOptimalPacks(context.Background(), 12, []int{1, 2, 3, 6, 9})
+	if err != nil {
+		t.Fatalf("CalculateOptimalPacks() error = %v", err)
+	}
+
+	for _, packSizes := range [][]int{{9, 6, 3, 2, 1}, {3, 9, 1, 6, 2}, {6, 1, 9, 2, 3}} {
+		got, err := service.CalculateOptimalPacks(context.Background(), 12, packSizes)
+		if err != nil {
+			t.Fatalf("CalculateOptimalPacks() error = %v", err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("CalculateOptimalPacks() with %v = %v, want %v", packSizes, got, want)
+		}
+	}
+}
+
+func FuzzCalculateOptimalPacks(f *testing.F) {
+	service := NewPackCalculatorService()
+
+	f.Add(uint16(250), []byte{25, 50, 100})
+	f.Add(uint16(251), []byte{50, 25})
+	f.Add(uint16(107), []byte{23, 31, 53})
+	f.Add(uint16(43), []byte{6, 9, 20})
+	f.Add(uint16(1), []byte{1})
+
+	f.Fuzz(func(t *testing.T, order uint16, sizes []byte) {
+		itemsOrdered, packSizes := packInput(order, sizes)
+		if err := checkOptimalPacks(service, itemsOrdered, packSizes); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
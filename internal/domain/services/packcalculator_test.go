@@ -1,6 +1,7 @@
 package services
 
 import (
+	"context"
 	"reflect"
 	"testing"
 
@@ -98,7 +99,7 @@ func TestPackCalculatorService_CalculateOptimalPacks(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			result, err := service.CalculateOptimalPacks(tt.itemsOrdered, tt.packSizes)
+			result, err := service.CalculateOptimalPacks(context.Background(), tt.itemsOrdered, tt.packSizes)
 
 			// Check error
 			if err != tt.wantErr {
@@ -129,3 +130,104 @@ func TestPackCalculatorService_CalculateOptimalPacks(t *testing.T) {
 		})
 	}
 }
+
+func TestPackCalculatorService_CalculateOptimalPacksByCost(t *testing.T) {
+	service := NewPackCalculatorService()
+
+	// Every pack counts as one, so the result matches the default calculation
+	fewestPacks := func(size int) (float64, float64) { return 1, 0 }
+
+	// 400 is covered by two packs as 200+200 or 300+100, ties are broken by weight
+	weights := map[int]float64{100: 1, 200: 3, 300: 1, 500: 10}
+	fewestPacksThenLightest := func(size int) (float64, float64) { return 1, weights[size] }
+
+	tests := []struct {
+		name         string
+		itemsOrdered int
+		packSizes    []int
+		cost         PackCostFunc
+		wantPacks    map[int]int
+		wantErr      error
+	}{
+		{
+			name:         "Same as default calculation",
+			itemsOrdered: 751,
+			packSizes:    []int{500, 250, 100},
+			cost:         fewestPacks,
+			wantPacks:    map[int]int{500: 1, 100: 3},
+		},
+		{
+			name:         "Unit pack available",
+			itemsOrdered: 123,
+			packSizes:    []int{100, 10, 1},
+			cost:         fewestPacks,
+			wantPacks:    map[int]int{100: 1, 10: 2, 1: 3},
+		},
+		{
+			name:         "Tie broken by secondary cost",
+			itemsOrdered: 400,
+			packSizes:    []int{100, 200, 300},
+			cost:         fewestPacksThenLightest,
+			wantPacks:    map[int]int{300: 1, 100: 1},
+		},
+		{
+			name:         "Primary cost preferred over pack count",
+			itemsOrdered: 500,
+			packSizes:    []int{100, 500},
+			cost:         func(size int) (float64, float64) { return weights[size], 1 },
+			wantPacks:    map[int]int{100: 5},
+		},
+		{
+			name:         "Invalid items ordered",
+			itemsOrdered: 0,
+			packSizes:    []int{500, 250},
+			cost:         fewestPacks,
+			wantErr:      errors.ErrInvalidItemsOrdered,
+		},
+		{
+			name:         "No pack sizes",
+			itemsOrdered: 100,
+			packSizes:    []int{},
+			cost:         fewestPacks,
+			wantErr:      errors.ErrNoPackSizesAvailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := service.CalculateOptimalPacksByCost(context.Background(), tt.itemsOrdered, tt.packSizes, tt.cost)
+
+			// Check error
+			if err != tt.wantErr {
+				t.Errorf("CalculateOptimalPacksByCost() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+
+			// If expecting an error, don't check the result
+			if tt.wantErr != nil {
+				return
+			}
+
+			// Check result
+			if !reflect.DeepEqual(result, tt.wantPacks) {
+				t.Errorf("CalculateOptimalPacksByCost() = %v, want %v", result, tt.wantPacks)
+			}
+		})
+	}
+}
+
+func TestPackCalculatorService_Cancellation(t *testing.T) {
+	service := NewPackCalculatorService()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	if _, err := service.CalculateOptimalPacks(ctx, 500000, []int{23, 31, 53}); err != context.Canceled {
+		t.Errorf("CalculateOptimalPacks() error = %v, want %v", err, context.Canceled)
+	}
+
+	cost := func(size int) (float64, float64) { return 1, 0 }
+	if _, err := service.CalculateOptimalPacksByCost(ctx, 500000, []int{23, 31, 53}, cost); err != context.Canceled {
+		t.Errorf("CalculateOptimalPacksByCost() error = %v, want %v", err, context.Canceled)
+	}
+}
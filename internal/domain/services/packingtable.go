@@ -0,0 +1,89 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"go-pack-calculator/internal/domain/entities"
+	"go-pack-calculator/internal/domain/errors"
+)
+
+// BuildPackingTable calculates the optimal packing of every quantity up to the ceiling in a
+// single pass. It explores the totals in the same order as CalculateOptimalPacks, so every
+// quantity gets the packing CalculateOptimalPacks returns for it, ties included.
+func (s *PackCalculatorService) BuildPackingTable(ctx context.Context, ceiling int, packSizes []int) (*entities.PackingTable, error) {
+	if ceiling <= 0 {
+		return nil, errors.ErrInvalidItemsOrdered
+	}
+	if len(packSizes) == 0 {
+		return nil, errors.ErrNoPackSizesAvailable
+	}
+
+	sizes := entities.DistinctPackSizes(packSizes)
+	if sizes[len(sizes)-1] <= 0 {
+		return nil, errors.ErrInvalidPackSize
+	}
+
+	// The smallest reachable total covering a quantity is below the quantity plus the largest size
+	limit := ceiling + sizes[0]
+
+	// used[total] is one more than the index of the last pack size on the way to total, 0 when
+	// total is unreachable. Totals are reached breadth first, so the first way found has the
+	// fewest packs and is kept.
+	used := make([]int32, limit)
+	queue := make([]int32, 1, limit)
+	for step, head := 0, 0; head < len(queue); step, head = step+1, head+1 {
+		// Stop when the caller is no longer waiting for the table
+		if step%cancellationCheckInterval == 0 {
+			if err := ctx.Err(); err != nil {
+				return nil, err
+			}
+		}
+
+		current := int(queue[head])
+		for i, size := range sizes {
+			next := current + size
+			if next >= limit || used[next] != 0 {
+				continue
+			}
+
+			used[next] = int32(i + 1)
+			queue = append(queue, int32(next))
+		}
+	}
+
+	// Give every reachable total a row, built from the row of the total before its last pack
+	rowOf := make([]int32, limit)
+	rows := make([]int32, len(sizes), len(queue)*len(sizes))
+	for total := 1; total < limit; total++ {
+		if used[total] == 0 {
+			continue
+		}
+
+		last := int(used[total] - 1)
+		prev := int(rowOf[total-sizes[last]]) * len(sizes)
+		rowOf[total] = int32(len(rows) / len(sizes))
+		rows = append(rows, rows[prev:prev+len(sizes)]...)
+		rows[len(rows)-len(sizes)+last]++
+	}
+
+	// Point every quantity at the row of the smallest reachable total covering it
+	index := make([]int32, ceiling+1)
+	next := 0
+	for total := limit - 1; total > 0; total-- {
+		if used[total] != 0 {
+			next = total
+		}
+		if total <= ceiling {
+			index[total] = rowOf[next]
+		}
+	}
+
+	return &entities.PackingTable{
+		Sizes:     sizes,
+		Ceiling:   ceiling,
+		Index:     index,
+		Rows:      rows,
+		CreatedAt: time.Now(),
+	}, nil
+}
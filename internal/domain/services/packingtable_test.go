@@ -0,0 +1,121 @@
+package services
+
+import (
+	"context"
+	stderr "errors"
+	"reflect"
+	"testing"
+
+	"go-pack-calculator/internal/domain/errors"
+)
+
+func TestPackCalculatorService_BuildPackingTable(t *testing.T) {
+	service := NewPackCalculatorService()
+
+	tests := []struct {
+		name      string
+		ceiling   int
+		packSizes []int
+	}{
+		{
+			name:      "Standard pack sizes",
+			ceiling:   12001,
+			packSizes: []int{250, 500, 1000, 2000, 5000},
+		},
+		{
+			name:      "Coprime pack sizes",
+			ceiling:   2000,
+			packSizes: []int{23, 31, 53},
+		},
+		{
+			name:      "Ties between packings",
+			ceiling:   500,
+			packSizes: []int{1, 2, 3, 6, 9, 20},
+		},
+		{
+			name:      "Duplicate pack sizes",
+			ceiling:   500,
+			packSizes: []int{6, 9, 20, 9},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			table, err := service.BuildPackingTable(context.Background(), tt.ceiling, tt.packSizes)
+			if err != nil {
+				t.Fatalf("BuildPackingTable() error = %v", err)
+			}
+
+			// Every quantity gets the packing of the solver
+			for itemsOrdered := 1; itemsOrdered <= tt.ceiling; itemsOrdered++ {
+				sizes := append([]int(nil), tt.packSizes...)
+				want, err := service.CalculateOptimalPacks(context.Background(), itemsOrdered, sizes)
+				if err != nil {
+					t.Fatalf("CalculateOptimalPacks(%d) error = %v", itemsOrdered, err)
+				}
+
+				got, ok := table.Lookup(itemsOrdered)
+				if !ok || !reflect.DeepEqual(got, want) {
+					t.Fatalf("Lookup(%d) = %v, want %v", itemsOrdered, got, want)
+				}
+			}
+
+			if _, ok := table.Lookup(tt.ceiling + 1); ok {
+				t.Errorf("Lookup() beyond the ceiling returned a packing")
+			}
+		})
+	}
+}
+
+func TestPackCalculatorService_BuildPackingTableErrors(t *testing.T) {
+	service := NewPackCalculatorService()
+
+	canceled, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		ceiling   int
+		packSizes []int
+		wantErr   error
+	}{
+		{
+			name:      "Invalid ceiling",
+			ctx:       context.Background(),
+			ceiling:   0,
+			packSizes: []int{250},
+			wantErr:   errors.ErrInvalidItemsOrdered,
+		},
+		{
+			name:      "No pack sizes",
+			ctx:       context.Background(),
+			ceiling:   100,
+			packSizes: []int{},
+			wantErr:   errors.ErrNoPackSizesAvailable,
+		},
+		{
+			name:      "Invalid pack size",
+			ctx:       context.Background(),
+			ceiling:   100,
+			packSizes: []int{250, 0},
+			wantErr:   errors.ErrInvalidPackSize,
+		},
+		{
+			name:      "Canceled",
+			ctx:       canceled,
+			ceiling:   100,
+			packSizes: []int{250},
+			wantErr:   context.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := service.BuildPackingTable(tt.ctx, tt.ceiling, tt.packSizes)
+			if !stderr.Is(err, tt.wantErr) {
+				t.Errorf("BuildPackingTable() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
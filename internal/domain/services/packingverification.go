@@ -0,0 +1,84 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"go-pack-calculator/internal/domain/entities"
+)
+
+// VerifyPacking checks a proposed packing of an order against the business rules, comparing it
+// with the optimal packing. Rules 2 and 3 only apply to packings following rule 1, and rule 3
+// only to packings shipping as few items as the optimal packing.
+func (s *PackCalculatorService) VerifyPacking(
+	itemsOrdered int,
+	proposed map[int]float64,
+	packSizes []int,
+	optimal map[int]int,
+) *entities.PackingVerification {
+	verification := &entities.PackingVerification{
+		ItemsOrdered: itemsOrdered,
+		Optimal:      entities.NewCalculationResult(itemsOrdered, optimal),
+	}
+
+	configured := make(map[int]bool, len(packSizes))
+	for _, size := range packSizes {
+		configured[size] = true
+	}
+
+	// Rule 1: only whole packs of the configured sizes, reported in pack size order
+	sizes := make([]int, 0, len(proposed))
+	for size := range proposed {
+		sizes = append(sizes, size)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(sizes)))
+
+	packs := make(map[int]int, len(proposed))
+	for _, size := range sizes {
+		quantity := proposed[size]
+		switch {
+		case !configured[size]:
+			verification.Violations = append(verification.Violations, entities.RuleViolation{
+				Rule:    entities.RuleWholePacks,
+				Message: fmt.Sprintf("pack size %d is not configured", size),
+			})
+		case quantity < 0 || quantity != math.Trunc(quantity):
+			verification.Violations = append(verification.Violations, entities.RuleViolation{
+				Rule:    entities.RuleWholePacks,
+				Message: fmt.Sprintf("%v packs of size %d is not a whole number of packs", quantity, size),
+			})
+		case quantity > 0:
+			packs[size] = int(quantity)
+		}
+	}
+	if !verification.Valid() {
+		return verification
+	}
+
+	verification.Proposed = entities.NewCalculationResult(itemsOrdered, packs)
+	items, count := verification.Proposed.TotalItems, verification.Proposed.TotalPacks()
+	optimalItems, optimalPacks := verification.Optimal.TotalItems, verification.Optimal.TotalPacks()
+
+	// Rule 2: the fewest items fulfilling the order
+	switch {
+	case items < itemsOrdered:
+		verification.Violations = append(verification.Violations, entities.RuleViolation{
+			Rule:    entities.RuleFewestItems,
+			Message: fmt.Sprintf("ships %d items, fewer than the %d ordered", items, itemsOrdered),
+		})
+	case items > optimalItems:
+		verification.Violations = append(verification.Violations, entities.RuleViolation{
+			Rule:    entities.RuleFewestItems,
+			Message: fmt.Sprintf("ships %d items, %d more than the optimal %d", items, items-optimalItems, optimalItems),
+		})
+	case count > optimalPacks:
+		// Rule 3: as few packs as possible
+		verification.Violations = append(verification.Violations, entities.RuleViolation{
+			Rule:    entities.RuleFewestPacks,
+			Message: fmt.Sprintf("uses %d packs, %d more than the optimal %d", count, count-optimalPacks, optimalPacks),
+		})
+	}
+
+	return verification
+}
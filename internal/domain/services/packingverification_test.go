@@ -0,0 +1,95 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"go-pack-calculator/internal/domain/entities"
+)
+
+func TestPackCalculatorService_VerifyPacking(t *testing.T) {
+	service := NewPackCalculatorService()
+	packSizes := []int{250, 500, 1000, 2000, 5000}
+
+	tests := []struct {
+		name         string
+		itemsOrdered int
+		proposed     map[int]float64
+		optimal      map[int]int
+		wantRules    []entities.PackingRule
+	}{
+		{
+			name:         "Optimal packing",
+			itemsOrdered: 501,
+			proposed:     map[int]float64{500: 1, 250: 1},
+			optimal:      map[int]int{500: 1, 250: 1},
+		},
+		{
+			name:         "Zero quantities are ignored",
+			itemsOrdered: 251,
+			proposed:     map[int]float64{500: 1, 1000: 0},
+			optimal:      map[int]int{500: 1},
+		},
+		{
+			name:         "Unconfigured pack size",
+			itemsOrdered: 501,
+			proposed:     map[int]float64{750: 1},
+			optimal:      map[int]int{500: 1, 250: 1},
+			wantRules:    []entities.PackingRule{entities.RuleWholePacks},
+		},
+		{
+			name:         "Broken and negative packs",
+			itemsOrdered: 501,
+			proposed:     map[int]float64{500: 1.5, 250: -1},
+			optimal:      map[int]int{500: 1, 250: 1},
+			wantRules:    []entities.PackingRule{entities.RuleWholePacks, entities.RuleWholePacks},
+		},
+		{
+			name:         "Order not fulfilled",
+			itemsOrdered: 501,
+			proposed:     map[int]float64{500: 1},
+			optimal:      map[int]int{500: 1, 250: 1},
+			wantRules:    []entities.PackingRule{entities.RuleFewestItems},
+		},
+		{
+			name:         "Too many items",
+			itemsOrdered: 251,
+			proposed:     map[int]float64{1000: 1},
+			optimal:      map[int]int{500: 1},
+			wantRules:    []entities.PackingRule{entities.RuleFewestItems},
+		},
+		{
+			name:         "Too many packs",
+			itemsOrdered: 251,
+			proposed:     map[int]float64{250: 2},
+			optimal:      map[int]int{500: 1},
+			wantRules:    []entities.PackingRule{entities.RuleFewestPacks},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			verification := service.VerifyPacking(tt.itemsOrdered, tt.proposed, packSizes, tt.optimal)
+
+			var rules []entities.PackingRule
+			for _, violation := range verification.Violations {
+				rules = append(rules, violation.Rule)
+			}
+			if !reflect.DeepEqual(rules, tt.wantRules) {
+				t.Errorf("VerifyPacking() violations = %+v, want rules %v", verification.Violations, tt.wantRules)
+			}
+			if verification.Valid() != (len(tt.wantRules) == 0) {
+				t.Errorf("VerifyPacking() valid = %v with %d violations", verification.Valid(), len(tt.wantRules))
+			}
+			if !reflect.DeepEqual(verification.Optimal.Packs, tt.optimal) {
+				t.Errorf("VerifyPacking() optimal = %v, want %v", verification.Optimal.Packs, tt.optimal)
+			}
+
+			// The proposed packing is only summarised when it follows rule 1
+			breaksRule1 := len(tt.wantRules) > 0 && tt.wantRules[0] == entities.RuleWholePacks
+			if (verification.Proposed == nil) != breaksRule1 {
+				t.Errorf("VerifyPacking() proposed = %v, breaks rule 1 = %v", verification.Proposed, breaksRule1)
+			}
+		})
+	}
+}
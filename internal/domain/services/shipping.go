@@ -0,0 +1,49 @@
+package services
+
+import (
+	"sort"
+
+	"go-pack-calculator/internal/domain/entities"
+)
+
+// ShippingCostService estimates the cost of shipping a packing from carrier rate tables
+type ShippingCostService struct{}
+
+// NewShippingCostService creates a new shipping cost service
+func NewShippingCostService() *ShippingCostService {
+	return &ShippingCostService{}
+}
+
+// Estimate returns the price of the cheapest rate covering the shipment for every carrier
+// that can ship it, cheapest carrier first. Carriers without a covering rate are left out.
+func (s *ShippingCostService) Estimate(weight float64, parcels int, rates []*entities.ShippingRate) []entities.ShippingEstimate {
+	cheapest := make(map[string]float64)
+	for _, rate := range rates {
+		if !rate.Covers(weight, parcels) {
+			continue
+		}
+
+		if price, ok := cheapest[rate.Carrier]; !ok || rate.Price < price {
+			cheapest[rate.Carrier] = rate.Price
+		}
+	}
+
+	estimates := make([]entities.ShippingEstimate, 0, len(cheapest))
+	for carrier, price := range cheapest {
+		estimates = append(estimates, entities.ShippingEstimate{
+			Carrier: carrier,
+			Price:   price,
+		})
+	}
+
+	// Sort by price, then carrier name for a stable order
+	sort.Slice(estimates, func(i, j int) bool {
+		if estimates[i].Price != estimates[j].Price {
+			return estimates[i].Price < estimates[j].Price
+		}
+
+		return estimates[i].Carrier < estimates[j].Carrier
+	})
+
+	return estimates
+}
@@ -0,0 +1,70 @@
+package services
+
+import (
+	"reflect"
+	"testing"
+
+	"go-pack-calculator/internal/domain/entities"
+)
+
+func TestShippingCostService_Estimate(t *testing.T) {
+	service := NewShippingCostService()
+
+	newRate := func(carrier string, maxWeight float64, maxParcels int, price float64) *entities.ShippingRate {
+		rate, err := entities.NewShippingRate(carrier, maxWeight, maxParcels, price)
+		if err != nil {
+			t.Fatalf("Failed to create test shipping rate: %v", err)
+		}
+		return rate
+	}
+
+	rates := []*entities.ShippingRate{
+		newRate("DHL", 10, 2, 8),
+		newRate("DHL", 30, 5, 15),
+		newRate("UPS", 20, 3, 9),
+		newRate("UPS", 50, 10, 25),
+		newRate("GLS", 5, 1, 4),
+	}
+
+	tests := []struct {
+		name    string
+		weight  float64
+		parcels int
+		want    []entities.ShippingEstimate
+	}{
+		{
+			name:    "Light single parcel",
+			weight:  4,
+			parcels: 1,
+			want: []entities.ShippingEstimate{
+				{Carrier: "GLS", Price: 4},
+				{Carrier: "DHL", Price: 8},
+				{Carrier: "UPS", Price: 9},
+			},
+		},
+		{
+			name:    "Heavier shipment",
+			weight:  25,
+			parcels: 4,
+			want: []entities.ShippingEstimate{
+				{Carrier: "DHL", Price: 15},
+				{Carrier: "UPS", Price: 25},
+			},
+		},
+		{
+			name:    "No carrier can ship",
+			weight:  100,
+			parcels: 1,
+			want:    []entities.ShippingEstimate{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := service.Estimate(tt.weight, tt.parcels, rates)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Estimate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
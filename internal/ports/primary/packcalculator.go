@@ -1,21 +1,61 @@
 package primary
 
 import (
+	"context"
 	"go-pack-calculator/internal/domain/entities"
 	"go-pack-calculator/internal/shared/types"
 )
 
 // PackSizeService defines the interface for pack size operations
 type PackSizeService interface {
-	CreatePackSize(size int) (*entities.PackSize, error)
-	GetAllPackSizes() ([]*entities.PackSize, error)
-	GetAllPackSizesWithPagination(page, limit int64) (*types.Pagination, error)
-	GetPackSizeByID(id string) (*entities.PackSize, error)
-	UpdatePackSize(id string, size int) (*entities.PackSize, error)
-	DeletePackSize(id string) error
+	CreatePackSize(ctx context.Context, size int, attributes entities.PackSizeAttributes) (*entities.PackSize, error)
+	CreatePackSizes(ctx context.Context, definitions []entities.PackSizeDefinition) ([]*entities.PackSize, error)
+	ReplacePackSizes(ctx context.Context, definitions []entities.PackSizeDefinition) (*entities.PackSizeChanges, error)
+	GetAllPackSizes(ctx context.Context) ([]*entities.PackSize, error)
+	GetAllPackSizesWithPagination(ctx context.Context, page, limit int64) (*types.Pagination, error)
+	GetPackSizeByID(ctx context.Context, id string) (*entities.PackSize, error)
+	UpdatePackSize(ctx context.Context, id string, version int64, size int, attributes entities.PackSizeAttributes) (*entities.PackSize, error)
+	DeletePackSize(ctx context.Context, id string, version int64) error
+	DeletePackSizes(ctx context.Context, ids []string) error
+	GetDeletedPackSizes(ctx context.Context) ([]*entities.PackSize, error)
+	RestorePackSize(ctx context.Context, id string) (*entities.PackSize, error)
+}
+
+// PackSizeTransferService defines the interface for exporting and importing pack sizes
+type PackSizeTransferService interface {
+	ExportPackSizes(ctx context.Context) ([]*entities.PackSize, error)
+	ImportPackSizes(ctx context.Context, rows []entities.PackSizeImportRow, strategy entities.ImportStrategy, dryRun bool) (*entities.PackSizeImportReport, error)
 }
 
 // CalculationService defines the interface for calculation operations
 type CalculationService interface {
-	CalculatePacksForOrder(itemsOrdered int) (*entities.CalculationResult, error)
+	CalculatePacksForOrder(ctx context.Context, itemsOrdered int, options entities.CalculationOptions) (*entities.CalculationResult, error)
+	ConsolidateOrders(ctx context.Context, orders []entities.Order) (*entities.ConsolidationResult, error)
+	PackOrderIntoBoxes(ctx context.Context, itemsOrdered int, boxes []entities.Box) (*entities.BoxPackingResult, error)
+	CalculatePackingTable(ctx context.Context, from, to, step int) (*entities.CalculationTable, error)
+	VerifyPacking(ctx context.Context, itemsOrdered int, proposed map[int]float64) (*entities.PackingVerification, error)
+	GetCalculationByID(ctx context.Context, id string) (*entities.CalculationResult, error)
+	GeneratePackingSlip(ctx context.Context, calculationID string, format entities.DocumentFormat) (*entities.Document, error)
+}
+
+// PackSetComparisonService defines the interface for comparing pack size sets
+type PackSetComparisonService interface {
+	ComparePackSets(ctx context.Context, current, candidate []int, demand []entities.QuantityDemand) (*entities.PackSetComparison, error)
+}
+
+// CalculationCacheService defines the interface for inspecting the calculation cache
+type CalculationCacheService interface {
+	GetCacheStats(ctx context.Context) entities.CacheStats
+}
+
+// ShippingRateService defines the interface for carrier rate table operations
+type ShippingRateService interface {
+	ImportShippingRates(ctx context.Context, rates []*entities.ShippingRate) ([]*entities.ShippingRate, error)
+	GetAllShippingRates(ctx context.Context) ([]*entities.ShippingRate, error)
+}
+
+// PackSizeAuditService defines the interface for reading the audit trail of pack sizes
+type PackSizeAuditService interface {
+	GetPackSizeHistory(ctx context.Context, id string) ([]*entities.PackSizeAuditEntry, error)
+	GetAuditFeed(ctx context.Context, page, limit int64) (*types.Pagination, error)
 }
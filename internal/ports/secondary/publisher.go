@@ -0,0 +1,13 @@
+package secondary
+
+import (
+	"context"
+	"go-pack-calculator/internal/domain/entities"
+)
+
+// EventPublisher defines the interface for delivering pack size events to a downstream system.
+// Publish returns once the system has accepted the event, or an error to deliver it again later.
+type EventPublisher interface {
+	Name() string
+	Publish(ctx context.Context, event *entities.PackSizeEvent) error
+}
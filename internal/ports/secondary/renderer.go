@@ -0,0 +1,12 @@
+package secondary
+
+import (
+	"context"
+	"go-pack-calculator/internal/domain/entities"
+)
+
+// PackingSlipRenderer defines the interface for rendering a packing slip into a printable document
+type PackingSlipRenderer interface {
+	Format() entities.DocumentFormat
+	Render(ctx context.Context, slip *entities.PackingSlip) (*entities.Document, error)
+}
@@ -1,15 +1,77 @@
 package secondary
 
 import (
+	"context"
+	"time"
+
 	"go-pack-calculator/internal/domain/entities"
 )
 
-// PackSizeRepository defines the interface for pack size repository operations
+// PackSizeRepository defines the interface for pack size repository operations. Create, Update,
+// Delete and Restore record an audit entry of the change, attributed to the request info of the
+// context, atomically with the change itself.
+//
+// Every change increments the version of a pack size. Update only writes when the stored version
+// is still the version of the given pack size and Delete when it is the given version, or any
+// version when zero; otherwise they return ErrVersionMismatch.
 type PackSizeRepository interface {
-	Create(packSize *entities.PackSize) (*entities.PackSize, error)
-	FindAll() ([]*entities.PackSize, error)
-	FindAllPaginated(page, limit int64) ([]*entities.PackSize, int64, error)
-	FindByID(id string) (*entities.PackSize, error)
-	Update(packSize *entities.PackSize) (*entities.PackSize, error)
-	Delete(id string) error
+	Create(ctx context.Context, packSize *entities.PackSize) (*entities.PackSize, error)
+	FindAll(ctx context.Context) ([]*entities.PackSize, error)
+	FindAllPaginated(ctx context.Context, page, limit int64) ([]*entities.PackSize, int64, error)
+	FindByID(ctx context.Context, id string) (*entities.PackSize, error)
+	Update(ctx context.Context, packSize *entities.PackSize) (*entities.PackSize, error)
+	Delete(ctx context.Context, id string, version int64) error
+	FindDeleted(ctx context.Context) ([]*entities.PackSize, error)
+	Restore(ctx context.Context, id string) (*entities.PackSize, error)
+	PurgeDeleted(ctx context.Context, before time.Time) (int64, error)
+}
+
+// PackSizeAuditRepository defines the interface for reading the audit trail of pack size changes
+type PackSizeAuditRepository interface {
+	FindByPackSizeID(ctx context.Context, packSizeID string) ([]*entities.PackSizeAuditEntry, error)
+	FindAllPaginated(ctx context.Context, page, limit int64) ([]*entities.PackSizeAuditEntry, int64, error)
+}
+
+// OutboxRepository defines the interface for the outbox of pack size events. Events added in a
+// transaction are only saved with its changes. ClaimPending returns the undelivered entries due
+// for delivery, oldest first, and hides them from other claims for the lease, so entries of a
+// relay that stopped before marking them are delivered again once it expires.
+type OutboxRepository interface {
+	Add(ctx context.Context, events ...*entities.PackSizeEvent) error
+	ClaimPending(ctx context.Context, limit int, lease time.Duration) ([]*entities.OutboxEntry, error)
+	MarkDelivered(ctx context.Context, id string) error
+	MarkFailed(ctx context.Context, id string, reason string, nextAttemptAt time.Time) error
+	MarkDead(ctx context.Context, id string, reason string) error
+}
+
+// Transaction gives the repositories of a unit of work, all bound to one transaction
+type Transaction interface {
+	PackSizes() PackSizeRepository
+	Outbox() OutboxRepository
+}
+
+// TransactionManager runs work in a transaction: every change the work makes through the
+// repositories of the transaction is saved, or none when it returns an error. Other requests do
+// not see the changes before the work completes, nor change the pack sizes the work has read.
+type TransactionManager interface {
+	WithinTransaction(ctx context.Context, work func(tx Transaction) error) error
+}
+
+// CalculationRepository defines the interface for calculation result repository operations
+type CalculationRepository interface {
+	Create(ctx context.Context, result *entities.CalculationResult) (*entities.CalculationResult, error)
+	FindByID(ctx context.Context, id string) (*entities.CalculationResult, error)
+	PurgeBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// ShippingRateRepository defines the interface for carrier rate table repository operations
+type ShippingRateRepository interface {
+	ReplaceAll(ctx context.Context, rates []*entities.ShippingRate) ([]*entities.ShippingRate, error)
+	FindAll(ctx context.Context) ([]*entities.ShippingRate, error)
+}
+
+// PackingTableRepository defines the interface for precomputed packing table repository operations
+type PackingTableRepository interface {
+	Save(ctx context.Context, table *entities.PackingTable) error
+	FindByKey(ctx context.Context, key string) (*entities.PackingTable, error)
 }
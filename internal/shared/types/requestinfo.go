@@ -0,0 +1,24 @@
+package types
+
+import "context"
+
+// RequestInfo identifies who made a request, for the audit trail of the changes it makes
+type RequestInfo struct {
+	ClaimedActor string // Self-reported by the client, not authenticated
+	RequestID    string
+}
+
+// requestInfoKey is the context key of the request info
+type requestInfoKey struct{}
+
+// WithRequestInfo returns a copy of the context carrying the request info
+func WithRequestInfo(ctx context.Context, info RequestInfo) context.Context {
+	return context.WithValue(ctx, requestInfoKey{}, info)
+}
+
+// RequestInfoFromContext returns the request info of the context, the zero value when it has none
+func RequestInfoFromContext(ctx context.Context) RequestInfo {
+	info, _ := ctx.Value(requestInfoKey{}).(RequestInfo)
+
+	return info
+}
@@ -0,0 +1,18 @@
+package types
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRequestInfo(t *testing.T) {
+	// A context without request info
+	assert.Equal(t, RequestInfo{}, RequestInfoFromContext(context.Background()))
+
+	// A context carrying request info
+	info := RequestInfo{ClaimedActor: "alice", RequestID: "req-1"}
+	ctx := WithRequestInfo(context.Background(), info)
+	assert.Equal(t, info, RequestInfoFromContext(ctx))
+}